@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/repostats"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
+)
+
+const (
+	// deleteDueUsersJob purges the accounts past their deletion grace period.
+	deleteDueUsersJob jobs.Kind[struct{}] = "users.delete_due"
+	// deleteExpiredUserSessionsJob purges the metadata of expired sessions.
+	deleteExpiredUserSessionsJob jobs.Kind[struct{}] = "user_sessions.delete_expired"
+	// enrichRepositoriesJob fetches the stats of the GitHub repositories of projects that are
+	// missing or stale.
+	enrichRepositoriesJob jobs.Kind[struct{}] = "projects.enrich_repositories"
+
+	// repositoryEnrichmentInterval is how often projects are enriched with the stats of their
+	// GitHub repository when the configuration leaves it unset.
+	repositoryEnrichmentInterval = 15 * time.Minute
+)
+
+// newWorker creates a job worker that handles and schedules the periodic jobs of the API.
+func newWorker(logger *slog.Logger, cfg awesomemy.Config, st store.Store) (*jobs.Worker, error) {
+	w := jobs.NewWorker(logger, cfg.Jobs, st)
+
+	// Users due for deletion are deleted along with their projects, events and sessions by
+	// the ON DELETE CASCADE relations.
+	jobs.Handle(w, deleteDueUsersJob, func(ctx context.Context, _ struct{}) error {
+		userIDs, err := st.DeleteDueUsers(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if len(userIDs) > 0 {
+			logger.Info("deleted users due for deletion", slog.Int("count", len(userIDs)))
+		}
+
+		return nil
+	})
+	if err := jobs.Schedule(w, "*/10 * * * *", deleteDueUsersJob, struct{}{}); err != nil {
+		return nil, err
+	}
+
+	jobs.Handle(w, deleteExpiredUserSessionsJob, func(ctx context.Context, _ struct{}) error {
+		return st.DeleteExpiredUserSessions(ctx, time.Now())
+	})
+	if err := jobs.Schedule(w, "@hourly", deleteExpiredUserSessionsJob, struct{}{}); err != nil {
+		return nil, err
+	}
+
+	if err := webhooks.Register(w, webhooks.NewDeliverer(logger, cfg.Webhooks, st)); err != nil {
+		return nil, err
+	}
+
+	transport, err := notify.NewTransport(logger, cfg.Mail)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := notify.NewNotifier(logger, cfg, st, transport)
+	if err != nil {
+		return nil, err
+	}
+	notify.Register(w, notifier)
+
+	if cfg.GitHub.Enrichment.Enabled {
+		enricher, err := repostats.NewEnricher(logger, cfg.GitHub, st)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs.Handle(w, enrichRepositoriesJob, func(ctx context.Context, _ struct{}) error {
+			n, err := enricher.Enrich(ctx)
+			if err != nil {
+				return err
+			}
+
+			if n > 0 {
+				logger.Info("enriched project repositories", slog.Int("count", n))
+			}
+
+			return nil
+		})
+
+		interval := cfg.GitHub.Enrichment.Interval.Duration
+		if interval == 0 {
+			interval = repositoryEnrichmentInterval
+		}
+		if err := jobs.Schedule(w, "@every "+interval.String(), enrichRepositoriesJob, struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
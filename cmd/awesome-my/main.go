@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 
@@ -18,13 +20,32 @@ func main() {
 				level = slog.LevelDebug
 			}
 
-			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			opts := &slog.HandlerOptions{
 				Level: level,
-			}))
+			}
+
+			var logHandler slog.Handler
+			switch cliCtx.String("log-format") {
+			case "text":
+				logHandler = slog.NewTextHandler(os.Stdout, opts)
+			case "json":
+				logHandler = slog.NewJSONHandler(os.Stdout, opts)
+			default:
+				return fmt.Errorf("unknown log format %q, expected json or text", cliCtx.String("log-format"))
+			}
+
+			logger := slog.New(logHandler)
 
-			cfg, err := awesomemy.ParseConfigFromFile("./config.yaml")
+			// Without an explicit --config, a missing default file leaves the configuration
+			// to the environment variables.
+			fp := cliCtx.String("config")
+			if _, err := os.Stat(fp); errors.Is(err, os.ErrNotExist) && !cliCtx.IsSet("config") {
+				fp = ""
+			}
+
+			cfg, err := awesomemy.LoadConfig(fp)
 			if err != nil {
-				logger.Error("could not parse configuration from file", slog.Any("err", err))
+				logger.Error("could not load configuration", slog.String("path", fp), slog.Any("err", err))
 				os.Exit(1)
 			}
 
@@ -36,6 +57,9 @@ func main() {
 		Commands: []*cli.Command{
 			newServeCommand(),
 			newMigrateCommand(),
+			newSeedCommand(),
+			newWorkerCommand(),
+			newJobsCommand(),
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -43,6 +67,17 @@ func main() {
 				Usage: "record level that will be logged.",
 				Value: false,
 			},
+			&cli.StringFlag{
+				Name:    "config",
+				Usage:   "path of the configuration file.",
+				Value:   "./config.yaml",
+				EnvVars: []string{"AWESOMEMY_CONFIG"},
+			},
+			&cli.StringFlag{
+				Name:  "log-format",
+				Usage: "format of the logs, either json or text.",
+				Value: "text",
+			},
 		},
 	}).Run(os.Args)
 }
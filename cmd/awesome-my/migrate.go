@@ -1,41 +1,381 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
+	"github.com/pressly/goose/v3"
 	"github.com/urfave/cli/v2"
 )
 
+// migrationTemplate matches the layout of the existing migrations, which use CRLF line endings.
+const migrationTemplate = "-- +goose Up\r\n-- +goose StatementBegin\r\n\r\n-- +goose StatementEnd\r\n\r\n-- +goose Down\r\n-- +goose StatementBegin\r\n\r\n-- +goose StatementEnd\r\n"
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 func newMigrateCommand() *cli.Command {
+	dryRunFlag := &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the SQL of the migrations that would run without applying them.",
+	}
+
 	return &cli.Command{
-		Name: "migrate",
-		Action: func(cliCtx *cli.Context) error {
-			logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
-			cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
+		Name:   "migrate",
+		Usage:  "apply all pending migrations, or manage them with a subcommand.",
+		Flags:  []cli.Flag{dryRunFlag},
+		Action: migrateUp,
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "apply pending migrations.",
+				Flags: []cli.Flag{
+					dryRunFlag,
+					&cli.Int64Flag{
+						Name:  "to",
+						Usage: "apply pending migrations up to and including this version.",
+					},
+				},
+				Action: migrateUp,
+			},
+			{
+				Name:  "down",
+				Usage: "roll back the latest migration.",
+				Flags: []cli.Flag{
+					dryRunFlag,
+					&cli.Int64Flag{
+						Name:  "to",
+						Usage: "roll back migrations until this version is the latest applied.",
+						Value: -1,
+					},
+				},
+				Action: migrateDown,
+			},
+			{
+				Name:   "redo",
+				Usage:  "roll back and reapply the latest migration.",
+				Flags:  []cli.Flag{dryRunFlag},
+				Action: migrateRedo,
+			},
+			{
+				Name:   "status",
+				Usage:  "list the migrations and whether they are applied.",
+				Action: migrateStatus,
+			},
+			{
+				Name:   "version",
+				Usage:  "print the version of the database.",
+				Action: migrateVersion,
+			},
+			{
+				Name:      "create",
+				Usage:     "create a new SQL migration.",
+				ArgsUsage: "<name>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "dir",
+						Usage: "directory the migration is created in.",
+						Value: database.MigrationsDir,
+					},
+				},
+				Action: migrateCreate,
+			},
+		},
+	}
+}
+
+// withMigrator opens the database and calls fn with a migrator over it.
+func withMigrator(cliCtx *cli.Context, fn func(logger *slog.Logger, migrator *goose.Provider) error) error {
+	logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+	cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
+
+	mustValidateDatabaseConfig(logger, cfg)
+
+	// Migrations may legitimately run longer than the statement timeout of the API.
+	cfg.Postgres.StatementTimeout = awesomemy.Duration{}
+
+	logger.Debug("opening a connection to postgres database")
+	db, err := database.Open(cfg.Postgres.DSN())
+	if err != nil {
+		logger.Error("could not initialize postgres database", slog.Any("err", err))
+		os.Exit(1)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+	cfg.Postgres.ConfigurePool(db)
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		logger.Error("could not initialize migrator", slog.Any("err", err))
+		os.Exit(1)
+	}
 
-			logger.Info("opening a connection to postgres database")
-			db, err := sql.Open("postgres", cfg.Postgres.DSN())
+	if err := fn(logger, migrator); err != nil {
+		logger.Error("could not migrate postgres database", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func migrateUp(cliCtx *cli.Context) error {
+	return withMigrator(cliCtx, func(logger *slog.Logger, migrator *goose.Provider) error {
+		to := cliCtx.Int64("to")
+
+		if cliCtx.Bool("dry-run") {
+			statuses, err := migrator.Status(cliCtx.Context)
 			if err != nil {
-				logger.Error("could not initialize postgres database", slog.Any("err", err))
-				os.Exit(1)
+				return err
 			}
-			defer func(db *sql.DB) {
-				_ = db.Close()
-			}(db)
 
-			logger.Info("migrating postgres database")
-			if err := database.Migrate(db); err != nil {
-				logger.Error("could not migrate postgres database", slog.Any("err", err))
-				os.Exit(1)
+			var pending []*goose.Source
+			for _, s := range statuses {
+				if s.State == goose.StatePending && (to == 0 || s.Source.Version <= to) {
+					pending = append(pending, s.Source)
+				}
 			}
 
-			return nil
-		},
+			return printMigrations(logger, pending, true)
+		}
+
+		var results []*goose.MigrationResult
+		var err error
+		if to > 0 {
+			results, err = migrator.UpTo(cliCtx.Context, to)
+		} else {
+			results, err = migrator.Up(cliCtx.Context)
+		}
+		logMigrationResults(logger, results, err)
+		if err != nil {
+			return err
+		}
+
+		if len(results) == 0 {
+			logger.Info("no pending migrations")
+		}
+
+		return nil
+	})
+}
+
+func migrateDown(cliCtx *cli.Context) error {
+	return withMigrator(cliCtx, func(logger *slog.Logger, migrator *goose.Provider) error {
+		to := cliCtx.Int64("to")
+
+		if cliCtx.Bool("dry-run") {
+			applied, err := appliedMigrations(cliCtx.Context, migrator)
+			if err != nil {
+				return err
+			}
+
+			var rollback []*goose.Source
+			for _, source := range applied {
+				if (to < 0 && len(rollback) == 1) || (to >= 0 && source.Version <= to) {
+					break
+				}
+				rollback = append(rollback, source)
+			}
+
+			return printMigrations(logger, rollback, false)
+		}
+
+		var results []*goose.MigrationResult
+		var err error
+		if to >= 0 {
+			results, err = migrator.DownTo(cliCtx.Context, to)
+		} else {
+			var result *goose.MigrationResult
+			result, err = migrator.Down(cliCtx.Context)
+			if result != nil {
+				results = append(results, result)
+			}
+		}
+		logMigrationResults(logger, results, err)
+
+		return err
+	})
+}
+
+func migrateRedo(cliCtx *cli.Context) error {
+	return withMigrator(cliCtx, func(logger *slog.Logger, migrator *goose.Provider) error {
+		applied, err := appliedMigrations(cliCtx.Context, migrator)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return errors.New("no migration has been applied")
+		}
+
+		if cliCtx.Bool("dry-run") {
+			if err := printMigrations(logger, applied[:1], false); err != nil {
+				return err
+			}
+
+			return printMigrations(logger, applied[:1], true)
+		}
+
+		down, err := migrator.ApplyVersion(cliCtx.Context, applied[0].Version, false)
+		logMigrationResults(logger, []*goose.MigrationResult{down}, err)
+		if err != nil {
+			return err
+		}
+
+		up, err := migrator.ApplyVersion(cliCtx.Context, applied[0].Version, true)
+		logMigrationResults(logger, []*goose.MigrationResult{up}, err)
+
+		return err
+	})
+}
+
+func migrateStatus(cliCtx *cli.Context) error {
+	return withMigrator(cliCtx, func(logger *slog.Logger, migrator *goose.Provider) error {
+		statuses, err := migrator.Status(cliCtx.Context)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			attrs := []any{
+				slog.Int64("version", s.Source.Version),
+				slog.String("migration", s.Source.Path),
+				slog.String("state", string(s.State)),
+			}
+			if s.State == goose.StateApplied {
+				attrs = append(attrs, slog.Time("applied_at", s.AppliedAt))
+			}
+
+			logger.Info("migration status", attrs...)
+		}
+
+		return nil
+	})
+}
+
+func migrateVersion(cliCtx *cli.Context) error {
+	return withMigrator(cliCtx, func(logger *slog.Logger, migrator *goose.Provider) error {
+		version, err := migrator.GetDBVersion(cliCtx.Context)
+		if err != nil {
+			return err
+		}
+
+		var latest int64
+		if sources := migrator.ListSources(); len(sources) > 0 {
+			latest = sources[len(sources)-1].Version
+		}
+
+		logger.Info("database version", slog.Int64("version", version), slog.Int64("latest", latest))
+
+		return nil
+	})
+}
+
+func migrateCreate(cliCtx *cli.Context) error {
+	logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+
+	name := cliCtx.Args().First()
+	if !migrationNamePattern.MatchString(name) {
+		logger.Error("migration name must be snake_case, e.g. add_members_table", slog.String("name", name))
+		os.Exit(1)
+	}
+
+	fp := filepath.Join(cliCtx.String("dir"), time.Now().UTC().Format("20060102150405")+"_"+name+".sql")
+	f, err := os.OpenFile(fp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		logger.Error("could not create migration", slog.Any("err", err))
+		os.Exit(1)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	if _, err := f.WriteString(migrationTemplate); err != nil {
+		logger.Error("could not write migration", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	logger.Info("created migration", slog.String("path", fp))
+
+	return nil
+}
+
+// appliedMigrations returns the applied migrations, latest first.
+func appliedMigrations(ctx context.Context, migrator *goose.Provider) ([]*goose.Source, error) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []*goose.Source
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if statuses[i].State == goose.StateApplied {
+			applied = append(applied, statuses[i].Source)
+		}
+	}
+
+	return applied, nil
+}
+
+// printMigrations writes the SQL of the given migrations to stdout without applying them.
+func printMigrations(logger *slog.Logger, sources []*goose.Source, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	if len(sources) == 0 {
+		logger.Info("dry run, no migrations to run", slog.String("direction", direction))
+		return nil
+	}
+
+	for _, source := range sources {
+		query, err := database.MigrationSQL(source, up)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("dry run, migration would run", slog.Int64("version", source.Version), slog.String("direction", direction))
+		fmt.Fprintf(os.Stdout, "-- %s (%s)\n%s\n\n", source.Path, direction, strings.TrimSpace(query))
+	}
+
+	return nil
+}
+
+// logMigrationResults logs the outcome of every migration that ran, including those applied
+// before a migration failed.
+func logMigrationResults(logger *slog.Logger, results []*goose.MigrationResult, err error) {
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = append(partial.Applied, partial.Failed)
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		attrs := []any{
+			slog.Int64("version", result.Source.Version),
+			slog.String("migration", result.Source.Path),
+			slog.String("direction", result.Direction),
+			slog.Duration("duration", result.Duration),
+		}
+		if result.Error != nil {
+			logger.Error("migration failed", append(attrs, slog.Any("err", result.Error))...)
+			continue
+		}
+
+		logger.Info("migration applied", attrs...)
 	}
 }
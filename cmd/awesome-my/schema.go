@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/pressly/goose/v3"
+)
+
+// schemaCheckWarn lets serve start with pending migrations, logging them instead.
+const schemaCheckWarn = "warn"
+
+// prepareSchema applies the pending migrations when migrate is set, otherwise it compares the
+// embedded migrations with the database and refuses to start when migrations are pending,
+// unless the schema check is relaxed to a warning.
+func prepareSchema(ctx context.Context, logger *slog.Logger, cfg awesomemy.PostgresConfig, migrate bool) error {
+	// Migrations may legitimately run longer than the statement timeout of the API.
+	cfg.StatementTimeout = awesomemy.Duration{}
+
+	db, err := database.Open(cfg.DSN())
+	if err != nil {
+		return err
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if migrate {
+		logger.Info("applying pending migrations")
+		results, err := migrator.Up(ctx)
+		logMigrationResults(logger, results, err)
+
+		return err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []*goose.Source
+	for _, s := range statuses {
+		if s.State == goose.StatePending {
+			pending = append(pending, s.Source)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, source := range pending {
+		logger.Warn("migration is pending", slog.Int64("version", source.Version), slog.String("migration", source.Path))
+	}
+
+	if cfg.SchemaCheck == schemaCheckWarn {
+		logger.Warn("starting with pending migrations, run awesome-my migrate or serve --migrate")
+		return nil
+	}
+
+	return fmt.Errorf("%d migrations are pending, run awesome-my migrate or serve --migrate", len(pending))
+}
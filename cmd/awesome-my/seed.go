@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/gobuffalo/nulls"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// seedMaxProjects and seedMaxEvents stay well below the quotas enforced by the API.
+	seedMaxProjects = 5
+	seedMaxEvents   = 4
+	// seedEventSpread is how far before and after today seeded events are spread.
+	seedEventSpread = 180 * 24 * time.Hour
+)
+
+var (
+	seedFirstNames = []string{
+		"aisyah", "weijie", "arjun", "nurul", "kumar", "meiling", "hafiz", "siti", "ravi", "jiahui",
+		"farah", "kahwai", "priya", "amir", "xinyi", "danial", "kavitha", "irfan", "sookmun", "zul",
+	}
+	// seedTags, project names and descriptions satisfy the validation of the client API so
+	// seeded data can be edited as is.
+	seedTags = []string{
+		"fintech", "e-wallet", "takaful", "halal-tech", "agritech", "edtech", "healthtech",
+		"proptech", "govtech", "e-commerce", "logistics", "ride-hailing", "electronics", "smart-city",
+		"machinelearn", "open-source", "golang", "flutter", "web3", "tourism", "bahasa", "mdec",
+		"kuala-lumpur", "penang", "cyberjaya", "johor-bahru", "sabah", "sarawak",
+	}
+	seedProjectPrefixes = []string{
+		"Kedai", "Pasar", "Lepak", "Mamak", "Durian", "TehTarik", "Kampung", "Batik", "Rimba",
+		"Rakyat", "Tapau", "Ombak", "Selera",
+	}
+	seedProjectSuffixes = []string{
+		"Pay", "Hub", "Connect", "Labs", "Cloud", "Track", "Match", "Ride", "Learn", "Finder",
+		"Works", "Space",
+	}
+	seedProjectPitches = []string{
+		"helps hawkers accept cashless payments without extra hardware",
+		"connects smallholder farmers with buyers across the peninsula",
+		"tracks halal certification for food and cosmetics suppliers",
+		"matches fresh graduates with startups in the Klang Valley",
+		"brings bilingual lessons in Bahasa Melayu and English to rural schools",
+		"shortens clinic queues with appointments over WhatsApp",
+		"plans public transport trips across LRT, MRT and KTM lines",
+		"compares rental listings around universities",
+		"organises open-source contributors across Southeast Asia",
+		"monitors flood levels and sends early warnings to residents",
+	}
+	seedEventCities = []string{
+		"Kuala Lumpur", "Penang", "Cyberjaya", "Johor Bahru", "Kota Kinabalu", "Kuching", "Ipoh", "Melaka",
+	}
+	seedEventTopics = []string{
+		"Fintech", "Golang", "Flutter", "AI", "Open Source", "Startup", "Cloud Native", "Product", "Web3", "Data",
+	}
+	seedEventKinds = []string{"Meetup", "Summit", "Hackathon", "Conference", "Workshop"}
+)
+
+func newSeedCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "seed",
+		Usage: "fill the database with deterministic fake users, projects and events.",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "count",
+				Usage: "number of users to create, each with a few projects and events.",
+				Value: 10,
+			},
+			&cli.Uint64Flag{
+				Name:  "seed",
+				Usage: "seed of the generator, the same seed generates the same data.",
+				Value: 1,
+			},
+			&cli.BoolFlag{
+				Name:  "truncate",
+				Usage: "delete all users, projects, events and sessions before seeding.",
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+			cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
+
+			mustValidateDatabaseConfig(logger, cfg)
+
+			logger.Info("opening a connection to postgres database")
+			db, err := database.Open(cfg.Postgres.DSN())
+			if err != nil {
+				logger.Error("could not initialize postgres database", slog.Any("err", err))
+				os.Exit(1)
+			}
+			defer func(db *sql.DB) {
+				_ = db.Close()
+			}(db)
+
+			seeder := &seeder{
+				logger:  logger,
+				queries: database.New(),
+				rand:    rand.New(rand.NewPCG(cliCtx.Uint64("seed"), cliCtx.Uint64("seed"))),
+				today:   time.Now().UTC().Truncate(24 * time.Hour),
+			}
+
+			if err := seeder.run(cliCtx.Context, db, cliCtx.Int("count"), cliCtx.Bool("truncate")); err != nil {
+				logger.Error("could not seed postgres database", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			return nil
+		},
+	}
+}
+
+type seeder struct {
+	logger  *slog.Logger
+	queries *database.Queries
+	rand    *rand.Rand
+	today   time.Time
+}
+
+// run seeds count users in a single transaction, users that already exist are left untouched
+// so that seeding twice without truncating does not fail.
+func (s *seeder) run(ctx context.Context, db *sql.DB, count int, truncate bool) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	if truncate {
+		s.logger.Info("truncating users, projects, events and sessions")
+		if err := s.queries.TruncateAll(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	var users, projects, events int
+	for i := 1; i <= count; i++ {
+		// Every user draws from the generator, even when skipped, so the data of the other
+		// users does not depend on which users already exist.
+		email := fmt.Sprintf("%s.%03d@example.com", seedFirstNames[(i-1)%len(seedFirstNames)], i)
+		projectParams := s.projects()
+		eventParams := s.events()
+
+		_, err := s.queries.UserByGithubEmail(ctx, tx, email)
+		if err == nil {
+			s.logger.Debug("skipping existing user", slog.String("email", email))
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		user, err := s.queries.InsertUser(ctx, tx, email)
+		if err != nil {
+			return err
+		}
+		users++
+
+		for _, params := range projectParams {
+			params.UserID = user.UserID
+			if _, err := s.queries.InsertProject(ctx, tx, params); err != nil {
+				return err
+			}
+			projects++
+		}
+
+		for _, params := range eventParams {
+			params.UserID = user.UserID
+			if _, err := s.queries.InsertEvent(ctx, tx, params); err != nil {
+				return err
+			}
+			events++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.logger.Info(
+		"seeded postgres database",
+		slog.Int("users", users),
+		slog.Int("projects", projects),
+		slog.Int("events", events),
+	)
+
+	return nil
+}
+
+func (s *seeder) projects() []database.InsertProjectParams {
+	names := make(map[string]bool)
+
+	var projects []database.InsertProjectParams
+	for range s.rand.IntN(seedMaxProjects + 1) {
+		name := pick(s.rand, seedProjectPrefixes) + pick(s.rand, seedProjectSuffixes)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+
+		slug := strings.ToLower(name)
+		var repository, website nulls.String
+		if s.rand.IntN(3) > 0 {
+			repository = nulls.NewString("https://github.com/" + slug + "/" + slug)
+		}
+		if s.rand.IntN(2) == 0 {
+			website = nulls.NewString("https://" + slug + ".my")
+		}
+
+		projects = append(projects, database.InsertProjectParams{
+			Name:        name,
+			Description: name + " " + pick(s.rand, seedProjectPitches) + ".",
+			Tags:        s.tags(),
+			Repository:  repository,
+			Website:     website,
+		})
+	}
+
+	return projects
+}
+
+func (s *seeder) events() []database.InsertEventParams {
+	var events []database.InsertEventParams
+	for range s.rand.IntN(seedMaxEvents + 1) {
+		city := pick(s.rand, seedEventCities)
+		topic := pick(s.rand, seedEventTopics)
+		kind := pick(s.rand, seedEventKinds)
+
+		offset := time.Duration(s.rand.Int64N(int64(2*seedEventSpread))) - seedEventSpread
+		startsAt := s.today.Add(offset).Truncate(24 * time.Hour).Add(time.Duration(9+s.rand.IntN(10)) * time.Hour)
+		endsAt := startsAt.Add(time.Duration(2+s.rand.IntN(4)) * time.Hour)
+		if kind == "Hackathon" || kind == "Summit" {
+			endsAt = startsAt.Add(time.Duration(1+s.rand.IntN(2)) * 24 * time.Hour)
+		}
+
+		var website nulls.String
+		if s.rand.IntN(3) > 0 {
+			website = nulls.NewString("https://" + strings.ToLower(strings.ReplaceAll(topic+"-"+kind, " ", "-")) + ".my")
+		}
+
+		events = append(events, database.InsertEventParams{
+			Name:        fmt.Sprintf("%s %s %s %d", city, topic, kind, startsAt.Year()),
+			Description: fmt.Sprintf("A %s for the %s community in %s.", strings.ToLower(kind), topic, city),
+			Tags:        s.tags(),
+			Website:     website,
+			StartsAt:    startsAt,
+			EndsAt:      endsAt,
+		})
+	}
+
+	return events
+}
+
+// tags picks between one and four distinct tags.
+func (s *seeder) tags() []string {
+	perm := s.rand.Perm(len(seedTags))
+
+	tags := make([]string, 1+s.rand.IntN(4))
+	for i := range tags {
+		tags[i] = seedTags[perm[i]]
+	}
+
+	return tags
+}
+
+func pick[T any](r *rand.Rand, items []T) T {
+	return items[r.IntN(len(items))]
+}
@@ -1,27 +1,56 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	_ "github.com/lib/pq"
 
 	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
 	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/store"
+	"github.com/gomodule/redigo/redis"
 	"github.com/urfave/cli/v2"
 )
 
 func newServeCommand() *cli.Command {
 	return &cli.Command{
 		Name: "serve",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "migrate",
+				Usage: "apply pending migrations before serving.",
+			},
+		},
 		Action: func(cliCtx *cli.Context) error {
 			logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
 			cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
 
+			mustValidateConfig(logger, cfg)
+
+			ctx, stop := signal.NotifyContext(cliCtx.Context, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			shutdownTracing, err := setupTracing(ctx, cfg.Tracing)
+			if err != nil {
+				logger.Error("could not initialize tracing", slog.Any("err", err))
+				os.Exit(1)
+			}
+			defer func() {
+				if err := shutdownTracing(context.Background()); err != nil {
+					logger.Error("could not shut down tracing", slog.Any("err", err))
+				}
+			}()
+
 			logger.Info("opening a connection to postgres database")
-			db, err := sql.Open("postgres", cfg.Postgres.DSN())
+			db, err := database.Open(cfg.Postgres.DSN())
 			if err != nil {
 				logger.Error("could not initialize postgres database", slog.Any("err", err))
 				os.Exit(1)
@@ -29,17 +58,142 @@ func newServeCommand() *cli.Command {
 			defer func(db *sql.DB) {
 				_ = db.Close()
 			}(db)
+			cfg.Postgres.ConfigurePool(db)
+
+			if err := waitFor(ctx, logger, "postgres", db.PingContext); err != nil {
+				logger.Error("could not connect to postgres database", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			if err := prepareSchema(ctx, logger, cfg.Postgres, cliCtx.Bool("migrate") || cfg.Postgres.AutoMigrate); err != nil {
+				logger.Error("could not prepare postgres database schema", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			var pool *redis.Pool
+			sessionsInRedis := cfg.Authentication.Session.Store == "" || cfg.Authentication.Session.Store == handler.SessionStoreRedis
+			if sessionsInRedis || cfg.Http.RateLimit.Store == handler.RateLimitStoreRedis {
+				pool = cfg.Redis.Pool()
+				defer func(pool *redis.Pool) {
+					_ = pool.Close()
+				}(pool)
+
+				if err := waitFor(ctx, logger, "redis", func(ctx context.Context) error {
+					return handler.PingRedis(ctx, pool)
+				}); err != nil {
+					logger.Error("could not connect to redis", slog.Any("err", err))
+					os.Exit(1)
+				}
+			}
+
+			sessionStore, err := handler.NewSessionStore(logger, cfg, db, pool)
+			if err != nil {
+				logger.Error("could not initialize session store", slog.Any("err", err))
+				os.Exit(1)
+			}
+			if s, ok := sessionStore.(interface{ StopCleanup() }); ok {
+				defer s.StopCleanup()
+			}
+
+			st := store.NewPostgres(db)
+
+			workerDone := make(chan struct{})
+			if cfg.Jobs.RunInServe {
+				w, err := newWorker(logger, cfg, st)
+				if err != nil {
+					logger.Error("could not initialize job worker", slog.Any("err", err))
+					os.Exit(1)
+				}
+
+				go func() {
+					w.Run(ctx)
+					close(workerDone)
+				}()
+			} else {
+				logger.Warn("jobs do not run in serve, account deletions, session cleanup and repository enrichment stop unless awesome-my worker runs")
+				close(workerDone)
+			}
+
+			metrics := handler.NewMetrics(logger, st, db, pool)
+
+			h, err := handler.New(logger, cfg, st, pool, sessionStore, metrics)
+			if err != nil {
+				logger.Error("could not initialize http handler", slog.Any("err", err))
+				os.Exit(1)
+			}
 
-			logger.Info("listening and serving http")
 			srv := &http.Server{
-				Addr:    cfg.Http.Address(),
-				Handler: handler.New(logger, cfg, db),
+				Addr:              cfg.Http.Address(),
+				Handler:           h,
+				ReadTimeout:       cfg.Http.ReadTimeout.Duration,
+				ReadHeaderTimeout: cfg.Http.ReadHeaderTimeout.Duration,
+				WriteTimeout:      cfg.Http.WriteTimeout.Duration,
+				IdleTimeout:       cfg.Http.IdleTimeout.Duration,
 			}
-			if err := srv.ListenAndServe(); err != nil {
+
+			errCh := make(chan error, 1)
+			go func() {
+				logger.Info("listening and serving http", slog.String("address", srv.Addr))
+				errCh <- srv.ListenAndServe()
+			}()
+
+			var adminSrv *http.Server
+			adminErrCh := make(chan error, 1)
+			if cfg.Http.Metrics.Enabled && cfg.Http.Admin.Port != 0 {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", metrics.Handler())
+
+				adminSrv = &http.Server{
+					Addr:              cfg.Http.AdminAddress(),
+					Handler:           mux,
+					ReadHeaderTimeout: cfg.Http.ReadHeaderTimeout.Duration,
+				}
+
+				go func() {
+					logger.Info("listening and serving admin http", slog.String("address", adminSrv.Addr))
+					adminErrCh <- adminSrv.ListenAndServe()
+				}()
+			}
+
+			select {
+			case err := <-errCh:
 				logger.Error("could not listen and serve http", slog.Any("err", err))
 				os.Exit(1)
+			case err := <-adminErrCh:
+				logger.Error("could not listen and serve admin http", slog.Any("err", err))
+				os.Exit(1)
+			case <-ctx.Done():
+			}
+
+			logger.Info("shutting down http server")
+			shutdownCtx := context.Background()
+			if cfg.Http.ShutdownTimeout.Duration > 0 {
+				var cancel context.CancelFunc
+				shutdownCtx, cancel = context.WithTimeout(shutdownCtx, cfg.Http.ShutdownTimeout.Duration)
+				defer cancel()
+			}
+
+			if adminSrv != nil {
+				if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+					logger.Error("could not gracefully shut down admin http server", slog.Any("err", err))
+				}
+			}
+
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				logger.Error("could not gracefully shut down http server", slog.Any("err", err))
+				return err
+			}
+
+			if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("could not listen and serve http", slog.Any("err", err))
+				return err
 			}
 
+			logger.Info("http server shut down")
+
+			// The worker stops claiming jobs once ctx is done, running jobs are waited for.
+			<-workerDone
+
 			return nil
 		},
 	}
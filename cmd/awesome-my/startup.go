@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/awesome-my/backend"
+)
+
+const (
+	// startupAttempts is how many times a dependency is checked before giving up.
+	startupAttempts = 6
+	// startupBackoff is the delay before the first retry, it doubles after every attempt.
+	startupBackoff = time.Second
+	// startupCheckTimeout bounds how long a single dependency check may take.
+	startupCheckTimeout = 5 * time.Second
+)
+
+// waitFor calls check until it succeeds, retrying with exponential backoff and returning
+// the last error once all attempts are exhausted or ctx is done.
+func waitFor(ctx context.Context, logger *slog.Logger, name string, check func(ctx context.Context) error) error {
+	backoff := startupBackoff
+
+	var err error
+	for attempt := 1; attempt <= startupAttempts; attempt++ {
+		checkCtx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+		err = check(checkCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if attempt == startupAttempts {
+			break
+		}
+
+		logger.Warn(
+			"dependency is not ready, retrying",
+			slog.String("dependency", name),
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.Any("err", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}
+
+// mustValidateConfig logs every problem of the configuration and exits when there is any.
+func mustValidateConfig(logger *slog.Logger, cfg awesomemy.Config) {
+	mustBeValid(logger, cfg.Validate())
+}
+
+// mustValidateDatabaseConfig is mustValidateConfig for the commands that only use the
+// database, which do not need the settings of the API.
+func mustValidateDatabaseConfig(logger *slog.Logger, cfg awesomemy.Config) {
+	mustBeValid(logger, cfg.ValidateDatabase())
+}
+
+// mustBeValid logs the problems reported by validating the configuration and exits when there
+// is any.
+func mustBeValid(logger *slog.Logger, err error) {
+	if err == nil {
+		return
+	}
+
+	var ve awesomemy.ValidationErrors
+	if errors.As(err, &ve) {
+		for _, problem := range ve {
+			logger.Error("invalid configuration", slog.String("problem", problem))
+		}
+	} else {
+		logger.Error("could not validate configuration", slog.Any("err", err))
+	}
+	os.Exit(1)
+}
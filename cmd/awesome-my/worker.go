@@ -0,0 +1,167 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/urfave/cli/v2"
+)
+
+func newWorkerCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "worker",
+		Usage: "run the background jobs until interrupted.",
+		Action: func(cliCtx *cli.Context) error {
+			logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+			cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
+
+			mustValidateConfig(logger, cfg)
+
+			ctx, stop := signal.NotifyContext(cliCtx.Context, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			logger.Info("opening a connection to postgres database")
+			db, err := database.Open(cfg.Postgres.DSN())
+			if err != nil {
+				logger.Error("could not initialize postgres database", slog.Any("err", err))
+				os.Exit(1)
+			}
+			defer func(db *sql.DB) {
+				_ = db.Close()
+			}(db)
+			cfg.Postgres.ConfigurePool(db)
+
+			if err := waitFor(ctx, logger, "postgres", db.PingContext); err != nil {
+				logger.Error("could not connect to postgres database", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			// Migrations are left to serve, the worker only refuses an outdated schema.
+			if err := prepareSchema(ctx, logger, cfg.Postgres, false); err != nil {
+				logger.Error("could not prepare postgres database schema", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			w, err := newWorker(logger, cfg, store.NewPostgres(db))
+			if err != nil {
+				logger.Error("could not initialize job worker", slog.Any("err", err))
+				os.Exit(1)
+			}
+
+			w.Run(ctx)
+
+			return nil
+		},
+	}
+}
+
+func newJobsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "jobs",
+		Usage: "inspect and requeue the dead background jobs.",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "dead",
+				Usage: "list the jobs that exhausted their attempts, most recent first.",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "maximum number of jobs to list.",
+						Value: 50,
+					},
+				},
+				Action: func(cliCtx *cli.Context) error {
+					return withJobStore(cliCtx, func(logger *slog.Logger, st store.JobStore) error {
+						dead, err := st.DeadJobs(cliCtx.Context, int32(cliCtx.Int("limit")))
+						if err != nil {
+							return err
+						}
+
+						for _, job := range dead {
+							logger.Info(
+								"dead job",
+								slog.Int("job_id", int(job.JobID)),
+								slog.String("kind", job.Kind),
+								slog.String("payload", string(job.Payload)),
+								slog.Int("attempts", int(job.Attempts)),
+								slog.Time("finished_at", job.FinishedAt.Time),
+								slog.String("last_error", job.LastError.String),
+							)
+						}
+
+						return nil
+					})
+				},
+			},
+			{
+				Name:      "requeue",
+				Usage:     "give dead jobs a fresh set of attempts.",
+				ArgsUsage: "<job id>...",
+				Action: func(cliCtx *cli.Context) error {
+					if cliCtx.NArg() == 0 {
+						logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+						logger.Error("at least one job id is required")
+						os.Exit(1)
+					}
+
+					return withJobStore(cliCtx, func(logger *slog.Logger, st store.JobStore) error {
+						for _, arg := range cliCtx.Args().Slice() {
+							jobID, err := strconv.ParseInt(arg, 10, 32)
+							if err != nil {
+								return fmt.Errorf("invalid job id %q", arg)
+							}
+
+							job, err := st.RequeueDeadJob(cliCtx.Context, int32(jobID), time.Now())
+							if errors.Is(err, store.ErrNotFound) {
+								return fmt.Errorf("job %d is not dead", jobID)
+							} else if err != nil {
+								return err
+							}
+
+							logger.Info("requeued job", slog.Int("job_id", int(job.JobID)), slog.String("kind", job.Kind))
+						}
+
+						return nil
+					})
+				},
+			},
+		},
+	}
+}
+
+// withJobStore opens the database and calls fn with a job store over it.
+func withJobStore(cliCtx *cli.Context, fn func(logger *slog.Logger, st store.JobStore) error) error {
+	logger := awesomemy.MustContextValue[*slog.Logger](cliCtx.Context, awesomemy.CtxKeyLogger)
+	cfg := awesomemy.MustContextValue[awesomemy.Config](cliCtx.Context, awesomemy.CtxKeyConfig)
+
+	mustValidateDatabaseConfig(logger, cfg)
+
+	logger.Debug("opening a connection to postgres database")
+	db, err := database.Open(cfg.Postgres.DSN())
+	if err != nil {
+		logger.Error("could not initialize postgres database", slog.Any("err", err))
+		os.Exit(1)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := fn(logger, store.NewPostgres(db)); err != nil {
+		logger.Error("could not manage jobs", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -1,11 +1,16 @@
 package awesomemy
 
 import (
-	"fmt"
+	"database/sql"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/gomodule/redigo/redis"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/endpoints"
 )
@@ -15,68 +20,344 @@ type Config struct {
 	Redis           RedisConfig          `yaml:"redis"`
 	Http            HttpConfig           `yaml:"http"`
 	Authentication  AuthenticationConfig `yaml:"authentication"`
+	Account         AccountConfig        `yaml:"account"`
+	Tracing         TracingConfig        `yaml:"tracing"`
+	GitHub          GitHubConfig         `yaml:"github"`
+	Jobs            JobsConfig           `yaml:"jobs"`
+	Webhooks        WebhooksConfig       `yaml:"webhooks"`
+	Mail            MailConfig           `yaml:"mail"`
 	FrontendBaseURL string               `yaml:"frontend_base_url"`
 }
 
 type PostgresConfig struct {
-	Name     string `yaml:"name"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
+	URL              string   `yaml:"url"`
+	Name             string   `yaml:"name"`
+	User             string   `yaml:"user"`
+	Password         string   `yaml:"password"`
+	Host             string   `yaml:"host"`
+	Port             int      `yaml:"port"`
+	SSLMode          string   `yaml:"sslmode"`
+	SSLRootCert      string   `yaml:"sslrootcert"`
+	SSLCert          string   `yaml:"sslcert"`
+	SSLKey           string   `yaml:"sslkey"`
+	ApplicationName  string   `yaml:"application_name"`
+	StatementTimeout Duration `yaml:"statement_timeout"`
+	MaxOpenConns     int      `yaml:"max_open_conns"`
+	MaxIdleConns     int      `yaml:"max_idle_conns"`
+	ConnMaxLifetime  Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime  Duration `yaml:"conn_max_idle_time"`
+	AutoMigrate      bool     `yaml:"auto_migrate"`
+	SchemaCheck      string   `yaml:"schema_check"`
 }
 
+// DSN is the connection string of the database, the URL takes precedence over the
+// individual connection fields when set. The SSL, application name and statement timeout
+// options are added unless the URL already sets them, sslmode defaults to disable only
+// when the URL is unset.
 func (pc PostgresConfig) DSN() string {
-	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=disable", pc.User, pc.Password, pc.Host, pc.Port, pc.Name)
+	u := &url.URL{
+		Scheme: "postgresql",
+		User:   url.UserPassword(pc.User, pc.Password),
+		Host:   net.JoinHostPort(pc.Host, strconv.Itoa(pc.Port)),
+		Path:   "/" + pc.Name,
+	}
+	sslMode := pc.SSLMode
+	if pc.URL != "" {
+		parsed, err := url.Parse(pc.URL)
+		if err != nil {
+			return pc.URL
+		}
+		u = parsed
+	} else if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	applicationName := pc.ApplicationName
+	if applicationName == "" {
+		applicationName = "awesome-my"
+	}
+
+	q := u.Query()
+	set := func(key, value string) {
+		if value != "" && !q.Has(key) {
+			q.Set(key, value)
+		}
+	}
+	set("sslmode", sslMode)
+	set("sslrootcert", pc.SSLRootCert)
+	set("sslcert", pc.SSLCert)
+	set("sslkey", pc.SSLKey)
+	set("application_name", applicationName)
+	if pc.StatementTimeout.Duration > 0 {
+		// Unknown parameters are sent to the server as run-time parameters, so every
+		// statement of the connection is bounded.
+		set("statement_timeout", strconv.FormatInt(pc.StatementTimeout.Milliseconds(), 10))
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// ConfigurePool applies the connection pool settings to db, unset settings keep the
+// database/sql defaults.
+func (pc PostgresConfig) ConfigurePool(db *sql.DB) {
+	if pc.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pc.MaxOpenConns)
+	}
+	if pc.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pc.MaxIdleConns)
+	}
+	if pc.ConnMaxLifetime.Duration > 0 {
+		db.SetConnMaxLifetime(pc.ConnMaxLifetime.Duration)
+	}
+	if pc.ConnMaxIdleTime.Duration > 0 {
+		db.SetConnMaxIdleTime(pc.ConnMaxIdleTime.Duration)
+	}
 }
 
 type RedisConfig struct {
-	Host string `yaml:"host"`
-	Port int    `yaml:"port"`
+	Host           string   `yaml:"host"`
+	Port           int      `yaml:"port"`
+	Password       string   `yaml:"password"`
+	Database       int      `yaml:"database"`
+	TLS            bool     `yaml:"tls"`
+	MaxIdle        int      `yaml:"max_idle"`
+	MaxActive      int      `yaml:"max_active"`
+	IdleTimeout    Duration `yaml:"idle_timeout"`
+	ConnectTimeout Duration `yaml:"connect_timeout"`
+	ReadTimeout    Duration `yaml:"read_timeout"`
+	WriteTimeout   Duration `yaml:"write_timeout"`
 }
 
 func (rc RedisConfig) ConnnectionString() string {
 	return rc.Host + ":" + strconv.Itoa(rc.Port)
 }
 
+// Pool creates a redis connection pool from the configuration, MaxIdle defaults to 10 when unset.
+func (rc RedisConfig) Pool() *redis.Pool {
+	maxIdle := rc.MaxIdle
+	if maxIdle == 0 {
+		maxIdle = 10
+	}
+
+	return &redis.Pool{
+		MaxIdle:     maxIdle,
+		MaxActive:   rc.MaxActive,
+		IdleTimeout: rc.IdleTimeout.Duration,
+		Dial: func() (redis.Conn, error) {
+			options := []redis.DialOption{
+				redis.DialPassword(rc.Password),
+				redis.DialDatabase(rc.Database),
+				redis.DialUseTLS(rc.TLS),
+				redis.DialReadTimeout(rc.ReadTimeout.Duration),
+				redis.DialWriteTimeout(rc.WriteTimeout.Duration),
+			}
+			if rc.ConnectTimeout.Duration > 0 {
+				options = append(options, redis.DialConnectTimeout(rc.ConnectTimeout.Duration))
+			}
+
+			return redis.Dial("tcp", rc.ConnnectionString(), options...)
+		},
+	}
+}
+
 type HttpConfig struct {
-	Host string `yaml:"host"`
-	Port int    `yaml:"port"`
-	Cors struct {
+	Host              string   `yaml:"host"`
+	Port              int      `yaml:"port"`
+	ReadTimeout       Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout"`
+	WriteTimeout      Duration `yaml:"write_timeout"`
+	IdleTimeout       Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   Duration `yaml:"shutdown_timeout"`
+	Cors              struct {
 		Origin []string `yaml:"origin"`
 	} `yaml:"cors"`
+	Metrics struct {
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"metrics"`
+	Admin struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"admin"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
 }
 
 func (hc HttpConfig) Address() string {
 	return hc.Host + ":" + strconv.Itoa(hc.Port)
 }
 
+// AdminAddress is the address of the separate admin listener, which is only started when
+// an admin port is configured.
+func (hc HttpConfig) AdminAddress() string {
+	return hc.Admin.Host + ":" + strconv.Itoa(hc.Admin.Port)
+}
+
+type RateLimitConfig struct {
+	Store  string          `yaml:"store"`
+	Prefix string          `yaml:"prefix"`
+	Public RateLimitPolicy `yaml:"public"`
+	Auth   RateLimitPolicy `yaml:"auth"`
+	Client RateLimitPolicy `yaml:"client"`
+}
+
+// RateLimitPolicy limits the requests made within a window to a route group, authenticated
+// requests are keyed by user and may be granted a separate budget.
+type RateLimitPolicy struct {
+	Requests              int      `yaml:"requests"`
+	AuthenticatedRequests int      `yaml:"authenticated_requests"`
+	Window                Duration `yaml:"window"`
+}
+
 type AuthenticationConfig struct {
 	Session struct {
-		Prefix   string   `yaml:"prefix"`
-		Name     string   `yaml:"name"`
-		Persist  bool     `yaml:"persist"`
-		SameSite string   `yaml:"same_site"`
-		Secure   bool     `yaml:"secure"`
-		Lifetime Duration `yaml:"lifetime"`
+		Store           string   `yaml:"store"`
+		CleanupInterval Duration `yaml:"cleanup_interval"`
+		Prefix          string   `yaml:"prefix"`
+		Name            string   `yaml:"name"`
+		Persist         bool     `yaml:"persist"`
+		SameSite        string   `yaml:"same_site"`
+		Secure          bool     `yaml:"secure"`
+		Lifetime        Duration `yaml:"lifetime"`
 	} `yaml:"session"`
 	OAuth2 AuthenticationOAuth2Config `yaml:"oauth2"`
+	Dev    AuthenticationDevConfig    `yaml:"dev"`
+}
+
+// AuthenticationDevConfig enables logging in as any existing user without OAuth2, it must
+// never be enabled in production and is refused alongside secure session cookies.
+type AuthenticationDevConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// defaultDeletionGracePeriod is how long a deleted account can be restored when no grace
+// period is configured.
+const defaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+type AccountConfig struct {
+	// DeletionGracePeriod is how long a deleted account can be restored before it is purged,
+	// see GracePeriod.
+	DeletionGracePeriod Duration `yaml:"deletion_grace_period"`
+	// AdminEmails are the GitHub emails of the users administering the site, who may
+	// manage site-wide settings such as the webhooks notified of every change.
+	AdminEmails []string `yaml:"admin_emails"`
+}
+
+// GracePeriod is the configured deletion grace period, 30 days when unset.
+func (ac AccountConfig) GracePeriod() time.Duration {
+	if ac.DeletionGracePeriod.Duration <= 0 {
+		return defaultDeletionGracePeriod
+	}
+
+	return ac.DeletionGracePeriod.Duration
+}
+
+// IsAdmin reports whether the user with the GitHub email is an administrator of the site.
+func (ac AccountConfig) IsAdmin(githubEmail string) bool {
+	for _, email := range ac.AdminEmails {
+		if strings.EqualFold(email, githubEmail) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// GitHubConfig configures the requests made to the GitHub API on behalf of the site, such as
+// enriching projects with the stats of their repository. The token is required when the
+// enrichment is enabled. It is sent as is and never refreshed, so GitHub App installation
+// tokens, which expire after an hour, are not supported.
+type GitHubConfig struct {
+	Token string `yaml:"token"`
+	// APIURL overrides the GitHub API base URL, e.g. to point at a mock in tests.
+	APIURL     string `yaml:"api_url"`
+	Enrichment struct {
+		Enabled      bool     `yaml:"enabled"`
+		Interval     Duration `yaml:"interval"`
+		RefreshAfter Duration `yaml:"refresh_after"`
+		BatchSize    int      `yaml:"batch_size"`
+	} `yaml:"enrichment"`
+}
+
+// JobsConfig configures the workers of the background jobs queued in Postgres. They run in the
+// worker command, and alongside the API in serve unless RunInServe is turned off, unset
+// settings take the defaults of the jobs package.
+type JobsConfig struct {
+	RunInServe   bool     `yaml:"run_in_serve"`
+	Concurrency  int      `yaml:"concurrency"`
+	PollInterval Duration `yaml:"poll_interval"`
+	// LockTimeout bounds how long a job may run, after which it is claimed again by another
+	// worker in case its worker died.
+	LockTimeout Duration `yaml:"lock_timeout"`
+	MaxAttempts int      `yaml:"max_attempts"`
+	// Retention is how long completed jobs are kept before being deleted.
+	Retention Duration `yaml:"retention"`
+}
+
+// WebhooksConfig configures the delivery of the webhooks notified of changes to projects and
+// events, unset settings take the defaults of the webhooks package.
+type WebhooksConfig struct {
+	Timeout Duration `yaml:"timeout"`
+	// AllowPrivateNetworks lets webhooks be delivered to loopback and private addresses,
+	// which are refused by default so webhooks cannot reach the internal services.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	// Retention is how long the log of deliveries is kept.
+	Retention Duration `yaml:"retention"`
+}
+
+// MailConfig configures the emails notifying users, which are sent by the job workers through
+// the transport: smtp, file which writes each email to a .eml file of Directory, or log which
+// only logs them. Emails are logged when the transport is unset.
+type MailConfig struct {
+	Transport string `yaml:"transport"`
+	From      string `yaml:"from"`
+	SMTP      struct {
+		Host     string `yaml:"host"`
+		Port     int    `yaml:"port"`
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+	} `yaml:"smtp"`
+	Directory string `yaml:"directory"`
+	// APIBaseURL is the public URL of the API, when set emails carry a List-Unsubscribe header
+	// letting mail clients unsubscribe in one click.
+	APIBaseURL string `yaml:"api_base_url"`
 }
 
 type AuthenticationOAuth2Config struct {
 	GitHub struct {
 		ClientID     string `yaml:"client_id"`
 		ClientSecret string `yaml:"client_secret"`
+		// AuthURL, TokenURL and APIURL override the GitHub endpoints, e.g. to point at a
+		// GitHub Enterprise server or at a mock provider in tests.
+		AuthURL  string `yaml:"auth_url"`
+		TokenURL string `yaml:"token_url"`
+		APIURL   string `yaml:"api_url"`
 	} `yaml:"github"`
 }
 
 func (aac AuthenticationOAuth2Config) OAuth2Config(provider string) *oauth2.Config {
 	switch provider {
 	case "github":
+		endpoint := endpoints.GitHub
+		if aac.GitHub.AuthURL != "" {
+			endpoint.AuthURL = aac.GitHub.AuthURL
+		}
+		if aac.GitHub.TokenURL != "" {
+			endpoint.TokenURL = aac.GitHub.TokenURL
+		}
+
 		return &oauth2.Config{
 			ClientID:     aac.GitHub.ClientID,
 			ClientSecret: aac.GitHub.ClientSecret,
-			Endpoint:     endpoints.GitHub,
+			Endpoint:     endpoint,
 			Scopes:       []string{"read:user", "user:email"},
 		}
 	}
@@ -93,8 +374,17 @@ func ParseConfigFromFile(fp string) (Config, error) {
 	return ParseConfig(b)
 }
 
-func ParseConfig(b []byte) (Config, error) {
+// defaultConfig returns the configuration fields whose default is not their zero value, which
+// the configuration file and the environment override.
+func defaultConfig() Config {
 	var cfg Config
+	cfg.Jobs.RunInServe = true
+
+	return cfg
+}
+
+func ParseConfig(b []byte) (Config, error) {
+	cfg := defaultConfig()
 	if err := yaml.Unmarshal(b, &cfg); err != nil {
 		return cfg, err
 	}
@@ -0,0 +1,159 @@
+package awesomemy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix prefixes the environment variables overriding configuration fields, a field is
+// named after its YAML path, e.g. AWESOMEMY_POSTGRES_PASSWORD for postgres.password.
+const EnvPrefix = "AWESOMEMY"
+
+// LoadConfig parses the configuration file at fp, if any, and applies the environment
+// variable overrides on top of it. An empty fp loads the configuration from the environment
+// alone.
+func LoadConfig(fp string) (Config, error) {
+	cfg := defaultConfig()
+	if fp != "" {
+		var err error
+		cfg, err = ParseConfigFromFile(fp)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	if err := ApplyEnv(&cfg, os.LookupEnv); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+// ApplyEnv overrides every configuration field that has a matching environment variable set.
+// A variable suffixed with _FILE names a file holding the value instead, which suits secrets
+// mounted by Docker. DATABASE_URL is honoured when AWESOMEMY_POSTGRES_URL is not set.
+func ApplyEnv(cfg *Config, lookup func(key string) (string, bool)) error {
+	errs := applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, lookup)
+
+	if cfg.Postgres.URL == "" {
+		url, ok, err := lookupEnv("DATABASE_URL", lookup)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			cfg.Postgres.URL = url
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func applyEnv(v reflect.Value, prefix string, lookup func(key string) (string, bool)) []error {
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + "_" + strings.ToUpper(name)
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(Duration{}) {
+			errs = append(errs, applyEnv(fv, key, lookup)...)
+			continue
+		}
+
+		value, ok, err := lookupEnv(key, lookup)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if err := setEnvValue(fv, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return errs
+}
+
+// lookupEnv looks up key, falling back to reading the file named by key_FILE.
+func lookupEnv(key string, lookup func(key string) (string, bool)) (string, bool, error) {
+	if value, ok := lookup(key); ok {
+		return value, true, nil
+	}
+
+	fp, ok := lookup(key + "_FILE")
+	if !ok {
+		return "", false, nil
+	}
+
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", key, err)
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), true, nil
+}
+
+func setEnvValue(v reflect.Value, value string) error {
+	if v.Type() == reflect.TypeOf(Duration{}) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(Duration{d}))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package awesomemy_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+)
+
+func TestApplyEnv(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secret, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("could not write secret file: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		want func(cfg *awesomemy.Config)
+	}{
+		{
+			name: "string",
+			env:  map[string]string{"AWESOMEMY_POSTGRES_PASSWORD": "hunter2"},
+			want: func(cfg *awesomemy.Config) { cfg.Postgres.Password = "hunter2" },
+		},
+		{
+			name: "int",
+			env:  map[string]string{"AWESOMEMY_HTTP_PORT": "8080"},
+			want: func(cfg *awesomemy.Config) { cfg.Http.Port = 8080 },
+		},
+		{
+			name: "bool in a nested struct",
+			env:  map[string]string{"AWESOMEMY_HTTP_METRICS_ENABLED": "true"},
+			want: func(cfg *awesomemy.Config) { cfg.Http.Metrics.Enabled = true },
+		},
+		{
+			name: "duration",
+			env:  map[string]string{"AWESOMEMY_ACCOUNT_DELETION_GRACE_PERIOD": "48h"},
+			want: func(cfg *awesomemy.Config) {
+				cfg.Account.DeletionGracePeriod = awesomemy.Duration{Duration: 48 * time.Hour}
+			},
+		},
+		{
+			name: "float",
+			env:  map[string]string{"AWESOMEMY_TRACING_SAMPLE_RATIO": "0.25"},
+			want: func(cfg *awesomemy.Config) { cfg.Tracing.SampleRatio = 0.25 },
+		},
+		{
+			name: "comma separated list",
+			env:  map[string]string{"AWESOMEMY_HTTP_CORS_ORIGIN": "https://awesome.my, ,https://www.awesome.my"},
+			want: func(cfg *awesomemy.Config) {
+				cfg.Http.Cors.Origin = []string{"https://awesome.my", "https://www.awesome.my"}
+			},
+		},
+		{
+			name: "file",
+			env:  map[string]string{"AWESOMEMY_REDIS_PASSWORD_FILE": secret},
+			want: func(cfg *awesomemy.Config) { cfg.Redis.Password = "from-file" },
+		},
+		{
+			name: "value before file",
+			env:  map[string]string{"AWESOMEMY_REDIS_PASSWORD": "from-env", "AWESOMEMY_REDIS_PASSWORD_FILE": secret},
+			want: func(cfg *awesomemy.Config) { cfg.Redis.Password = "from-env" },
+		},
+		{
+			name: "database url",
+			env:  map[string]string{"DATABASE_URL": "postgres://localhost/awesomemy"},
+			want: func(cfg *awesomemy.Config) { cfg.Postgres.URL = "postgres://localhost/awesomemy" },
+		},
+		{
+			name: "postgres url before database url",
+			env:  map[string]string{"AWESOMEMY_POSTGRES_URL": "postgres://primary/awesomemy", "DATABASE_URL": "postgres://localhost/awesomemy"},
+			want: func(cfg *awesomemy.Config) { cfg.Postgres.URL = "postgres://primary/awesomemy" },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got, want awesomemy.Config
+			got.Postgres.Password = "from-yaml"
+			want.Postgres.Password = "from-yaml"
+			tc.want(&want)
+
+			if err := awesomemy.ApplyEnv(&got, lookup(tc.env)); err != nil {
+				t.Fatalf("could not apply env: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyEnvErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+	}{
+		{"int", map[string]string{"AWESOMEMY_HTTP_PORT": "eighty"}},
+		{"bool", map[string]string{"AWESOMEMY_TRACING_ENABLED": "maybe"}},
+		{"duration", map[string]string{"AWESOMEMY_HTTP_READ_TIMEOUT": "5"}},
+		{"missing file", map[string]string{"AWESOMEMY_POSTGRES_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg awesomemy.Config
+			if err := awesomemy.ApplyEnv(&cfg, lookup(tc.env)); err == nil {
+				t.Error("got no error, want one")
+			}
+		})
+	}
+}
+
+func lookup(env map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
@@ -0,0 +1,25 @@
+package awesomemy_test
+
+import (
+	"testing"
+
+	"github.com/awesome-my/backend"
+)
+
+func TestParseConfigDefaults(t *testing.T) {
+	cfg, err := awesomemy.ParseConfig([]byte("http:\n  port: 8080\n"))
+	if err != nil {
+		t.Fatalf("could not parse config: %v", err)
+	}
+	if !cfg.Jobs.RunInServe {
+		t.Error("got jobs.run_in_serve false, want it on by default")
+	}
+
+	cfg, err = awesomemy.ParseConfig([]byte("jobs:\n  run_in_serve: false\n"))
+	if err != nil {
+		t.Fatalf("could not parse config: %v", err)
+	}
+	if cfg.Jobs.RunInServe {
+		t.Error("got jobs.run_in_serve true, want it turned off")
+	}
+}
@@ -0,0 +1,199 @@
+package awesomemy
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ValidationErrors lists every problem found in a configuration.
+type ValidationErrors []string
+
+func (ve ValidationErrors) Error() string {
+	return "invalid configuration: " + strings.Join(ve, "; ")
+}
+
+// problems collects the problems found while validating a configuration.
+type problems struct {
+	ve ValidationErrors
+}
+
+func (p *problems) add(problem string) {
+	p.ve = append(p.ve, problem)
+}
+
+func (p *problems) required(field, value string) {
+	if value == "" {
+		p.add(field + " is required")
+	}
+}
+
+func (p *problems) port(field string, value int, optional bool) {
+	if (value == 0 && !optional) || value < 0 || value > 65535 {
+		p.add(fmt.Sprintf("%s must be a port between 1 and 65535, got %d", field, value))
+	}
+}
+
+func (p *problems) oneOf(field, value string, allowed ...string) {
+	if value != "" && !slices.Contains(allowed, value) {
+		p.add(fmt.Sprintf("%s must be one of %s, got %q", field, strings.Join(allowed, ", "), value))
+	}
+}
+
+func (p *problems) nonNegative(field string, value Duration) {
+	if value.Duration < 0 {
+		p.add(field + " must not be negative")
+	}
+}
+
+// err returns nil or the problems found as ValidationErrors.
+func (p *problems) err() error {
+	if len(p.ve) > 0 {
+		return p.ve
+	}
+
+	return nil
+}
+
+func (pc PostgresConfig) validate(p *problems) {
+	if pc.URL != "" {
+		if u, err := url.Parse(pc.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			p.add("postgres.url must be a postgres:// or postgresql:// URL")
+		}
+	} else {
+		p.required("postgres.name", pc.Name)
+		p.required("postgres.user", pc.User)
+		p.required("postgres.host", pc.Host)
+		p.port("postgres.port", pc.Port, false)
+	}
+	p.oneOf("postgres.sslmode", pc.SSLMode, "disable", "require", "verify-ca", "verify-full")
+	if pc.MaxOpenConns < 0 || pc.MaxIdleConns < 0 {
+		p.add("postgres connection pool sizes must not be negative")
+	}
+	if pc.MaxOpenConns > 0 && pc.MaxIdleConns > pc.MaxOpenConns {
+		p.add("postgres.max_idle_conns must not exceed postgres.max_open_conns")
+	}
+	p.oneOf("postgres.schema_check", pc.SchemaCheck, "strict", "warn")
+	p.nonNegative("postgres.statement_timeout", pc.StatementTimeout)
+	p.nonNegative("postgres.conn_max_lifetime", pc.ConnMaxLifetime)
+	p.nonNegative("postgres.conn_max_idle_time", pc.ConnMaxIdleTime)
+}
+
+// ValidateDatabase reports the problems of the Postgres configuration alone, for the commands
+// that only use the database such as migrate and seed. It returns nil or ValidationErrors.
+func (c Config) ValidateDatabase() error {
+	var p problems
+	c.Postgres.validate(&p)
+
+	return p.err()
+}
+
+// Validate reports all missing and invalid fields of the configuration at once, it returns
+// nil or ValidationErrors.
+func (c Config) Validate() error {
+	var p problems
+	c.Postgres.validate(&p)
+
+	p.oneOf("authentication.session.store", c.Authentication.Session.Store, "redis", "postgres", "memory")
+	p.oneOf("http.rate_limit.store", c.Http.RateLimit.Store, "memory", "redis")
+	if c.Authentication.Session.Store == "" || c.Authentication.Session.Store == "redis" || c.Http.RateLimit.Store == "redis" {
+		p.required("redis.host", c.Redis.Host)
+		p.port("redis.port", c.Redis.Port, false)
+	}
+
+	p.port("http.port", c.Http.Port, false)
+	p.port("http.admin.port", c.Http.Admin.Port, true)
+	p.nonNegative("http.read_timeout", c.Http.ReadTimeout)
+	p.nonNegative("http.read_header_timeout", c.Http.ReadHeaderTimeout)
+	p.nonNegative("http.write_timeout", c.Http.WriteTimeout)
+	p.nonNegative("http.idle_timeout", c.Http.IdleTimeout)
+	p.nonNegative("http.shutdown_timeout", c.Http.ShutdownTimeout)
+	for _, group := range []struct {
+		name   string
+		policy RateLimitPolicy
+	}{
+		{"public", c.Http.RateLimit.Public},
+		{"auth", c.Http.RateLimit.Auth},
+		{"client", c.Http.RateLimit.Client},
+	} {
+		if group.policy.Requests < 0 || group.policy.AuthenticatedRequests < 0 {
+			p.add("http.rate_limit." + group.name + " requests must not be negative")
+		}
+		p.nonNegative("http.rate_limit."+group.name+".window", group.policy.Window)
+	}
+
+	p.required("authentication.session.name", c.Authentication.Session.Name)
+	p.oneOf("authentication.session.same_site", c.Authentication.Session.SameSite, "lax", "strict", "none")
+	if c.Authentication.Session.Lifetime.Duration <= 0 {
+		p.add("authentication.session.lifetime must be positive")
+	}
+	p.nonNegative("authentication.session.cleanup_interval", c.Authentication.Session.CleanupInterval)
+	if c.Authentication.Dev.Enabled {
+		if c.Authentication.Session.Secure {
+			p.add("authentication.dev.enabled is refused when authentication.session.secure is true")
+		}
+	} else {
+		p.required("authentication.oauth2.github.client_id", c.Authentication.OAuth2.GitHub.ClientID)
+		p.required("authentication.oauth2.github.client_secret", c.Authentication.OAuth2.GitHub.ClientSecret)
+	}
+
+	p.nonNegative("account.deletion_grace_period", c.Account.DeletionGracePeriod)
+
+	if c.Tracing.Enabled {
+		p.oneOf("tracing.exporter", c.Tracing.Exporter, "otlp", "stdout")
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			p.add("tracing.sample_ratio must be between 0 and 1")
+		}
+	}
+
+	if c.GitHub.APIURL != "" {
+		if u, err := url.Parse(c.GitHub.APIURL); err != nil || u.Scheme == "" || u.Host == "" {
+			p.add("github.api_url must be an absolute URL")
+		}
+	}
+	if c.GitHub.Enrichment.Enabled {
+		p.required("github.token", c.GitHub.Token)
+	}
+	p.nonNegative("github.enrichment.interval", c.GitHub.Enrichment.Interval)
+	p.nonNegative("github.enrichment.refresh_after", c.GitHub.Enrichment.RefreshAfter)
+	if c.GitHub.Enrichment.BatchSize < 0 {
+		p.add("github.enrichment.batch_size must not be negative")
+	}
+
+	if c.Jobs.Concurrency < 0 {
+		p.add("jobs.concurrency must not be negative")
+	}
+	if c.Jobs.MaxAttempts < 0 {
+		p.add("jobs.max_attempts must not be negative")
+	}
+	p.nonNegative("jobs.poll_interval", c.Jobs.PollInterval)
+	p.nonNegative("jobs.lock_timeout", c.Jobs.LockTimeout)
+	p.nonNegative("jobs.retention", c.Jobs.Retention)
+
+	p.nonNegative("webhooks.timeout", c.Webhooks.Timeout)
+	p.nonNegative("webhooks.retention", c.Webhooks.Retention)
+
+	p.oneOf("mail.transport", c.Mail.Transport, "smtp", "file", "log")
+	switch c.Mail.Transport {
+	case "smtp":
+		p.required("mail.from", c.Mail.From)
+		p.required("mail.smtp.host", c.Mail.SMTP.Host)
+		p.port("mail.smtp.port", c.Mail.SMTP.Port, false)
+	case "file":
+		p.required("mail.directory", c.Mail.Directory)
+	}
+	if c.Mail.APIBaseURL != "" {
+		if u, err := url.Parse(c.Mail.APIBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			p.add("mail.api_base_url must be an absolute URL")
+		}
+	}
+
+	if c.FrontendBaseURL == "" {
+		p.add("frontend_base_url is required")
+	} else if u, err := url.Parse(c.FrontendBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		p.add("frontend_base_url must be an absolute URL")
+	}
+
+	return p.err()
+}
@@ -0,0 +1,145 @@
+package awesomemy_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+)
+
+// validConfig returns the smallest configuration passing validation.
+func validConfig() awesomemy.Config {
+	var cfg awesomemy.Config
+	cfg.Postgres.Name = "awesomemy"
+	cfg.Postgres.User = "awesomemy"
+	cfg.Postgres.Host = "localhost"
+	cfg.Postgres.Port = 5432
+	cfg.Redis.Host = "localhost"
+	cfg.Redis.Port = 6379
+	cfg.Http.Port = 8080
+	cfg.Authentication.Session.Name = "awesomemy_session"
+	cfg.Authentication.Session.Lifetime = awesomemy.Duration{Duration: 24 * time.Hour}
+	cfg.Authentication.OAuth2.GitHub.ClientID = "client-id"
+	cfg.Authentication.OAuth2.GitHub.ClientSecret = "client-secret"
+	cfg.FrontendBaseURL = "https://awesome.my"
+
+	return cfg
+}
+
+func TestValidateDatabase(t *testing.T) {
+	// The settings of the API are not needed to reach the database.
+	var cfg awesomemy.Config
+	cfg.Postgres = validConfig().Postgres
+	cfg.GitHub.Enrichment.Enabled = true
+	if err := cfg.ValidateDatabase(); err != nil {
+		t.Fatalf("got %v, want the database configuration to pass", err)
+	}
+
+	cfg.Postgres.Host = ""
+	var ve awesomemy.ValidationErrors
+	if err := cfg.ValidateDatabase(); !errors.As(err, &ve) || len(ve) != 1 || ve[0] != "postgres.host is required" {
+		t.Errorf("got %v, want postgres.host reported", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("got %v, want the valid configuration to pass", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(cfg *awesomemy.Config)
+		// want are the problems reported, nil when the configuration is valid.
+		want []string
+	}{
+		{
+			name: "postgres url instead of fields",
+			modify: func(cfg *awesomemy.Config) {
+				cfg.Postgres = awesomemy.PostgresConfig{URL: "postgres://localhost/awesomemy"}
+			},
+		},
+		{
+			name:   "postgres url scheme",
+			modify: func(cfg *awesomemy.Config) { cfg.Postgres.URL = "mysql://localhost/awesomemy" },
+			want:   []string{"postgres.url must be a postgres:// or postgresql:// URL"},
+		},
+		{
+			name:   "missing fields are all reported",
+			modify: func(cfg *awesomemy.Config) { cfg.Postgres.Name, cfg.FrontendBaseURL = "", "" },
+			want:   []string{"postgres.name is required", "frontend_base_url is required"},
+		},
+		{
+			name:   "port out of range",
+			modify: func(cfg *awesomemy.Config) { cfg.Http.Port = 70000 },
+			want:   []string{"http.port must be a port between 1 and 65535, got 70000"},
+		},
+		{
+			name: "redis is not needed without redis stores",
+			modify: func(cfg *awesomemy.Config) {
+				cfg.Redis = awesomemy.RedisConfig{}
+				cfg.Authentication.Session.Store = "postgres"
+			},
+		},
+		{
+			name:   "unknown session store",
+			modify: func(cfg *awesomemy.Config) { cfg.Authentication.Session.Store = "file" },
+			want:   []string{`authentication.session.store must be one of redis, postgres, memory, got "file"`},
+		},
+		{
+			name: "negative duration",
+			modify: func(cfg *awesomemy.Config) {
+				cfg.Postgres.StatementTimeout = awesomemy.Duration{Duration: -time.Second}
+			},
+			want: []string{"postgres.statement_timeout must not be negative"},
+		},
+		{
+			name: "negative deletion grace period",
+			modify: func(cfg *awesomemy.Config) {
+				cfg.Account.DeletionGracePeriod = awesomemy.Duration{Duration: -time.Hour}
+			},
+			want: []string{"account.deletion_grace_period must not be negative"},
+		},
+		{
+			name: "dev login with secure cookies",
+			modify: func(cfg *awesomemy.Config) {
+				cfg.Authentication.Dev.Enabled = true
+				cfg.Authentication.Session.Secure = true
+			},
+			want: []string{"authentication.dev.enabled is refused when authentication.session.secure is true"},
+		},
+		{
+			name:   "enrichment without token",
+			modify: func(cfg *awesomemy.Config) { cfg.GitHub.Enrichment.Enabled = true },
+			want:   []string{"github.token is required"},
+		},
+		{
+			name:   "smtp without host",
+			modify: func(cfg *awesomemy.Config) { cfg.Mail.Transport = "smtp" },
+			want:   []string{"mail.from is required", "mail.smtp.host is required", "mail.smtp.port must be a port between 1 and 65535, got 0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.modify(&cfg)
+
+			err := cfg.Validate()
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("got %v, want no error", err)
+				}
+				return
+			}
+
+			var ve awesomemy.ValidationErrors
+			if !errors.As(err, &ve) {
+				t.Fatalf("got %v, want ValidationErrors", err)
+			}
+			if strings.Join(ve, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("got %q, want %q", []string(ve), tc.want)
+			}
+		})
+	}
+}
@@ -10,9 +10,10 @@ type ctxKey struct {
 }
 
 var (
-	CtxKeyLogger   = ctxKey{"awesomemy.logger"}
-	CtxKeyConfig   = ctxKey{"awesomemy.config"}
-	CtxKeyAuthUser = ctxKey{"awesomemy.auth.user"}
+	CtxKeyLogger    = ctxKey{"awesomemy.logger"}
+	CtxKeyConfig    = ctxKey{"awesomemy.config"}
+	CtxKeyAuthUser  = ctxKey{"awesomemy.auth.user"}
+	CtxKeyRequestID = ctxKey{"awesomemy.request.id"}
 )
 
 // MustContextValue retrieves a context value of type T with the given key.
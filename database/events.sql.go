@@ -36,6 +36,22 @@ func (q *Queries) CountEventsByTags(ctx context.Context, db DBTX, tags []string)
 	return count, err
 }
 
+const countUpcomingOrganisationEvents = `-- name: CountUpcomingOrganisationEvents :one
+SELECT count(*) FROM events WHERE organisation_id = $1 AND ends_at > $2
+`
+
+type CountUpcomingOrganisationEventsParams struct {
+	OrganisationID nulls.Int32
+	EndsAt         time.Time
+}
+
+func (q *Queries) CountUpcomingOrganisationEvents(ctx context.Context, db DBTX, arg CountUpcomingOrganisationEventsParams) (int64, error) {
+	row := db.QueryRowContext(ctx, countUpcomingOrganisationEvents, arg.OrganisationID, arg.EndsAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserEvents = `-- name: CountUserEvents :one
 SELECT count(*) FROM events WHERE user_id = $1
 `
@@ -57,7 +73,7 @@ func (q *Queries) DeleteEvent(ctx context.Context, db DBTX, eventID int32) error
 }
 
 const eventByUUID = `-- name: EventByUUID :one
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events WHERE uuid = $1 LIMIT 1
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE uuid = $1 LIMIT 1
 `
 
 func (q *Queries) EventByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID) (Event, error) {
@@ -74,12 +90,13 @@ func (q *Queries) EventByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID) (
 		&i.CreatedAt,
 		&i.Website,
 		&i.UserID,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
 const eventsByAscOffsetLimit = `-- name: EventsByAscOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events ORDER BY event_id ASC OFFSET $1 LIMIT $2
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events ORDER BY event_id ASC OFFSET $1 LIMIT $2
 `
 
 type EventsByAscOffsetLimitParams struct {
@@ -107,6 +124,7 @@ func (q *Queries) EventsByAscOffsetLimit(ctx context.Context, db DBTX, arg Event
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -122,7 +140,7 @@ func (q *Queries) EventsByAscOffsetLimit(ctx context.Context, db DBTX, arg Event
 }
 
 const eventsByDescOffsetLimit = `-- name: EventsByDescOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events ORDER BY event_id DESC OFFSET $1 LIMIT $2
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events ORDER BY event_id DESC OFFSET $1 LIMIT $2
 `
 
 type EventsByDescOffsetLimitParams struct {
@@ -150,6 +168,46 @@ func (q *Queries) EventsByDescOffsetLimit(ctx context.Context, db DBTX, arg Even
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const eventsByIDs = `-- name: EventsByIDs :many
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE event_id = ANY($1::int[]) ORDER BY event_id ASC
+`
+
+func (q *Queries) EventsByIDs(ctx context.Context, db DBTX, eventIds []int32) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, eventsByIDs, pq.Array(eventIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+			&i.Website,
+			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -165,7 +223,7 @@ func (q *Queries) EventsByDescOffsetLimit(ctx context.Context, db DBTX, arg Even
 }
 
 const eventsByTagsAscOffsetLimit = `-- name: EventsByTagsAscOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events WHERE tags && $1 ORDER BY event_id ASC OFFSET $2 LIMIT $3
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE tags && $1 ORDER BY event_id ASC OFFSET $2 LIMIT $3
 `
 
 type EventsByTagsAscOffsetLimitParams struct {
@@ -194,6 +252,7 @@ func (q *Queries) EventsByTagsAscOffsetLimit(ctx context.Context, db DBTX, arg E
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -209,7 +268,7 @@ func (q *Queries) EventsByTagsAscOffsetLimit(ctx context.Context, db DBTX, arg E
 }
 
 const eventsByTagsDescOffsetLimit = `-- name: EventsByTagsDescOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events WHERE tags && $1 ORDER BY event_id DESC OFFSET $2 LIMIT $3
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE tags && $1 ORDER BY event_id DESC OFFSET $2 LIMIT $3
 `
 
 type EventsByTagsDescOffsetLimitParams struct {
@@ -238,6 +297,7 @@ func (q *Queries) EventsByTagsDescOffsetLimit(ctx context.Context, db DBTX, arg
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -253,7 +313,7 @@ func (q *Queries) EventsByTagsDescOffsetLimit(ctx context.Context, db DBTX, arg
 }
 
 const insertEvent = `-- name: InsertEvent :one
-INSERT INTO events (name, description, tags, website, starts_at, ends_at, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id
+INSERT INTO events (name, description, tags, website, starts_at, ends_at, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id
 `
 
 type InsertEventParams struct {
@@ -288,12 +348,92 @@ func (q *Queries) InsertEvent(ctx context.Context, db DBTX, arg InsertEventParam
 		&i.CreatedAt,
 		&i.Website,
 		&i.UserID,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
+const setEventOrganisation = `-- name: SetEventOrganisation :one
+UPDATE events SET organisation_id = $1 WHERE event_id = $2 RETURNING event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id
+`
+
+type SetEventOrganisationParams struct {
+	OrganisationID nulls.Int32
+	EventID        int32
+}
+
+func (q *Queries) SetEventOrganisation(ctx context.Context, db DBTX, arg SetEventOrganisationParams) (Event, error) {
+	row := db.QueryRowContext(ctx, setEventOrganisation, arg.OrganisationID, arg.EventID)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Uuid,
+		&i.Name,
+		&i.Description,
+		pq.Array(&i.Tags),
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.Website,
+		&i.UserID,
+		&i.OrganisationID,
+	)
+	return i, err
+}
+
+const upcomingOrganisationEventsByOffsetLimit = `-- name: UpcomingOrganisationEventsByOffsetLimit :many
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE organisation_id = $1 AND ends_at > $2 ORDER BY starts_at ASC, event_id ASC OFFSET $3 LIMIT $4
+`
+
+type UpcomingOrganisationEventsByOffsetLimitParams struct {
+	OrganisationID nulls.Int32
+	EndsAt         time.Time
+	Offset         int32
+	Limit          int32
+}
+
+func (q *Queries) UpcomingOrganisationEventsByOffsetLimit(ctx context.Context, db DBTX, arg UpcomingOrganisationEventsByOffsetLimitParams) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, upcomingOrganisationEventsByOffsetLimit,
+		arg.OrganisationID,
+		arg.EndsAt,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+			&i.Website,
+			&i.UserID,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateEvent = `-- name: UpdateEvent :one
-UPDATE events SET name = $1, description = $2, tags = $3, website = $4, starts_at = $5, ends_at = $6 WHERE event_id = $7 RETURNING event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id
+UPDATE events SET name = $1, description = $2, tags = $3, website = $4, starts_at = $5, ends_at = $6 WHERE event_id = $7 RETURNING event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id
 `
 
 type UpdateEventParams struct {
@@ -328,12 +468,80 @@ func (q *Queries) UpdateEvent(ctx context.Context, db DBTX, arg UpdateEventParam
 		&i.CreatedAt,
 		&i.Website,
 		&i.UserID,
+		&i.OrganisationID,
+	)
+	return i, err
+}
+
+const userEventByName = `-- name: UserEventByName :one
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE user_id = $1 AND name = $2 LIMIT 1
+`
+
+type UserEventByNameParams struct {
+	UserID int32
+	Name   string
+}
+
+func (q *Queries) UserEventByName(ctx context.Context, db DBTX, arg UserEventByNameParams) (Event, error) {
+	row := db.QueryRowContext(ctx, userEventByName, arg.UserID, arg.Name)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Uuid,
+		&i.Name,
+		&i.Description,
+		pq.Array(&i.Tags),
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+		&i.Website,
+		&i.UserID,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
+const userEvents = `-- name: UserEvents :many
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE user_id = $1 ORDER BY event_id ASC
+`
+
+func (q *Queries) UserEvents(ctx context.Context, db DBTX, userID int32) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, userEvents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+			&i.Website,
+			&i.UserID,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const userEventsByAscOffsetLimit = `-- name: UserEventsByAscOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events WHERE user_id = $1 ORDER BY event_id ASC OFFSET $2 LIMIT $3
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE user_id = $1 ORDER BY event_id ASC OFFSET $2 LIMIT $3
 `
 
 type UserEventsByAscOffsetLimitParams struct {
@@ -362,6 +570,7 @@ func (q *Queries) UserEventsByAscOffsetLimit(ctx context.Context, db DBTX, arg U
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -377,7 +586,7 @@ func (q *Queries) UserEventsByAscOffsetLimit(ctx context.Context, db DBTX, arg U
 }
 
 const userEventsByDescOffsetLimit = `-- name: UserEventsByDescOffsetLimit :many
-SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id FROM events WHERE user_id = $1 ORDER BY event_id DESC OFFSET $2 LIMIT $3
+SELECT event_id, uuid, name, description, tags, starts_at, ends_at, created_at, website, user_id, organisation_id FROM events WHERE user_id = $1 ORDER BY event_id DESC OFFSET $2 LIMIT $3
 `
 
 type UserEventsByDescOffsetLimitParams struct {
@@ -406,6 +615,7 @@ func (q *Queries) UserEventsByDescOffsetLimit(ctx context.Context, db DBTX, arg
 			&i.CreatedAt,
 			&i.Website,
 			&i.UserID,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -1,26 +1,81 @@
 package database
 
 import (
+	"bufio"
+	"context"
 	"database/sql"
 	"embed"
+	"io/fs"
+	"strings"
 
 	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
 )
 
 //go:embed migrations/*.sql
 var migrations embed.FS
 
-func Migrate(db *sql.DB) error {
-	goose.SetBaseFS(migrations)
-	goose.SetLogger(goose.NopLogger())
+// MigrationsDir is the directory new migrations are created in, relative to the repository root.
+const MigrationsDir = "database/migrations"
 
-	if err := goose.SetDialect("postgres"); err != nil {
-		return err
+// NewMigrator creates a migration provider over the embedded migrations. Every operation
+// holds a postgres advisory lock so that concurrent deploys do not race.
+func NewMigrator(db *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := goose.Up(db, "migrations"); err != nil {
+	return goose.NewProvider(goose.DialectPostgres, db, fsys, goose.WithSessionLocker(locker))
+}
+
+// Migrate applies all pending embedded migrations.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = migrator.Up(ctx)
+	return err
+}
+
+// MigrationSQL returns the statements of the up or down section of an embedded migration.
+func MigrationSQL(source *goose.Source, up bool) (string, error) {
+	b, err := fs.ReadFile(migrations, "migrations/"+source.Path)
+	if err != nil {
+		return "", err
+	}
+
+	section := "-- +goose Down"
+	if up {
+		section = "-- +goose Up"
+	}
+
+	var sb strings.Builder
+	var inSection bool
+	scanner := bufio.NewScanner(strings.NewReader(string(b)))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "-- +goose Up") || strings.HasPrefix(line, "-- +goose Down") {
+			inSection = strings.HasPrefix(line, section)
+			continue
+		}
+		if !inSection || strings.HasPrefix(line, "-- +goose Statement") {
+			continue
+		}
+
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(sb.String()), nil
 }
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS project_members (
+    project_member_id SERIAL NOT NULL PRIMARY KEY,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('maintainer', 'editor')),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    project_id INT NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    UNIQUE (project_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS project_members_user_id_idx ON project_members (user_id);
+
+CREATE TABLE IF NOT EXISTS project_invitations (
+    project_invitation_id SERIAL NOT NULL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    email VARCHAR(191) NOT NULL,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('maintainer', 'editor')),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    project_id INT NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    invited_by INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    UNIQUE (project_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS project_invitations_email_idx ON project_invitations (email);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE project_invitations;
+DROP TABLE project_members;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organisations (
+    organisation_id SERIAL NOT NULL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(191) NOT NULL,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    logo VARCHAR(191) DEFAULT NULL,
+    description TEXT NOT NULL,
+    website VARCHAR(191) DEFAULT NULL,
+    industry VARCHAR(64) NOT NULL,
+    hq_state VARCHAR(32) NOT NULL,
+    founded_year INT NOT NULL,
+    team_size VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS organisation_members (
+    organisation_member_id SERIAL NOT NULL PRIMARY KEY,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    organisation_id INT NOT NULL REFERENCES organisations(organisation_id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    UNIQUE (organisation_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS organisation_members_user_id_idx ON organisation_members (user_id);
+
+ALTER TABLE projects ADD COLUMN organisation_id INT DEFAULT NULL REFERENCES organisations(organisation_id) ON DELETE SET NULL;
+ALTER TABLE events ADD COLUMN organisation_id INT DEFAULT NULL REFERENCES organisations(organisation_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS projects_organisation_id_idx ON projects (organisation_id);
+CREATE INDEX IF NOT EXISTS events_organisation_id_idx ON events (organisation_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN organisation_id;
+ALTER TABLE projects DROP COLUMN organisation_id;
+DROP TABLE organisation_members;
+DROP TABLE organisations;
+-- +goose StatementEnd
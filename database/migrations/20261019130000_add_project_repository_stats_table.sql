@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS project_repository_stats (
+    project_id INT NOT NULL PRIMARY KEY REFERENCES projects(project_id) ON DELETE CASCADE,
+    repository VARCHAR(191) NOT NULL,
+    found BOOLEAN NOT NULL,
+    stars INT NOT NULL DEFAULT 0,
+    forks INT NOT NULL DEFAULT 0,
+    language VARCHAR(64) DEFAULT NULL,
+    topics TEXT[] DEFAULT NULL,
+    license VARCHAR(64) DEFAULT NULL,
+    pushed_at TIMESTAMP DEFAULT NULL,
+    etag VARCHAR(191) DEFAULT NULL,
+    fetched_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS project_repository_stats_fetched_at_idx ON project_repository_stats (fetched_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE project_repository_stats;
+-- +goose StatementEnd
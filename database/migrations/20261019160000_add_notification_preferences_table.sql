@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INT NOT NULL PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    listing_created BOOLEAN NOT NULL DEFAULT TRUE,
+    listing_changes BOOLEAN NOT NULL DEFAULT TRUE,
+    unsubscribe_token UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_preferences;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS follows (
+    follow_id SERIAL NOT NULL PRIMARY KEY,
+    follower_id INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    followed_user_id INT DEFAULT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    followed_organisation_id INT DEFAULT NULL REFERENCES organisations(organisation_id) ON DELETE CASCADE,
+    followed_tag VARCHAR(12) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (num_nonnulls(followed_user_id, followed_organisation_id, followed_tag) = 1),
+    UNIQUE (follower_id, followed_user_id),
+    UNIQUE (follower_id, followed_organisation_id),
+    UNIQUE (follower_id, followed_tag)
+);
+
+CREATE INDEX IF NOT EXISTS projects_created_at_idx ON projects (created_at, project_id);
+CREATE INDEX IF NOT EXISTS events_created_at_idx ON events (created_at, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX events_created_at_idx;
+DROP INDEX projects_created_at_idx;
+DROP TABLE follows;
+-- +goose StatementEnd
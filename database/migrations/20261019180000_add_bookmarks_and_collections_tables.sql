@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bookmarks (
+    bookmark_id SERIAL NOT NULL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    project_id INT DEFAULT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    event_id INT DEFAULT NULL REFERENCES events(event_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (num_nonnulls(project_id, event_id) = 1),
+    UNIQUE (user_id, project_id),
+    UNIQUE (user_id, event_id)
+);
+
+CREATE TABLE IF NOT EXISTS collections (
+    collection_id SERIAL NOT NULL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(191) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    public BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    user_id INT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS collections_user_id_idx ON collections (user_id);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_item_id SERIAL NOT NULL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    position INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    collection_id INT NOT NULL REFERENCES collections(collection_id) ON DELETE CASCADE,
+    project_id INT DEFAULT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    event_id INT DEFAULT NULL REFERENCES events(event_id) ON DELETE CASCADE,
+    CHECK (num_nonnulls(project_id, event_id) = 1),
+    UNIQUE (collection_id, project_id),
+    UNIQUE (collection_id, event_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_items;
+DROP TABLE collections;
+DROP TABLE bookmarks;
+-- +goose StatementEnd
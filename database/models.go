@@ -5,35 +5,159 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
 )
 
+type Bookmark struct {
+	BookmarkID int32
+	UserID     int32
+	ProjectID  nulls.Int32
+	EventID    nulls.Int32
+	CreatedAt  time.Time
+}
+
+type Collection struct {
+	CollectionID int32
+	Uuid         uuid.UUID
+	Name         string
+	Description  string
+	Public       bool
+	CreatedAt    time.Time
+	UserID       int32
+}
+
+type CollectionItem struct {
+	CollectionItemID int32
+	Uuid             uuid.UUID
+	Position         int32
+	CreatedAt        time.Time
+	CollectionID     int32
+	ProjectID        nulls.Int32
+	EventID          nulls.Int32
+}
+
 type Event struct {
-	EventID     int32
-	Uuid        uuid.UUID
-	Name        string
-	Description string
-	Tags        []string
-	StartsAt    time.Time
-	EndsAt      time.Time
+	EventID        int32
+	Uuid           uuid.UUID
+	Name           string
+	Description    string
+	Tags           []string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	CreatedAt      time.Time
+	Website        nulls.String
+	UserID         int32
+	OrganisationID nulls.Int32
+}
+
+type Follow struct {
+	FollowID               int32
+	FollowerID             int32
+	FollowedUserID         nulls.Int32
+	FollowedOrganisationID nulls.Int32
+	FollowedTag            nulls.String
+	CreatedAt              time.Time
+}
+
+type Job struct {
+	JobID       int32
+	Kind        string
+	Payload     json.RawMessage
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	UniqueKey   nulls.String
+	LastError   nulls.String
+	RunAt       time.Time
+	LockedAt    nulls.Time
+	FinishedAt  nulls.Time
 	CreatedAt   time.Time
-	Website     nulls.String
-	UserID      int32
+}
+
+type NotificationPreference struct {
+	UserID           int32
+	ListingCreated   bool
+	ListingChanges   bool
+	UnsubscribeToken uuid.UUID
+	UpdatedAt        time.Time
+}
+
+type Organisation struct {
+	OrganisationID int32
+	Uuid           uuid.UUID
+	Name           string
+	Slug           string
+	Logo           nulls.String
+	Description    string
+	Website        nulls.String
+	Industry       string
+	HqState        string
+	FoundedYear    int32
+	TeamSize       string
+	CreatedAt      time.Time
+}
+
+type OrganisationMember struct {
+	OrganisationMemberID int32
+	Role                 string
+	CreatedAt            time.Time
+	OrganisationID       int32
+	UserID               int32
 }
 
 type Project struct {
-	ProjectID   int32
-	Uuid        uuid.UUID
-	Name        string
-	Description string
-	Tags        []string
-	UserID      int32
-	CreatedAt   time.Time
-	Repository  nulls.String
-	Website     nulls.String
+	ProjectID      int32
+	Uuid           uuid.UUID
+	Name           string
+	Description    string
+	Tags           []string
+	UserID         int32
+	CreatedAt      time.Time
+	Repository     nulls.String
+	Website        nulls.String
+	OrganisationID nulls.Int32
+}
+
+type ProjectInvitation struct {
+	ProjectInvitationID int32
+	Uuid                uuid.UUID
+	Email               string
+	Role                string
+	CreatedAt           time.Time
+	ProjectID           int32
+	InvitedBy           int32
+}
+
+type ProjectMember struct {
+	ProjectMemberID int32
+	Role            string
+	CreatedAt       time.Time
+	ProjectID       int32
+	UserID          int32
+}
+
+type ProjectRepositoryStat struct {
+	ProjectID  int32
+	Repository string
+	Found      bool
+	Stars      int32
+	Forks      int32
+	Language   nulls.String
+	Topics     []string
+	License    nulls.String
+	PushedAt   nulls.Time
+	Etag       nulls.String
+	FetchedAt  time.Time
+}
+
+type Session struct {
+	Token  string
+	Data   []byte
+	Expiry time.Time
 }
 
 type User struct {
@@ -41,4 +165,45 @@ type User struct {
 	Uuid        uuid.UUID
 	GithubEmail string
 	CreatedAt   time.Time
+	DeletesAt   nulls.Time
+}
+
+type UserSession struct {
+	UserSessionID int32
+	Uuid          uuid.UUID
+	IpAddress     string
+	UserAgent     string
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	ExpiresAt     time.Time
+	UserID        int32
+	Token         string
+}
+
+type Webhook struct {
+	WebhookID  int32
+	Uuid       uuid.UUID
+	Url        string
+	Secret     string
+	EventTypes []string
+	Site       bool
+	Active     bool
+	CreatedAt  time.Time
+	UserID     int32
+}
+
+type WebhookDelivery struct {
+	WebhookDeliveryID int32
+	Uuid              uuid.UUID
+	EventType         string
+	Payload           json.RawMessage
+	Status            string
+	Attempts          int32
+	ResponseStatus    nulls.Int32
+	ResponseBody      nulls.String
+	Error             nulls.String
+	DurationMs        nulls.Int32
+	DeliveredAt       nulls.Time
+	CreatedAt         time.Time
+	WebhookID         int32
 }
@@ -13,6 +13,17 @@ import (
 	"github.com/lib/pq"
 )
 
+const countOrganisationProjects = `-- name: CountOrganisationProjects :one
+SELECT count(*) FROM projects WHERE organisation_id = $1
+`
+
+func (q *Queries) CountOrganisationProjects(ctx context.Context, db DBTX, organisationID nulls.Int32) (int64, error) {
+	row := db.QueryRowContext(ctx, countOrganisationProjects, organisationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countProjects = `-- name: CountProjects :one
 SELECT count(*) FROM projects
 `
@@ -56,7 +67,7 @@ func (q *Queries) DeleteProject(ctx context.Context, db DBTX, projectID int32) e
 }
 
 const insertProject = `-- name: InsertProject :one
-INSERT INTO projects (name, description, tags, repository, website, user_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING project_id, uuid, name, description, tags, user_id, created_at, repository, website
+INSERT INTO projects (name, description, tags, repository, website, user_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id
 `
 
 type InsertProjectParams struct {
@@ -88,12 +99,101 @@ func (q *Queries) InsertProject(ctx context.Context, db DBTX, arg InsertProjectP
 		&i.CreatedAt,
 		&i.Repository,
 		&i.Website,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
+const organisationProjectsByAscOffsetLimit = `-- name: OrganisationProjectsByAscOffsetLimit :many
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE organisation_id = $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3
+`
+
+type OrganisationProjectsByAscOffsetLimitParams struct {
+	OrganisationID nulls.Int32
+	Offset         int32
+	Limit          int32
+}
+
+func (q *Queries) OrganisationProjectsByAscOffsetLimit(ctx context.Context, db DBTX, arg OrganisationProjectsByAscOffsetLimitParams) ([]Project, error) {
+	rows, err := db.QueryContext(ctx, organisationProjectsByAscOffsetLimit, arg.OrganisationID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Repository,
+			&i.Website,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const organisationProjectsByDescOffsetLimit = `-- name: OrganisationProjectsByDescOffsetLimit :many
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE organisation_id = $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3
+`
+
+type OrganisationProjectsByDescOffsetLimitParams struct {
+	OrganisationID nulls.Int32
+	Offset         int32
+	Limit          int32
+}
+
+func (q *Queries) OrganisationProjectsByDescOffsetLimit(ctx context.Context, db DBTX, arg OrganisationProjectsByDescOffsetLimitParams) ([]Project, error) {
+	rows, err := db.QueryContext(ctx, organisationProjectsByDescOffsetLimit, arg.OrganisationID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Repository,
+			&i.Website,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const projectByUUID = `-- name: ProjectByUUID :one
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects WHERE uuid = $1 LIMIT 1
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE uuid = $1 LIMIT 1
 `
 
 func (q *Queries) ProjectByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID) (Project, error) {
@@ -109,12 +209,13 @@ func (q *Queries) ProjectByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID)
 		&i.CreatedAt,
 		&i.Repository,
 		&i.Website,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
 const projectsByAscOffsetLimit = `-- name: ProjectsByAscOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects ORDER BY project_id ASC OFFSET $1 LIMIT $2
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects ORDER BY project_id ASC OFFSET $1 LIMIT $2
 `
 
 type ProjectsByAscOffsetLimitParams struct {
@@ -141,6 +242,7 @@ func (q *Queries) ProjectsByAscOffsetLimit(ctx context.Context, db DBTX, arg Pro
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -156,7 +258,7 @@ func (q *Queries) ProjectsByAscOffsetLimit(ctx context.Context, db DBTX, arg Pro
 }
 
 const projectsByDescOffsetLimit = `-- name: ProjectsByDescOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects ORDER BY project_id DESC OFFSET $1 LIMIT $2
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects ORDER BY project_id DESC OFFSET $1 LIMIT $2
 `
 
 type ProjectsByDescOffsetLimitParams struct {
@@ -183,6 +285,45 @@ func (q *Queries) ProjectsByDescOffsetLimit(ctx context.Context, db DBTX, arg Pr
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const projectsByIDs = `-- name: ProjectsByIDs :many
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE project_id = ANY($1::int[]) ORDER BY project_id ASC
+`
+
+func (q *Queries) ProjectsByIDs(ctx context.Context, db DBTX, projectIds []int32) ([]Project, error) {
+	rows, err := db.QueryContext(ctx, projectsByIDs, pq.Array(projectIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Repository,
+			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +339,7 @@ func (q *Queries) ProjectsByDescOffsetLimit(ctx context.Context, db DBTX, arg Pr
 }
 
 const projectsByTagsAscOffsetLimit = `-- name: ProjectsByTagsAscOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects WHERE tags && $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE tags && $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3
 `
 
 type ProjectsByTagsAscOffsetLimitParams struct {
@@ -226,6 +367,7 @@ func (q *Queries) ProjectsByTagsAscOffsetLimit(ctx context.Context, db DBTX, arg
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -241,7 +383,7 @@ func (q *Queries) ProjectsByTagsAscOffsetLimit(ctx context.Context, db DBTX, arg
 }
 
 const projectsByTagsDescOffsetLimit = `-- name: ProjectsByTagsDescOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects WHERE tags && $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE tags && $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3
 `
 
 type ProjectsByTagsDescOffsetLimitParams struct {
@@ -269,6 +411,7 @@ func (q *Queries) ProjectsByTagsDescOffsetLimit(ctx context.Context, db DBTX, ar
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -283,8 +426,35 @@ func (q *Queries) ProjectsByTagsDescOffsetLimit(ctx context.Context, db DBTX, ar
 	return items, nil
 }
 
+const setProjectOrganisation = `-- name: SetProjectOrganisation :one
+UPDATE projects SET organisation_id = $1 WHERE project_id = $2 RETURNING project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id
+`
+
+type SetProjectOrganisationParams struct {
+	OrganisationID nulls.Int32
+	ProjectID      int32
+}
+
+func (q *Queries) SetProjectOrganisation(ctx context.Context, db DBTX, arg SetProjectOrganisationParams) (Project, error) {
+	row := db.QueryRowContext(ctx, setProjectOrganisation, arg.OrganisationID, arg.ProjectID)
+	var i Project
+	err := row.Scan(
+		&i.ProjectID,
+		&i.Uuid,
+		&i.Name,
+		&i.Description,
+		pq.Array(&i.Tags),
+		&i.UserID,
+		&i.CreatedAt,
+		&i.Repository,
+		&i.Website,
+		&i.OrganisationID,
+	)
+	return i, err
+}
+
 const updateProject = `-- name: UpdateProject :one
-UPDATE projects SET name = $1, description = $2, tags = $3, repository = $4, website = $5 WHERE project_id = $6 RETURNING project_id, uuid, name, description, tags, user_id, created_at, repository, website
+UPDATE projects SET name = $1, description = $2, tags = $3, repository = $4, website = $5 WHERE project_id = $6 RETURNING project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id
 `
 
 type UpdateProjectParams struct {
@@ -316,12 +486,78 @@ func (q *Queries) UpdateProject(ctx context.Context, db DBTX, arg UpdateProjectP
 		&i.CreatedAt,
 		&i.Repository,
 		&i.Website,
+		&i.OrganisationID,
 	)
 	return i, err
 }
 
+const userProjectByName = `-- name: UserProjectByName :one
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE user_id = $1 AND name = $2 LIMIT 1
+`
+
+type UserProjectByNameParams struct {
+	UserID int32
+	Name   string
+}
+
+func (q *Queries) UserProjectByName(ctx context.Context, db DBTX, arg UserProjectByNameParams) (Project, error) {
+	row := db.QueryRowContext(ctx, userProjectByName, arg.UserID, arg.Name)
+	var i Project
+	err := row.Scan(
+		&i.ProjectID,
+		&i.Uuid,
+		&i.Name,
+		&i.Description,
+		pq.Array(&i.Tags),
+		&i.UserID,
+		&i.CreatedAt,
+		&i.Repository,
+		&i.Website,
+		&i.OrganisationID,
+	)
+	return i, err
+}
+
+const userProjects = `-- name: UserProjects :many
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE user_id = $1 ORDER BY project_id ASC
+`
+
+func (q *Queries) UserProjects(ctx context.Context, db DBTX, userID int32) ([]Project, error) {
+	rows, err := db.QueryContext(ctx, userProjects, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.Uuid,
+			&i.Name,
+			&i.Description,
+			pq.Array(&i.Tags),
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Repository,
+			&i.Website,
+			&i.OrganisationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const userProjectsByAscOffsetLimit = `-- name: UserProjectsByAscOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects WHERE user_id = $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE user_id = $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3
 `
 
 type UserProjectsByAscOffsetLimitParams struct {
@@ -349,6 +585,7 @@ func (q *Queries) UserProjectsByAscOffsetLimit(ctx context.Context, db DBTX, arg
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
@@ -364,7 +601,7 @@ func (q *Queries) UserProjectsByAscOffsetLimit(ctx context.Context, db DBTX, arg
 }
 
 const userProjectsByDescOffsetLimit = `-- name: UserProjectsByDescOffsetLimit :many
-SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website FROM projects WHERE user_id = $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3
+SELECT project_id, uuid, name, description, tags, user_id, created_at, repository, website, organisation_id FROM projects WHERE user_id = $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3
 `
 
 type UserProjectsByDescOffsetLimitParams struct {
@@ -392,6 +629,7 @@ func (q *Queries) UserProjectsByDescOffsetLimit(ctx context.Context, db DBTX, ar
 			&i.CreatedAt,
 			&i.Repository,
 			&i.Website,
+			&i.OrganisationID,
 		); err != nil {
 			return nil, err
 		}
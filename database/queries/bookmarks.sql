@@ -0,0 +1,11 @@
+-- name: UserBookmarksByOffsetLimit :many
+SELECT * FROM bookmarks WHERE user_id = $1 ORDER BY bookmark_id DESC OFFSET $2 LIMIT $3;
+
+-- name: CountUserBookmarks :one
+SELECT count(*) FROM bookmarks WHERE user_id = $1;
+
+-- name: InsertBookmark :one
+INSERT INTO bookmarks (user_id, project_id, event_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING RETURNING *;
+
+-- name: DeleteBookmark :execrows
+DELETE FROM bookmarks WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND event_id IS NOT DISTINCT FROM $3;
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- name: UserCollections :many
+SELECT * FROM collections WHERE user_id = $1 ORDER BY collection_id ASC;
+
+-- name: CountUserCollections :one
+SELECT count(*) FROM collections WHERE user_id = $1;
+
+-- name: CollectionByUUID :one
+SELECT * FROM collections WHERE uuid = $1 LIMIT 1;
+
+-- name: InsertCollection :one
+INSERT INTO collections (name, description, public, user_id) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: UpdateCollection :one
+UPDATE collections SET name = $1, description = $2, public = $3 WHERE collection_id = $4 RETURNING *;
+
+-- name: DeleteCollection :exec
+DELETE FROM collections WHERE collection_id = $1;
+
+-- name: CollectionItems :many
+SELECT * FROM collection_items WHERE collection_id = $1 ORDER BY position ASC, collection_item_id ASC;
+
+-- name: CountCollectionItems :one
+SELECT count(*) FROM collection_items WHERE collection_id = $1;
+
+-- name: InsertCollectionItem :one
+INSERT INTO collection_items (collection_id, project_id, event_id, position) SELECT @collection_id, @project_id, @event_id, COALESCE(max(position), 0) + 1 FROM collection_items WHERE collection_id = @collection_id ON CONFLICT DO NOTHING RETURNING *;
+
+-- name: UpdateCollectionItemPosition :exec
+UPDATE collection_items SET position = $1 WHERE collection_item_id = $2;
+
+-- name: DeleteCollectionItem :exec
+DELETE FROM collection_items WHERE collection_item_id = $1;
\ No newline at end of file
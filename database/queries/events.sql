@@ -35,4 +35,22 @@ SELECT * FROM events WHERE tags && $1 ORDER BY event_id ASC OFFSET $2 LIMIT $3;
 SELECT * FROM events WHERE tags && $1 ORDER BY event_id DESC OFFSET $2 LIMIT $3;
 
 -- name: CountEventsByTags :one
-SELECT count(*) FROM events WHERE tags && $1;
\ No newline at end of file
+SELECT count(*) FROM events WHERE tags && $1;
+
+-- name: UserEvents :many
+SELECT * FROM events WHERE user_id = $1 ORDER BY event_id ASC;
+
+-- name: UserEventByName :one
+SELECT * FROM events WHERE user_id = $1 AND name = $2 LIMIT 1;
+
+-- name: UpcomingOrganisationEventsByOffsetLimit :many
+SELECT * FROM events WHERE organisation_id = $1 AND ends_at > $2 ORDER BY starts_at ASC, event_id ASC OFFSET $3 LIMIT $4;
+
+-- name: CountUpcomingOrganisationEvents :one
+SELECT count(*) FROM events WHERE organisation_id = $1 AND ends_at > $2;
+
+-- name: SetEventOrganisation :one
+UPDATE events SET organisation_id = $1 WHERE event_id = $2 RETURNING *;
+
+-- name: EventsByIDs :many
+SELECT * FROM events WHERE event_id = ANY(@event_ids::int[]) ORDER BY event_id ASC;
\ No newline at end of file
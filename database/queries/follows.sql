@@ -0,0 +1,17 @@
+-- name: Follows :many
+SELECT follows.*, users.uuid AS user_uuid, users.github_email AS user_github_email, organisations.uuid AS organisation_uuid, organisations.slug AS organisation_slug, organisations.name AS organisation_name FROM follows LEFT JOIN users ON users.user_id = follows.followed_user_id LEFT JOIN organisations ON organisations.organisation_id = follows.followed_organisation_id WHERE follows.follower_id = $1 ORDER BY follows.follow_id ASC;
+
+-- name: CountFollows :one
+SELECT count(*) FROM follows WHERE follower_id = $1;
+
+-- name: InsertFollow :one
+INSERT INTO follows (follower_id, followed_user_id, followed_organisation_id, followed_tag) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING RETURNING *;
+
+-- name: DeleteFollow :execrows
+DELETE FROM follows WHERE follower_id = $1 AND followed_user_id IS NOT DISTINCT FROM $2 AND followed_organisation_id IS NOT DISTINCT FROM $3 AND followed_tag IS NOT DISTINCT FROM $4;
+
+-- name: FeedProjects :many
+SELECT * FROM projects WHERE user_id <> @follower_id AND (user_id IN (SELECT followed_user_id FROM follows WHERE follower_id = @follower_id) OR organisation_id IN (SELECT followed_organisation_id FROM follows WHERE follower_id = @follower_id) OR tags && ARRAY(SELECT followed_tag FROM follows WHERE follower_id = @follower_id AND followed_tag IS NOT NULL)::text[]) AND (created_at, project_id) < (@before_created_at::timestamp, @before_id::int) ORDER BY created_at DESC, project_id DESC LIMIT @limit;
+
+-- name: FeedEvents :many
+SELECT * FROM events WHERE user_id <> @follower_id AND (user_id IN (SELECT followed_user_id FROM follows WHERE follower_id = @follower_id) OR organisation_id IN (SELECT followed_organisation_id FROM follows WHERE follower_id = @follower_id) OR tags && ARRAY(SELECT followed_tag FROM follows WHERE follower_id = @follower_id AND followed_tag IS NOT NULL)::text[]) AND (created_at, event_id) < (@before_created_at::timestamp, @before_id::int) ORDER BY created_at DESC, event_id DESC LIMIT @limit;
\ No newline at end of file
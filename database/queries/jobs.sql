@@ -0,0 +1,26 @@
+-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, max_attempts, unique_key, run_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (unique_key) DO NOTHING RETURNING *;
+
+-- name: KillExpiredJobs :exec
+UPDATE jobs SET status = 'dead', locked_at = NULL, last_error = @last_error, finished_at = @now WHERE status = 'running' AND locked_at < @lock_expired_before::timestamp AND attempts >= COALESCE(NULLIF(max_attempts, 0), @max_attempts::int);
+
+-- name: ClaimJobs :many
+UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_at = @now::timestamp WHERE job_id IN (SELECT job_id FROM jobs WHERE (jobs.status = 'pending' AND jobs.run_at <= @now) OR (jobs.status = 'running' AND jobs.locked_at < @lock_expired_before::timestamp AND jobs.attempts < COALESCE(NULLIF(jobs.max_attempts, 0), @max_attempts::int)) ORDER BY jobs.run_at ASC, jobs.job_id ASC LIMIT @limit FOR UPDATE SKIP LOCKED) RETURNING *;
+
+-- name: CompleteJob :execrows
+UPDATE jobs SET status = 'completed', locked_at = NULL, finished_at = $1 WHERE job_id = $2 AND locked_at = $3;
+
+-- name: RetryJob :execrows
+UPDATE jobs SET status = 'pending', locked_at = NULL, last_error = $1, run_at = $2 WHERE job_id = $3 AND locked_at = $4;
+
+-- name: KillJob :execrows
+UPDATE jobs SET status = 'dead', locked_at = NULL, last_error = $1, finished_at = $2 WHERE job_id = $3 AND locked_at = $4;
+
+-- name: DeadJobs :many
+SELECT * FROM jobs WHERE status = 'dead' ORDER BY finished_at DESC, job_id DESC LIMIT $1;
+
+-- name: RequeueDeadJob :one
+UPDATE jobs SET status = 'pending', attempts = 0, finished_at = NULL, run_at = $1 WHERE job_id = $2 AND status = 'dead' RETURNING *;
+
+-- name: DeleteCompletedJobs :execrows
+DELETE FROM jobs WHERE status = 'completed' AND finished_at < @finished_before::timestamp;
\ No newline at end of file
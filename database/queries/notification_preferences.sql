@@ -0,0 +1,8 @@
+-- name: NotificationPreferences :one
+INSERT INTO notification_preferences (user_id) VALUES ($1) ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id RETURNING *;
+
+-- name: NotificationPreferencesByUnsubscribeToken :one
+SELECT * FROM notification_preferences WHERE unsubscribe_token = $1 LIMIT 1;
+
+-- name: UpdateNotificationPreferences :one
+INSERT INTO notification_preferences (user_id, listing_created, listing_changes) VALUES ($1, $2, $3) ON CONFLICT (user_id) DO UPDATE SET listing_created = EXCLUDED.listing_created, listing_changes = EXCLUDED.listing_changes, updated_at = now() RETURNING *;
\ No newline at end of file
@@ -0,0 +1,44 @@
+-- name: OrganisationsByAscOffsetLimit :many
+SELECT * FROM organisations WHERE (@industry::varchar = '' OR industry = @industry) AND (@hq_state::varchar = '' OR hq_state = @hq_state) ORDER BY organisation_id ASC OFFSET @offset LIMIT @limit;
+
+-- name: OrganisationsByDescOffsetLimit :many
+SELECT * FROM organisations WHERE (@industry::varchar = '' OR industry = @industry) AND (@hq_state::varchar = '' OR hq_state = @hq_state) ORDER BY organisation_id DESC OFFSET @offset LIMIT @limit;
+
+-- name: CountOrganisations :one
+SELECT count(*) FROM organisations WHERE (@industry::varchar = '' OR industry = @industry) AND (@hq_state::varchar = '' OR hq_state = @hq_state);
+
+-- name: OrganisationByUUID :one
+SELECT * FROM organisations WHERE uuid = $1 LIMIT 1;
+
+-- name: OrganisationBySlug :one
+SELECT * FROM organisations WHERE slug = $1 LIMIT 1;
+
+-- name: InsertOrganisation :one
+INSERT INTO organisations (name, slug, logo, description, website, industry, hq_state, founded_year, team_size) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *;
+
+-- name: UpdateOrganisation :one
+UPDATE organisations SET name = $1, slug = $2, logo = $3, description = $4, website = $5, industry = $6, hq_state = $7, founded_year = $8, team_size = $9 WHERE organisation_id = $10 RETURNING *;
+
+-- name: DeleteOrganisation :exec
+DELETE FROM organisations WHERE organisation_id = $1;
+
+-- name: UserOrganisations :many
+SELECT organisations.* FROM organisations INNER JOIN organisation_members ON organisation_members.organisation_id = organisations.organisation_id WHERE organisation_members.user_id = $1 ORDER BY organisations.organisation_id ASC;
+
+-- name: OrganisationMembers :many
+SELECT organisation_members.*, users.uuid AS user_uuid, users.github_email FROM organisation_members INNER JOIN users ON users.user_id = organisation_members.user_id WHERE organisation_members.organisation_id = $1 ORDER BY organisation_members.organisation_member_id ASC;
+
+-- name: OrganisationMember :one
+SELECT * FROM organisation_members WHERE organisation_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: InsertOrganisationMember :one
+INSERT INTO organisation_members (role, organisation_id, user_id) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: UpdateOrganisationMemberRole :one
+UPDATE organisation_members SET role = $1 WHERE organisation_id = $2 AND user_id = $3 RETURNING *;
+
+-- name: DeleteOrganisationMember :exec
+DELETE FROM organisation_members WHERE organisation_id = $1 AND user_id = $2;
+
+-- name: CountOrganisationOwners :one
+SELECT count(*) FROM organisation_members WHERE organisation_id = $1 AND role = 'owner';
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- name: ProjectMembers :many
+SELECT project_members.*, users.uuid AS user_uuid, users.github_email FROM project_members INNER JOIN users ON users.user_id = project_members.user_id WHERE project_members.project_id = $1 ORDER BY project_members.project_member_id ASC;
+
+-- name: ProjectMember :one
+SELECT * FROM project_members WHERE project_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: InsertProjectMember :one
+INSERT INTO project_members (role, project_id, user_id) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: UpdateProjectMemberRole :one
+UPDATE project_members SET role = $1 WHERE project_id = $2 AND user_id = $3 RETURNING *;
+
+-- name: DeleteProjectMember :exec
+DELETE FROM project_members WHERE project_id = $1 AND user_id = $2;
+
+-- name: MemberProjects :many
+SELECT projects.* FROM projects INNER JOIN project_members ON project_members.project_id = projects.project_id WHERE project_members.user_id = $1 ORDER BY projects.project_id DESC;
+
+-- name: TransferProject :one
+UPDATE projects SET user_id = $1 WHERE project_id = $2 RETURNING *;
+
+-- name: ProjectInvitations :many
+SELECT * FROM project_invitations WHERE project_id = $1 ORDER BY project_invitation_id ASC;
+
+-- name: ProjectInvitationByUUID :one
+SELECT * FROM project_invitations WHERE uuid = $1 LIMIT 1;
+
+-- name: ProjectInvitationByEmail :one
+SELECT * FROM project_invitations WHERE project_id = $1 AND email = $2 LIMIT 1;
+
+-- name: UserProjectInvitations :many
+SELECT project_invitations.*, projects.uuid AS project_uuid, projects.name AS project_name FROM project_invitations INNER JOIN projects ON projects.project_id = project_invitations.project_id WHERE project_invitations.email = $1 ORDER BY project_invitations.project_invitation_id ASC;
+
+-- name: InsertProjectInvitation :one
+INSERT INTO project_invitations (email, role, project_id, invited_by) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: DeleteProjectInvitation :exec
+DELETE FROM project_invitations WHERE project_invitation_id = $1;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- name: ProjectsDueForRepositoryStats :many
+SELECT projects.project_id, projects.repository, project_repository_stats.etag FROM projects LEFT JOIN project_repository_stats ON project_repository_stats.project_id = projects.project_id AND project_repository_stats.repository = projects.repository WHERE projects.repository IS NOT NULL AND (project_repository_stats.fetched_at IS NULL OR project_repository_stats.fetched_at < @stale_before) ORDER BY project_repository_stats.fetched_at ASC NULLS FIRST, projects.project_id ASC LIMIT @limit;
+
+-- name: ProjectRepositoryStats :many
+SELECT project_repository_stats.* FROM project_repository_stats INNER JOIN projects ON projects.project_id = project_repository_stats.project_id AND projects.repository = project_repository_stats.repository WHERE project_repository_stats.project_id = ANY(@project_ids::int[]) AND project_repository_stats.found;
+
+-- name: UpsertProjectRepositoryStats :one
+INSERT INTO project_repository_stats (project_id, repository, found, stars, forks, language, topics, license, pushed_at, etag, fetched_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (project_id) DO UPDATE SET repository = EXCLUDED.repository, found = EXCLUDED.found, stars = EXCLUDED.stars, forks = EXCLUDED.forks, language = EXCLUDED.language, topics = EXCLUDED.topics, license = EXCLUDED.license, pushed_at = EXCLUDED.pushed_at, etag = EXCLUDED.etag, fetched_at = EXCLUDED.fetched_at RETURNING *;
+
+-- name: TouchProjectRepositoryStats :exec
+INSERT INTO project_repository_stats (project_id, repository, found, fetched_at) VALUES (@project_id, @repository, false, @fetched_at) ON CONFLICT (project_id) DO UPDATE SET found = project_repository_stats.found AND project_repository_stats.repository = EXCLUDED.repository, etag = CASE WHEN project_repository_stats.repository = EXCLUDED.repository THEN project_repository_stats.etag END, repository = EXCLUDED.repository, fetched_at = EXCLUDED.fetched_at;
\ No newline at end of file
@@ -35,4 +35,25 @@ SELECT * FROM projects WHERE tags && $1 ORDER BY project_id ASC OFFSET $2 LIMIT
 SELECT * FROM projects WHERE tags && $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3;
 
 -- name: CountProjectsByTags :one
-SELECT count(*) FROM projects WHERE tags && $1;
\ No newline at end of file
+SELECT count(*) FROM projects WHERE tags && $1;
+
+-- name: UserProjects :many
+SELECT * FROM projects WHERE user_id = $1 ORDER BY project_id ASC;
+
+-- name: UserProjectByName :one
+SELECT * FROM projects WHERE user_id = $1 AND name = $2 LIMIT 1;
+
+-- name: OrganisationProjectsByAscOffsetLimit :many
+SELECT * FROM projects WHERE organisation_id = $1 ORDER BY project_id ASC OFFSET $2 LIMIT $3;
+
+-- name: OrganisationProjectsByDescOffsetLimit :many
+SELECT * FROM projects WHERE organisation_id = $1 ORDER BY project_id DESC OFFSET $2 LIMIT $3;
+
+-- name: CountOrganisationProjects :one
+SELECT count(*) FROM projects WHERE organisation_id = $1;
+
+-- name: SetProjectOrganisation :one
+UPDATE projects SET organisation_id = $1 WHERE project_id = $2 RETURNING *;
+
+-- name: ProjectsByIDs :many
+SELECT * FROM projects WHERE project_id = ANY(@project_ids::int[]) ORDER BY project_id ASC;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: TruncateAll :exec
+TRUNCATE users, projects, events, user_sessions, sessions, organisations, jobs, webhooks, notification_preferences, follows, bookmarks, collections RESTART IDENTITY CASCADE;
\ No newline at end of file
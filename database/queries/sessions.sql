@@ -0,0 +1,14 @@
+-- name: SessionDataByToken :one
+SELECT data FROM sessions WHERE token = $1 AND expiry > now() LIMIT 1;
+
+-- name: UpsertSession :exec
+INSERT INTO sessions (token, data, expiry) VALUES ($1, $2, $3) ON CONFLICT (token) DO UPDATE SET data = EXCLUDED.data, expiry = EXCLUDED.expiry;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions WHERE token = $1;
+
+-- name: ActiveSessions :many
+SELECT token, data FROM sessions WHERE expiry > now();
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions WHERE expiry <= now();
\ No newline at end of file
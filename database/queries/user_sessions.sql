@@ -0,0 +1,20 @@
+-- name: InsertUserSession :one
+INSERT INTO user_sessions (token, ip_address, user_agent, last_seen_at, expires_at, user_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: UserSessionByUUID :one
+SELECT * FROM user_sessions WHERE uuid = $1 LIMIT 1;
+
+-- name: UserSessions :many
+SELECT * FROM user_sessions WHERE user_id = $1 AND expires_at > $2 ORDER BY last_seen_at DESC;
+
+-- name: TouchUserSession :exec
+UPDATE user_sessions SET token = $1, ip_address = $2, user_agent = $3, last_seen_at = $4 WHERE uuid = $5;
+
+-- name: DeleteUserSession :exec
+DELETE FROM user_sessions WHERE uuid = $1;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM user_sessions WHERE user_id = $1;
+
+-- name: DeleteExpiredUserSessions :exec
+DELETE FROM user_sessions WHERE expires_at <= $1;
\ No newline at end of file
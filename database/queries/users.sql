@@ -1,8 +1,23 @@
 -- name: UserByGithubEmail :one
-SELECT * FROM users WHERE github_email = $1 LIMIT 1;
+SELECT * FROM users WHERE lower(github_email) = lower(@github_email) LIMIT 1;
 
 -- name: UserByUUID :one
 SELECT * FROM users WHERE uuid = $1 LIMIT 1;
 
+-- name: UserByID :one
+SELECT * FROM users WHERE user_id = $1 LIMIT 1;
+
 -- name: InsertUser :one
-INSERT INTO users (github_email) VALUES ($1) RETURNING *;
\ No newline at end of file
+INSERT INTO users (github_email) VALUES ($1) RETURNING *;
+
+-- name: ScheduleUserDeletion :one
+UPDATE users SET deletes_at = $1 WHERE user_id = $2 RETURNING *;
+
+-- name: CancelUserDeletion :one
+UPDATE users SET deletes_at = NULL WHERE user_id = $1 RETURNING *;
+
+-- name: DeleteDueUsers :many
+DELETE FROM users WHERE deletes_at <= $1 RETURNING user_id;
+
+-- name: CountUsers :one
+SELECT count(*) FROM users;
\ No newline at end of file
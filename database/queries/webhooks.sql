@@ -0,0 +1,44 @@
+-- name: UserWebhooks :many
+SELECT * FROM webhooks WHERE user_id = $1 ORDER BY webhook_id ASC;
+
+-- name: CountUserWebhooks :one
+SELECT count(*) FROM webhooks WHERE user_id = $1;
+
+-- name: WebhookByUUID :one
+SELECT * FROM webhooks WHERE uuid = $1 LIMIT 1;
+
+-- name: WebhookByID :one
+SELECT * FROM webhooks WHERE webhook_id = $1 LIMIT 1;
+
+-- name: InsertWebhook :one
+INSERT INTO webhooks (url, secret, event_types, site, active, user_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks SET url = $1, event_types = $2, site = $3, active = $4 WHERE webhook_id = $5 RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE webhook_id = $1;
+
+-- name: SubscribedWebhooks :many
+SELECT * FROM webhooks WHERE active AND @event_type::text = ANY(event_types) AND (site OR user_id = @user_id) ORDER BY webhook_id ASC;
+
+-- name: InsertWebhookDelivery :one
+INSERT INTO webhook_deliveries (uuid, event_type, payload, webhook_id) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: WebhookDeliveryByUUID :one
+SELECT * FROM webhook_deliveries WHERE uuid = $1 LIMIT 1;
+
+-- name: WebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries WHERE webhook_delivery_id = $1 LIMIT 1;
+
+-- name: WebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY webhook_delivery_id DESC OFFSET $2 LIMIT $3;
+
+-- name: CountWebhookDeliveries :one
+SELECT count(*) FROM webhook_deliveries WHERE webhook_id = $1;
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries SET status = $1, attempts = attempts + 1, response_status = $2, response_body = $3, error = $4, duration_ms = $5, delivered_at = $6 WHERE webhook_delivery_id = $7 RETURNING *;
+
+-- name: DeleteWebhookDeliveries :execrows
+DELETE FROM webhook_deliveries WHERE created_at < @created_before::timestamp;
\ No newline at end of file
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Open opens a postgres database whose queries are traced with the global tracer provider,
+// spans are named after the sqlc query being executed.
+func Open(dsn string) (*sql.DB, error) {
+	return otelsql.Open(
+		"postgres",
+		dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanNameFormatter(querySpanName),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			OmitRows:             true,
+		}),
+	)
+}
+
+// querySpanName names a span after the "-- name: X :kind" header sqlc prepends to its
+// queries, falling back to the database/sql method for any other statement.
+func querySpanName(_ context.Context, method otelsql.Method, query string) string {
+	name, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return string(method)
+	}
+
+	if i := strings.IndexAny(name, " \r\n"); i >= 0 {
+		name = name[:i]
+	}
+
+	return "sql " + name
+}
@@ -8,11 +8,67 @@ package database
 import (
 	"context"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
 )
 
+const cancelUserDeletion = `-- name: CancelUserDeletion :one
+UPDATE users SET deletes_at = NULL WHERE user_id = $1 RETURNING user_id, uuid, github_email, created_at, deletes_at
+`
+
+func (q *Queries) CancelUserDeletion(ctx context.Context, db DBTX, userID int32) (User, error) {
+	row := db.QueryRowContext(ctx, cancelUserDeletion, userID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Uuid,
+		&i.GithubEmail,
+		&i.CreatedAt,
+		&i.DeletesAt,
+	)
+	return i, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT count(*) FROM users
+`
+
+func (q *Queries) CountUsers(ctx context.Context, db DBTX) (int64, error) {
+	row := db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteDueUsers = `-- name: DeleteDueUsers :many
+DELETE FROM users WHERE deletes_at <= $1 RETURNING user_id
+`
+
+func (q *Queries) DeleteDueUsers(ctx context.Context, db DBTX, deletesAt nulls.Time) ([]int32, error) {
+	rows, err := db.QueryContext(ctx, deleteDueUsers, deletesAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertUser = `-- name: InsertUser :one
-INSERT INTO users (github_email) VALUES ($1) RETURNING user_id, uuid, github_email, created_at
+INSERT INTO users (github_email) VALUES ($1) RETURNING user_id, uuid, github_email, created_at, deletes_at
 `
 
 func (q *Queries) InsertUser(ctx context.Context, db DBTX, githubEmail string) (User, error) {
@@ -23,12 +79,35 @@ func (q *Queries) InsertUser(ctx context.Context, db DBTX, githubEmail string) (
 		&i.Uuid,
 		&i.GithubEmail,
 		&i.CreatedAt,
+		&i.DeletesAt,
+	)
+	return i, err
+}
+
+const scheduleUserDeletion = `-- name: ScheduleUserDeletion :one
+UPDATE users SET deletes_at = $1 WHERE user_id = $2 RETURNING user_id, uuid, github_email, created_at, deletes_at
+`
+
+type ScheduleUserDeletionParams struct {
+	DeletesAt nulls.Time
+	UserID    int32
+}
+
+func (q *Queries) ScheduleUserDeletion(ctx context.Context, db DBTX, arg ScheduleUserDeletionParams) (User, error) {
+	row := db.QueryRowContext(ctx, scheduleUserDeletion, arg.DeletesAt, arg.UserID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Uuid,
+		&i.GithubEmail,
+		&i.CreatedAt,
+		&i.DeletesAt,
 	)
 	return i, err
 }
 
 const userByGithubEmail = `-- name: UserByGithubEmail :one
-SELECT user_id, uuid, github_email, created_at FROM users WHERE github_email = $1 LIMIT 1
+SELECT user_id, uuid, github_email, created_at, deletes_at FROM users WHERE lower(github_email) = lower($1) LIMIT 1
 `
 
 func (q *Queries) UserByGithubEmail(ctx context.Context, db DBTX, githubEmail string) (User, error) {
@@ -39,12 +118,30 @@ func (q *Queries) UserByGithubEmail(ctx context.Context, db DBTX, githubEmail st
 		&i.Uuid,
 		&i.GithubEmail,
 		&i.CreatedAt,
+		&i.DeletesAt,
+	)
+	return i, err
+}
+
+const userByID = `-- name: UserByID :one
+SELECT user_id, uuid, github_email, created_at, deletes_at FROM users WHERE user_id = $1 LIMIT 1
+`
+
+func (q *Queries) UserByID(ctx context.Context, db DBTX, userID int32) (User, error) {
+	row := db.QueryRowContext(ctx, userByID, userID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Uuid,
+		&i.GithubEmail,
+		&i.CreatedAt,
+		&i.DeletesAt,
 	)
 	return i, err
 }
 
 const userByUUID = `-- name: UserByUUID :one
-SELECT user_id, uuid, github_email, created_at FROM users WHERE uuid = $1 LIMIT 1
+SELECT user_id, uuid, github_email, created_at, deletes_at FROM users WHERE uuid = $1 LIMIT 1
 `
 
 func (q *Queries) UserByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID) (User, error) {
@@ -55,6 +152,7 @@ func (q *Queries) UserByUUID(ctx context.Context, db DBTX, argUuid uuid.UUID) (U
 		&i.Uuid,
 		&i.GithubEmail,
 		&i.CreatedAt,
+		&i.DeletesAt,
 	)
 	return i, err
 }
@@ -0,0 +1,275 @@
+// Package githubtest provides a stand-in for the GitHub OAuth2 provider and the parts of the
+// GitHub API used by the login flow and the repository enrichment, so they can be exercised
+// in tests.
+package githubtest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/awesome-my/backend"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	ClientID     = "githubtest-client-id"
+	ClientSecret = "githubtest-client-secret"
+	// APIToken is the token the repository requests must be authorized with.
+	APIToken = "githubtest-api-token"
+)
+
+type authorization struct {
+	challenge string
+	email     string
+}
+
+// Server is a mock GitHub that authorizes every request as the account with Email.
+type Server struct {
+	*httptest.Server
+
+	// CallbackURL is where authorizations redirect to when the request has no redirect_uri,
+	// like the callback URL registered with a GitHub OAuth app.
+	CallbackURL string
+
+	mu             sync.Mutex
+	email          string
+	authorizations map[string]authorization
+	tokens         map[string]string
+	repositories   map[string][]byte
+	failures       map[string]int
+	repoRequests   int
+	notModified    int
+}
+
+// NewServer starts a mock GitHub that logs in as the account with the given primary email.
+func NewServer(email string) *Server {
+	s := &Server{
+		email:          email,
+		authorizations: make(map[string]authorization),
+		tokens:         make(map[string]string),
+		repositories:   make(map[string][]byte),
+		failures:       make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /login/oauth/authorize", s.authorize)
+	mux.HandleFunc("POST /login/oauth/access_token", s.accessToken)
+	mux.HandleFunc("GET /user/emails", s.userEmails)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", s.repository)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// SetEmail changes the account that subsequent authorizations log in as.
+func (s *Server) SetEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.email = email
+}
+
+// Configure points the GitHub OAuth2 configuration and the GitHub API at the server.
+func (s *Server) Configure(cfg *awesomemy.Config) {
+	cfg.Authentication.OAuth2.GitHub.ClientID = ClientID
+	cfg.Authentication.OAuth2.GitHub.ClientSecret = ClientSecret
+	cfg.Authentication.OAuth2.GitHub.AuthURL = s.URL + "/login/oauth/authorize"
+	cfg.Authentication.OAuth2.GitHub.TokenURL = s.URL + "/login/oauth/access_token"
+	cfg.Authentication.OAuth2.GitHub.APIURL = s.URL + "/"
+	cfg.GitHub.APIURL = s.URL + "/"
+	cfg.GitHub.Token = APIToken
+}
+
+// SetRepository serves repo as the GitHub repository owner/name, e.g. with the keys
+// stargazers_count, forks_count, language, topics, license and pushed_at. A nil repo makes
+// the repository not found.
+func (s *Server) SetRepository(owner, name string, repo map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(owner + "/" + name)
+	if repo == nil {
+		delete(s.repositories, key)
+		return
+	}
+
+	b, _ := json.Marshal(repo)
+	s.repositories[key] = b
+}
+
+// FailRepository answers requests for the GitHub repository owner/name with status, such as
+// 500 Internal Server Error. A zero status serves the repository again.
+func (s *Server) FailRepository(owner, name string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(owner + "/" + name)
+	if status == 0 {
+		delete(s.failures, key)
+		return
+	}
+
+	s.failures[key] = status
+}
+
+// RepositoryRequests returns how many requests were made for repositories and how many of
+// them were answered with 304 Not Modified.
+func (s *Server) RepositoryRequests() (total, notModified int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repoRequests, s.notModified
+}
+
+// Authorize grants an authorization for the PKCE challenge and returns its code, as if the
+// user approved the OAuth app on GitHub.
+func (s *Server) Authorize(challenge string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := uuid.Must(uuid.NewV4()).String()
+	s.authorizations[code] = authorization{
+		challenge: challenge,
+		email:     s.email,
+	}
+
+	return code
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != ClientID || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURL := q.Get("redirect_uri")
+	if redirectURL == "" {
+		redirectURL = s.CallbackURL
+	}
+	u, err := url.Parse(redirectURL)
+	if err != nil || redirectURL == "" {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	callbackQuery := u.Query()
+	callbackQuery.Set("code", s.Authorize(q.Get("code_challenge")))
+	callbackQuery.Set("state", q.Get("state"))
+	u.RawQuery = callbackQuery.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *Server) accessToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid token request", http.StatusBadRequest)
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != ClientID || clientSecret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "incorrect_client_credentials"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := r.PostForm.Get("code")
+	auth, ok := s.authorizations[code]
+	delete(s.authorizations, code)
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "bad_verification_code"})
+		return
+	}
+
+	token := uuid.Must(uuid.NewV4()).String()
+	s.tokens[token] = auth.email
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": token,
+		"token_type":   "bearer",
+		"scope":        "read:user,user:email",
+	})
+}
+
+func (s *Server) userEmails(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	email, ok := s.tokens[bearerToken(r)]
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, []map[string]any{
+		{"email": email, "primary": true, "verified": true, "visibility": "private"},
+	})
+}
+
+// repository serves a repository with an ETag, answering 304 Not Modified to requests made
+// with a matching If-None-Match like GitHub does.
+func (s *Server) repository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.repoRequests++
+	if bearerToken(r) != APIToken {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return
+	}
+
+	key := strings.ToLower(r.PathValue("owner") + "/" + r.PathValue("repo"))
+	if status, ok := s.failures[key]; ok {
+		writeJSON(w, status, map[string]string{"message": http.StatusText(status)})
+		return
+	}
+
+	b, ok := s.repositories[key]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+
+	sum := sha256.Sum256(b)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+
+	h := r.Header.Get("Authorization")
+	if len(h) > len(prefix) && h[:len(prefix)] == prefix {
+		return h[len(prefix):]
+	}
+
+	return ""
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
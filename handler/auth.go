@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -9,26 +8,28 @@ import (
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/awesome-my/backend"
-	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
 	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
 	"golang.org/x/oauth2"
 )
 
 type Auth struct {
 	logger         *slog.Logger
 	config         awesomemy.Config
-	database       *sql.DB
-	queries        *database.Queries
+	store          store.Store
 	sessionManager *scs.SessionManager
+	metrics        *Metrics
 }
 
-func NewAuth(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB, sm *scs.SessionManager) http.Handler {
+func NewAuth(logger *slog.Logger, cfg awesomemy.Config, st store.Store, sm *scs.SessionManager, metrics *Metrics) http.Handler {
 	a := &Auth{
 		logger:         logger,
 		config:         cfg,
-		database:       db,
-		queries:        database.New(),
+		store:          st,
 		sessionManager: sm,
+		metrics:        metrics,
 	}
 
 	r := chi.NewRouter()
@@ -38,12 +39,21 @@ func NewAuth(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB, sm *scs.Sess
 	})
 	r.Post("/logout", a.Logout)
 
+	if cfg.Authentication.Dev.Enabled {
+		if cfg.Authentication.Session.Secure {
+			logger.Error("refusing to enable development login with secure session cookies")
+		} else {
+			logger.Warn("development login is enabled, never enable it in production")
+			r.Post("/dev/login", a.DevLogin)
+		}
+	}
+
 	return r
 }
 
 func (a *Auth) OAuth2(w http.ResponseWriter, r *http.Request) {
 	if err := a.sessionManager.RenewToken(r.Context()); err != nil {
-		a.logger.Error("could not renew request session token", slog.Any("err", err))
+		requestLogger(r, a.logger).Error("could not renew request session token", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not renew the request session token.",
@@ -61,6 +71,7 @@ func (a *Auth) OAuth2(w http.ResponseWriter, r *http.Request) {
 func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 	verifier := a.sessionManager.GetString(r.Context(), "oauth2:verifier")
 	if verifier == "" {
+		a.metrics.OAuth2Login("github", "missing_verifier")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "The request is missing PKCE code.",
@@ -70,6 +81,7 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 
 	code := r.URL.Query().Get("code")
 	if code == "" {
+		a.metrics.OAuth2Login("github", "missing_code")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "The request is missing GitHub OAuth2 code.",
@@ -77,9 +89,11 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	oauth2Ctx := oauth2Context(r.Context())
 	oauth2Cfg := a.config.Authentication.OAuth2.OAuth2Config("github")
-	token, err := oauth2Cfg.Exchange(r.Context(), code, oauth2.VerifierOption(verifier))
+	token, err := oauth2Cfg.Exchange(oauth2Ctx, code, oauth2.VerifierOption(verifier))
 	if err != nil {
+		a.metrics.OAuth2Login("github", "invalid_token")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "The GitHub OAuth2 token is invalid.",
@@ -87,9 +101,10 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	githubEmail, err := githubOAuth2Email(r.Context(), oauth2Cfg, token)
+	githubEmail, err := githubOAuth2Email(oauth2Ctx, oauth2Cfg, a.config.Authentication.OAuth2.GitHub.APIURL, token)
 	if err != nil {
-		a.logger.Error("could not fetch github oauth2 account details", slog.Any("err", err))
+		requestLogger(r, a.logger).Error("could not fetch github oauth2 account details", slog.Any("err", err))
+		a.metrics.OAuth2Login("github", "provider_error")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch account details from GitHub.",
@@ -97,12 +112,21 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := a.queries.UserByGithubEmail(r.Context(), a.database, githubEmail)
+	user, err := a.store.UserByGithubEmail(r.Context(), githubEmail)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			user, err = a.queries.InsertUser(r.Context(), a.database, githubEmail)
+		if errors.Is(err, store.ErrNotFound) {
+			err = a.store.WithTx(r.Context(), func(tx store.Store) error {
+				var err error
+				user, err = tx.InsertUser(r.Context(), githubEmail)
+				if err != nil {
+					return err
+				}
+
+				return notify.Enqueue(r.Context(), tx, user.UserID, notify.Welcome, nil)
+			})
 			if err != nil {
-				a.logger.Error("could not insert user by github email", slog.Any("err", err))
+				requestLogger(r, a.logger).Error("could not insert user by github email", slog.Any("err", err))
+				a.metrics.OAuth2Login("github", "database_error")
 				w.WriteHeader(http.StatusInternalServerError)
 				json.NewEncoder(w).Encode(map[string]string{
 					"message": "Could not insert user into database.",
@@ -110,7 +134,8 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		} else {
-			a.logger.Error("could not fetch user by github email", slog.Any("err", err))
+			requestLogger(r, a.logger).Error("could not fetch user by github email", slog.Any("err", err))
+			a.metrics.OAuth2Login("github", "database_error")
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{
 				"message": "Could not fetch user by GitHub email.",
@@ -120,7 +145,8 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := a.sessionManager.RenewToken(r.Context()); err != nil {
-		a.logger.Error("could not renew request session token", slog.Any("err", err))
+		requestLogger(r, a.logger).Error("could not renew request session token", slog.Any("err", err))
+		a.metrics.OAuth2Login("github", "session_error")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not renew the request session token.",
@@ -130,18 +156,39 @@ func (a *Auth) OAuth2Callback(w http.ResponseWriter, r *http.Request) {
 
 	a.sessionManager.Put(r.Context(), "user:uuid", user.Uuid.String())
 
+	if err := trackSession(r, a.store, a.sessionManager, user); err != nil {
+		requestLogger(r, a.logger).Error("could not track user session", slog.Any("err", err))
+		a.metrics.OAuth2Login("github", "session_error")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not track the request session.",
+		})
+		return
+	}
+
+	a.metrics.OAuth2Login("github", "success")
+
 	http.Redirect(w, r, a.config.FrontendBaseURL, http.StatusTemporaryRedirect)
 }
 
 func (a *Auth) Logout(w http.ResponseWriter, r *http.Request) {
-	if err := a.sessionManager.RenewToken(r.Context()); err != nil {
-		a.logger.Error("could not renew request session token", slog.Any("err", err))
+	if sessionUuid, err := uuid.FromString(a.sessionManager.GetString(r.Context(), "session:uuid")); err == nil {
+		if err := a.store.DeleteUserSession(r.Context(), sessionUuid); err != nil {
+			requestLogger(r, a.logger).Error("could not delete user session", slog.Any("err", err))
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "Could not delete the request session.",
+			})
+			return
+		}
+	}
+
+	if err := a.sessionManager.Destroy(r.Context()); err != nil {
+		requestLogger(r, a.logger).Error("could not destroy request session", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not renew the request session token.",
+			"message": "Could not destroy the request session.",
 		})
 		return
 	}
-
-	a.sessionManager.Put(r.Context(), "user:uuid", "")
 }
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/awesome-my/backend/store"
+)
+
+// DevLogin logs in as an existing user by email without going through OAuth2, it is only
+// routed when the development login is enabled.
+func (a *Auth) DevLogin(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil || data.Email == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body must contain the email of the user to log in as.",
+		})
+		return
+	}
+
+	user, err := a.store.UserByGithubEmail(r.Context(), data.Email)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The user you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, a.logger).Error("could not fetch user by github email", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user by GitHub email.",
+		})
+		return
+	}
+
+	if err := a.sessionManager.RenewToken(r.Context()); err != nil {
+		requestLogger(r, a.logger).Error("could not renew request session token", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not renew the request session token.",
+		})
+		return
+	}
+
+	a.sessionManager.Put(r.Context(), "user:uuid", user.Uuid.String())
+
+	if err := trackSession(r, a.store, a.sessionManager, user); err != nil {
+		requestLogger(r, a.logger).Error("could not track user session", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not track the request session.",
+		})
+		return
+	}
+
+	requestLogger(r, a.logger).Warn("logged in through development login", slog.String("user_uuid", user.Uuid.String()))
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": UserFromDatabase(user),
+	})
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -10,43 +9,98 @@ import (
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/awesome-my/backend"
-	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofrs/uuid"
 )
 
+const (
+	maxUserProjects    = 20
+	maxUserEvents      = 20
+	maxUserWebhooks    = 10
+	maxUserFollows     = 100
+	maxUserBookmarks   = 500
+	maxUserCollections = 20
+	maxCollectionItems = 100
+)
+
 type Client struct {
 	logger         *slog.Logger
 	config         awesomemy.Config
-	database       *sql.DB
-	queries        *database.Queries
+	store          store.Store
 	sessionManager *scs.SessionManager
 	validator      *validator.Validate
+	deliverer      *webhooks.Deliverer
 }
 
-func NewClient(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB, sm *scs.SessionManager) http.Handler {
+func NewClient(logger *slog.Logger, cfg awesomemy.Config, st store.Store, sm *scs.SessionManager) http.Handler {
 	c := &Client{
 		logger:         logger,
 		config:         cfg,
-		database:       db,
-		queries:        database.New(),
+		store:          st,
 		sessionManager: sm,
 		validator:      validator.New(),
+		deliverer:      webhooks.NewDeliverer(logger, cfg.Webhooks, st),
 	}
 
 	r := chi.NewRouter()
 	r.Use(c.AuthenticateUser)
 	r.Route("/account", func(r chi.Router) {
 		r.Get("/", c.Account)
+		r.Delete("/", c.DeleteAccount)
+		r.Post("/restore", c.RestoreAccount)
+		r.Get("/export", c.ExportAccount)
+		r.Get("/notifications", c.NotificationPreferences)
+		r.Post("/notifications", c.UpdateNotificationPreferences)
+		r.Route("/sessions", func(r chi.Router) {
+			r.Get("/", c.Sessions)
+			r.Delete("/", c.DeleteSessions)
+			r.Delete("/{session}", c.DeleteSession)
+		})
 	})
+	r.Get("/export", c.Export)
+	r.Post("/import", c.Import)
 	r.Route("/projects", func(r chi.Router) {
 		r.Get("/", c.Projects)
 		r.Post("/", c.StoreProject)
+		r.Get("/shared", c.SharedProjects)
 		r.Route("/{project}", func(r chi.Router) {
 			r.Get("/", c.Project)
 			r.Post("/", c.UpdateProject)
 			r.Delete("/", c.DeleteProject)
+			r.Post("/transfer", c.TransferProject)
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", c.ProjectMembers)
+				r.Post("/{user}", c.UpdateProjectMember)
+				r.Delete("/{user}", c.DeleteProjectMember)
+			})
+			r.Route("/invitations", func(r chi.Router) {
+				r.Get("/", c.ProjectInvitations)
+				r.Post("/", c.StoreProjectInvitation)
+				r.Delete("/{invitation}", c.DeleteProjectInvitation)
+			})
+		})
+	})
+	r.Route("/invitations", func(r chi.Router) {
+		r.Get("/", c.Invitations)
+		r.Post("/{invitation}/accept", c.AcceptInvitation)
+		r.Post("/{invitation}/decline", c.DeclineInvitation)
+	})
+	r.Route("/organisations", func(r chi.Router) {
+		r.Get("/", c.Organisations)
+		r.Post("/", c.StoreOrganisation)
+		r.Route("/{organisation}", func(r chi.Router) {
+			r.Get("/", c.Organisation)
+			r.Post("/", c.UpdateOrganisation)
+			r.Delete("/", c.DeleteOrganisation)
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", c.OrganisationMembers)
+				r.Post("/", c.StoreOrganisationMember)
+				r.Post("/{user}", c.UpdateOrganisationMember)
+				r.Delete("/{user}", c.DeleteOrganisationMember)
+			})
 		})
 	})
 	r.Route("/events", func(r chi.Router) {
@@ -58,6 +112,50 @@ func NewClient(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB, sm *scs.Se
 			r.Delete("/", c.DeleteEvent)
 		})
 	})
+	r.Route("/follows", func(r chi.Router) {
+		r.Get("/", c.Follows)
+		r.Post("/users/{user}", c.FollowUser)
+		r.Delete("/users/{user}", c.UnfollowUser)
+		r.Post("/organisations/{organisation}", c.FollowOrganisation)
+		r.Delete("/organisations/{organisation}", c.UnfollowOrganisation)
+		r.Post("/tags/{tag}", c.FollowTag)
+		r.Delete("/tags/{tag}", c.UnfollowTag)
+	})
+	r.Get("/feed", c.Feed)
+	r.Route("/bookmarks", func(r chi.Router) {
+		r.Get("/", c.Bookmarks)
+		r.Post("/projects/{project}", c.BookmarkProject)
+		r.Delete("/projects/{project}", c.UnbookmarkProject)
+		r.Post("/events/{event}", c.BookmarkEvent)
+		r.Delete("/events/{event}", c.UnbookmarkEvent)
+	})
+	r.Route("/collections", func(r chi.Router) {
+		r.Get("/", c.Collections)
+		r.Post("/", c.StoreCollection)
+		r.Route("/{collection}", func(r chi.Router) {
+			r.Get("/", c.Collection)
+			r.Post("/", c.UpdateCollection)
+			r.Delete("/", c.DeleteCollection)
+			r.Post("/items", c.StoreCollectionItem)
+			r.Post("/items/order", c.OrderCollectionItems)
+			r.Delete("/items/{item}", c.DeleteCollectionItem)
+		})
+	})
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", c.Webhooks)
+		r.Post("/", c.StoreWebhook)
+		r.Route("/{webhook}", func(r chi.Router) {
+			r.Get("/", c.Webhook)
+			r.Post("/", c.UpdateWebhook)
+			r.Delete("/", c.DeleteWebhook)
+			r.Post("/ping", c.PingWebhook)
+			r.Route("/deliveries", func(r chi.Router) {
+				r.Get("/", c.WebhookDeliveries)
+				r.Get("/{delivery}", c.WebhookDelivery)
+				r.Post("/{delivery}/redeliver", c.RedeliverWebhookDelivery)
+			})
+		})
+	})
 
 	return r
 }
@@ -73,9 +171,9 @@ func (c *Client) AuthenticateUser(next http.Handler) http.Handler {
 			return
 		}
 
-		user, err := c.queries.UserByUUID(r.Context(), c.database, userUuid)
+		user, err := c.store.UserByUUID(r.Context(), userUuid)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			if errors.Is(err, store.ErrNotFound) {
 				w.WriteHeader(http.StatusUnauthorized)
 				json.NewEncoder(w).Encode(map[string]string{
 					"message": "You are not authorized to access this resource.",
@@ -83,7 +181,7 @@ func (c *Client) AuthenticateUser(next http.Handler) http.Handler {
 				return
 			}
 
-			c.logger.Error("could not fetch user by uuid", slog.Any("err", err))
+			requestLogger(r, c.logger).Error("could not fetch user by uuid", slog.Any("err", err))
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{
 				"message": "Could not fetch user.",
@@ -91,6 +189,16 @@ func (c *Client) AuthenticateUser(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), awesomemy.CtxKeyAuthUser, user)))
+		if c.sessionManager.Exists(r.Context(), "session:uuid") {
+			err = touchSession(r, c.store, c.sessionManager)
+		} else {
+			err = trackSession(r, c.store, c.sessionManager, user)
+		}
+		if err != nil {
+			requestLogger(r, c.logger).Error("could not record user session activity", slog.Any("err", err))
+		}
+
+		ctx := withRequestUser(r, c.logger, user.Uuid.String())
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, awesomemy.CtxKeyAuthUser, user)))
 	})
 }
@@ -1,19 +1,24 @@
 package handler
 
 import (
+	"archive/zip"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
+	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
 )
 
 type User struct {
-	Uuid        uuid.UUID `json:"uuid"`
-	GitHubEmail string    `json:"github_email"`
-	CreatedAt   time.Time `json:"created_at"`
+	Uuid        uuid.UUID  `json:"uuid"`
+	GitHubEmail string     `json:"github_email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeletesAt   nulls.Time `json:"deletes_at"`
 }
 
 func UserFromDatabase(u database.User) User {
@@ -21,6 +26,7 @@ func UserFromDatabase(u database.User) User {
 		Uuid:        u.Uuid,
 		GitHubEmail: u.GithubEmail,
 		CreatedAt:   u.CreatedAt,
+		DeletesAt:   u.DeletesAt,
 	}
 }
 
@@ -31,3 +37,177 @@ func (c *Client) Account(w http.ResponseWriter, r *http.Request) {
 		"item": UserFromDatabase(authUser),
 	})
 }
+
+func (c *Client) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	var data struct {
+		Confirmation string `json:"confirmation" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if !strings.EqualFold(data.Confirmation, authUser.GithubEmail) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The confirmation does not match your account email.",
+		})
+		return
+	}
+
+	user, err := c.store.ScheduleUserDeletion(r.Context(), database.ScheduleUserDeletionParams{
+		DeletesAt: nulls.NewTime(time.Now().Add(c.config.Account.GracePeriod())),
+		UserID:    authUser.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not schedule user deletion", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not schedule account deletion.",
+		})
+		return
+	}
+
+	if err := destroyUserSessions(r.Context(), c.store, c.sessionManager, user.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not destroy user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not revoke account sessions.",
+		})
+		return
+	}
+
+	if err := c.sessionManager.Destroy(r.Context()); err != nil {
+		requestLogger(r, c.logger).Error("could not destroy request session", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not revoke account sessions.",
+		})
+		return
+	}
+
+	if err := c.store.DeleteUserSessions(r.Context(), user.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete account sessions.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": UserFromDatabase(user),
+	})
+}
+
+func (c *Client) RestoreAccount(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	user, err := c.store.CancelUserDeletion(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not cancel user deletion", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not cancel account deletion.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": UserFromDatabase(user),
+	})
+}
+
+func (c *Client) ExportAccount(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	projects, err := c.store.AllUserProjects(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user projects", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user projects.",
+		})
+		return
+	}
+
+	events, err := c.store.AllUserEvents(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user events", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user events.",
+		})
+		return
+	}
+
+	sessions, err := c.store.UserSessions(r.Context(), authUser.UserID, time.Now())
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user sessions.",
+		})
+		return
+	}
+
+	apiProjects := make([]Project, len(projects))
+	for i, p := range projects {
+		apiProjects[i] = ProjectFromDatabase(p)
+	}
+
+	apiEvents := make([]Event, len(events))
+	for i, e := range events {
+		apiEvents[i] = EventFromDatabase(e)
+	}
+
+	currentUuid := c.sessionManager.GetString(r.Context(), "session:uuid")
+	apiSessions := make([]Session, len(sessions))
+	for i, s := range sessions {
+		apiSessions[i] = SessionFromDatabase(s, currentUuid)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="awesomemy-account.zip"`)
+
+	zw := zip.NewWriter(w)
+	for _, file := range []struct {
+		name string
+		data any
+	}{
+		{"profile.json", UserFromDatabase(authUser)},
+		{"projects.json", apiProjects},
+		{"events.json", apiEvents},
+		{"sessions.json", apiSessions},
+	} {
+		f, err := zw.Create(file.name)
+		if err != nil {
+			requestLogger(r, c.logger).Error("could not create account export file", slog.Any("err", err), slog.String("name", file.name))
+			return
+		}
+
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			requestLogger(r, c.logger).Error("could not encode account export file", slog.Any("err", err), slog.String("name", file.name))
+			return
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		requestLogger(r, c.logger).Error("could not close account export archive", slog.Any("err", err))
+	}
+}
@@ -0,0 +1,276 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/handler"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+func TestClientAccount(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+
+	var res itemResponse[handler.User]
+	api.login(alice).expect(http.StatusOK, http.MethodGet, "/client/account", nil, &res)
+	if res.Item.Uuid != alice.Uuid || res.Item.GitHubEmail != alice.GithubEmail || res.Item.DeletesAt.Valid {
+		t.Errorf("got %+v, want the account of %s", res.Item, alice.GithubEmail)
+	}
+}
+
+func TestClientDeleteAccount(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	c := api.login(alice)
+	other := api.login(alice)
+
+	c.expect(http.StatusBadRequest, http.MethodDelete, "/client/account", map[string]string{}, nil)
+	c.expect(http.StatusBadRequest, http.MethodDelete, "/client/account", map[string]string{"confirmation": "bob@example.com"}, nil)
+
+	var res itemResponse[handler.User]
+	c.expect(http.StatusOK, http.MethodDelete, "/client/account", map[string]string{"confirmation": "ALICE@example.com"}, &res)
+	if !res.Item.DeletesAt.Valid || res.Item.DeletesAt.Time.Before(time.Now()) {
+		t.Errorf("got deletes at %v, want a deletion after the grace period", res.Item.DeletesAt)
+	}
+
+	// Every session of the account is revoked.
+	c.expect(http.StatusUnauthorized, http.MethodGet, "/client/account", nil, nil)
+	other.expect(http.StatusUnauthorized, http.MethodGet, "/client/account", nil, nil)
+}
+
+func TestClientRestoreAccount(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	if _, err := api.store.ScheduleUserDeletion(context.Background(), database.ScheduleUserDeletionParams{
+		DeletesAt: nulls.NewTime(time.Now().Add(time.Hour)),
+		UserID:    alice.UserID,
+	}); err != nil {
+		t.Fatalf("could not schedule user deletion: %v", err)
+	}
+
+	var res itemResponse[handler.User]
+	api.login(alice).expect(http.StatusOK, http.MethodPost, "/client/account/restore", nil, &res)
+	if res.Item.DeletesAt.Valid {
+		t.Errorf("got deletes at %v, want the deletion cancelled", res.Item.DeletesAt)
+	}
+}
+
+func TestClientExportAccount(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+	event := api.event(alice, "Kuala Lumpur Golang Meetup")
+	api.project(api.user("bob@example.com"), "Pasar Hub Project")
+
+	status, header, body := api.login(alice).raw(http.MethodGet, "/client/account/export", "", nil)
+	if status != http.StatusOK || header.Get("Content-Type") != "application/zip" {
+		t.Fatalf("got status %d and content type %s, want a zip archive", status, header.Get("Content-Type"))
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("could not read zip archive: %v", err)
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if !slices.Equal(names, []string{"profile.json", "projects.json", "events.json", "sessions.json"}) {
+		t.Fatalf("got files %v", names)
+	}
+
+	var projects []handler.Project
+	readZipJSON(t, zr, "projects.json", &projects)
+	if len(projects) != 1 || projects[0].Uuid != project.Uuid {
+		t.Errorf("got projects %v, want only the projects of the user", projects)
+	}
+
+	var events []handler.Event
+	readZipJSON(t, zr, "events.json", &events)
+	if len(events) != 1 || events[0].Uuid != event.Uuid {
+		t.Errorf("got events %v, want only the events of the user", events)
+	}
+
+	var sessions []handler.Session
+	readZipJSON(t, zr, "sessions.json", &sessions)
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Errorf("got sessions %v, want the current session", sessions)
+	}
+}
+
+func readZipJSON(t *testing.T, zr *zip.Reader, name string, out any) {
+	t.Helper()
+
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatalf("could not open %s: %v", name, err)
+	}
+	defer func(f io.Closer) {
+		_ = f.Close()
+	}(f)
+
+	if err := json.NewDecoder(f).Decode(out); err != nil {
+		t.Fatalf("could not decode %s: %v", name, err)
+	}
+}
+
+func TestClientSessions(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	c := api.login(alice)
+	other := api.login(alice)
+	bob := api.login(api.user("bob@example.com"))
+
+	var res itemsResponse[handler.Session]
+	c.expect(http.StatusOK, http.MethodGet, "/client/account/sessions", nil, &res)
+	if len(res.Items) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(res.Items))
+	}
+
+	var otherUuid uuid.UUID
+	var current int
+	for _, s := range res.Items {
+		if s.Current {
+			current++
+		} else {
+			otherUuid = s.Uuid
+		}
+	}
+	if current != 1 {
+		t.Fatalf("got %d current sessions, want 1", current)
+	}
+
+	// The sessions of other users are not found.
+	bob.expect(http.StatusNotFound, http.MethodDelete, "/client/account/sessions/"+otherUuid.String(), nil, nil)
+	other.expect(http.StatusOK, http.MethodGet, "/client/account", nil, nil)
+
+	c.expect(http.StatusOK, http.MethodDelete, "/client/account/sessions/"+otherUuid.String(), nil, nil)
+	other.expect(http.StatusUnauthorized, http.MethodGet, "/client/account", nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/account/sessions/"+otherUuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/account/sessions/not-a-uuid", nil, nil)
+
+	c.expect(http.StatusOK, http.MethodDelete, "/client/account/sessions", nil, nil)
+	c.expect(http.StatusUnauthorized, http.MethodGet, "/client/account", nil, nil)
+	bob.expect(http.StatusOK, http.MethodGet, "/client/account", nil, nil)
+}
+
+type importResponse struct {
+	DryRun    bool                   `json:"dry_run"`
+	Committed bool                   `json:"committed"`
+	Items     []handler.ImportResult `json:"items"`
+}
+
+func TestClientExportImport(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	api.project(alice, "Kedai Pay Project", "fintech")
+	api.event(alice, "Kuala Lumpur Golang Meetup", "golang")
+	c := api.login(alice)
+
+	// Importing an export of the same account updates every row in place.
+	for _, format := range []string{"json", "csv", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			c := c.with(t)
+
+			status, _, body := c.raw(http.MethodGet, "/client/export?format="+format, "", nil)
+			if status != http.StatusOK {
+				t.Fatalf("got export status %d, want %d", status, http.StatusOK)
+			}
+
+			var res importResponse
+			status, _, body = c.raw(http.MethodPost, "/client/import?format="+format, "", body)
+			if status != http.StatusOK {
+				t.Fatalf("got import status %d, want %d: %s", status, http.StatusOK, body)
+			}
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatalf("could not decode import response: %v", err)
+			}
+			if !res.Committed || len(res.Items) != 2 {
+				t.Fatalf("got %+v, want 2 committed rows", res)
+			}
+			for _, item := range res.Items {
+				if item.Action != "updated" {
+					t.Errorf("got %s for %s, want updated", item.Action, item.Name)
+				}
+			}
+		})
+	}
+
+	var transfer handler.Transfer
+	c.expect(http.StatusOK, http.MethodGet, "/client/export", nil, &transfer)
+
+	bob := api.user("bob@example.com")
+	bc := api.login(bob)
+
+	// The rows reference the projects and events of alice by UUID, which bob cannot update.
+	var res importResponse
+	bc.expect(http.StatusBadRequest, http.MethodPost, "/client/import", transfer, &res)
+	if res.Committed || len(res.Items) != 2 {
+		t.Fatalf("got %+v, want 2 failed rows", res)
+	}
+
+	for i := range transfer.Projects {
+		transfer.Projects[i].Uuid = ""
+	}
+	for i := range transfer.Events {
+		transfer.Events[i].Uuid = ""
+	}
+
+	res = importResponse{}
+	bc.expect(http.StatusOK, http.MethodPost, "/client/import?dry_run=true", transfer, &res)
+	if !res.DryRun || res.Committed || len(res.Items) != 2 {
+		t.Fatalf("got %+v, want a dry run of 2 rows", res)
+	}
+	if count, _ := api.store.CountUserProjects(context.Background(), bob.UserID); count != 0 {
+		t.Fatalf("got %d projects after a dry run, want none", count)
+	}
+
+	res = importResponse{}
+	bc.expect(http.StatusOK, http.MethodPost, "/client/import", transfer, &res)
+	if !res.Committed || len(res.Items) != 2 {
+		t.Fatalf("got %+v, want 2 committed rows", res)
+	}
+	for _, item := range res.Items {
+		if item.Action != "created" {
+			t.Errorf("got %s for %s, want created", item.Action, item.Name)
+		}
+	}
+
+	bc.expect(http.StatusBadRequest, http.MethodPost, "/client/import", map[string]any{"projects": "none"}, nil)
+
+	// Rows of a CSV that cannot be read fail on their own, reported by their line.
+	csv := "type,name,description,starts_at,ends_at\n" +
+		"project,Pasar Hub Project,The description of Pasar Hub Project.,,\n" +
+		"event,Penang Rust Meetup,The description of Penang Rust Meetup.,tomorrow,2030-01-01T00:00:00Z\n" +
+		"meetup,Ipoh Meetup,,,\n"
+	res = importResponse{}
+	status, _, body := bc.raw(http.MethodPost, "/client/import?format=csv", "text/csv", []byte(csv))
+	if status != http.StatusBadRequest {
+		t.Fatalf("got import status %d, want %d: %s", status, http.StatusBadRequest, body)
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatalf("could not decode import response: %v", err)
+	}
+	if len(res.Items) != 3 {
+		t.Fatalf("got %+v, want 3 rows", res.Items)
+	}
+	for i, want := range []struct {
+		row    int
+		action string
+	}{{2, "created"}, {3, "failed"}, {4, "failed"}} {
+		if res.Items[i].Row != want.row || res.Items[i].Action != want.action {
+			t.Errorf("got %+v, want row %d %s", res.Items[i], want.row, want.action)
+		}
+	}
+}
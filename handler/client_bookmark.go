@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// Bookmark is a project or event bookmarked, only the field of its type is set.
+type Bookmark struct {
+	// Type is project or event.
+	Type      string    `json:"type"`
+	Project   *Project  `json:"project"`
+	Event     *Event    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// listings are the projects and events pointed at by bookmarks or collection items, by ID.
+type listings struct {
+	projects map[int32]Project
+	events   map[int32]Event
+}
+
+func listingsFromDatabase(ctx context.Context, st store.Store, projectIDs, eventIDs []int32) (listings, error) {
+	l := listings{
+		projects: make(map[int32]Project, len(projectIDs)),
+		events:   make(map[int32]Event, len(eventIDs)),
+	}
+
+	if len(projectIDs) > 0 {
+		projects, err := st.ProjectsByIDs(ctx, projectIDs)
+		if err != nil {
+			return listings{}, err
+		}
+
+		apiProjects, err := projectsFromDatabase(ctx, st, projects)
+		if err != nil {
+			return listings{}, err
+		}
+
+		for i, p := range projects {
+			l.projects[p.ProjectID] = apiProjects[i]
+		}
+	}
+
+	if len(eventIDs) > 0 {
+		events, err := st.EventsByIDs(ctx, eventIDs)
+		if err != nil {
+			return listings{}, err
+		}
+
+		for _, e := range events {
+			l.events[e.EventID] = EventFromDatabase(e)
+		}
+	}
+
+	return l, nil
+}
+
+// get returns the type along with the project or event of a record pointing at either.
+func (l listings) get(projectID, eventID nulls.Int32) (string, *Project, *Event) {
+	if projectID.Valid {
+		project := l.projects[projectID.Int32]
+		return "project", &project, nil
+	}
+
+	event := l.events[eventID.Int32]
+	return "event", nil, &event
+}
+
+// listingIDs splits the IDs of the projects and events records point at.
+func listingIDs[T any](records []T, ids func(T) (nulls.Int32, nulls.Int32)) ([]int32, []int32) {
+	var projectIDs, eventIDs []int32
+	for _, r := range records {
+		projectID, eventID := ids(r)
+		if projectID.Valid {
+			projectIDs = append(projectIDs, projectID.Int32)
+		}
+		if eventID.Valid {
+			eventIDs = append(eventIDs, eventID.Int32)
+		}
+	}
+
+	return projectIDs, eventIDs
+}
+
+// anyProject fetches the project of the request, which may belong to any user. It writes a
+// not found response and returns false when the project does not exist.
+func (c *Client) anyProject(w http.ResponseWriter, r *http.Request) (database.Project, bool) {
+	projectUuid, err := uuid.FromString(chi.URLParam(r, "project"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Project{}, false
+	}
+
+	project, err := c.store.ProjectByUUID(r.Context(), projectUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Project{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch project by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project.",
+		})
+		return database.Project{}, false
+	}
+
+	return project, true
+}
+
+// anyEvent fetches the event of the request, which may belong to any user. It writes a not
+// found response and returns false when the event does not exist.
+func (c *Client) anyEvent(w http.ResponseWriter, r *http.Request) (database.Event, bool) {
+	eventUuid, err := uuid.FromString(chi.URLParam(r, "event"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Event{}, false
+	}
+
+	event, err := c.store.EventByUUID(r.Context(), eventUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Event{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch event by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch event.",
+		})
+		return database.Event{}, false
+	}
+
+	return event, true
+}
+
+func (c *Client) Bookmarks(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
+
+	bookmarks, err := c.store.UserBookmarks(r.Context(), authUser.UserID, store.Page{
+		Offset: int32(offset),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user bookmarks by limit offset", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch bookmarks.",
+		})
+		return
+	}
+
+	total, err := c.store.CountUserBookmarks(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user bookmarks count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch bookmarks count.",
+		})
+		return
+	}
+
+	projectIDs, eventIDs := listingIDs(bookmarks, func(b database.Bookmark) (nulls.Int32, nulls.Int32) {
+		return b.ProjectID, b.EventID
+	})
+	l, err := listingsFromDatabase(r.Context(), c.store, projectIDs, eventIDs)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch bookmarked projects and events", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch bookmarks.",
+		})
+		return
+	}
+
+	apiBookmarks := make([]Bookmark, len(bookmarks))
+	for i, b := range bookmarks {
+		apiBookmarks[i].Type, apiBookmarks[i].Project, apiBookmarks[i].Event = l.get(b.ProjectID, b.EventID)
+		apiBookmarks[i].CreatedAt = b.CreatedAt
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      apiBookmarks,
+		"pagination": awesomemy.NewPaginationMeta(page, len(bookmarks), int(total)),
+	})
+}
+
+// bookmark has the user bookmark what params point at, responding with bookmark once it is
+// bookmarked.
+func (c *Client) bookmark(w http.ResponseWriter, r *http.Request, user database.User, params database.InsertBookmarkParams, bookmark Bookmark) {
+	count, err := c.store.CountUserBookmarks(r.Context(), user.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user bookmarks count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch bookmarks count.",
+		})
+		return
+	}
+
+	if count >= maxUserBookmarks {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You have hit the bookmark limit, try removing some old bookmarks.",
+		})
+		return
+	}
+
+	params.UserID = user.UserID
+	inserted, err := c.store.InsertBookmark(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, store.ErrBookmarkExists) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "You already bookmarked this " + bookmark.Type + ".",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not insert bookmark", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert bookmark into database.",
+		})
+		return
+	}
+
+	bookmark.CreatedAt = inserted.CreatedAt
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": bookmark,
+	})
+}
+
+// unbookmark removes the bookmark of the user on what params point at.
+func (c *Client) unbookmark(w http.ResponseWriter, r *http.Request, user database.User, params database.DeleteBookmarkParams) {
+	params.UserID = user.UserID
+	if err := c.store.DeleteBookmark(r.Context(), params); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not delete bookmark", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete bookmark.",
+		})
+		return
+	}
+}
+
+func (c *Client) BookmarkProject(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, ok := c.anyProject(w, r)
+	if !ok {
+		return
+	}
+
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, []database.Project{project})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
+	c.bookmark(w, r, authUser, database.InsertBookmarkParams{
+		ProjectID: nulls.NewInt32(project.ProjectID),
+	}, Bookmark{
+		Type:    "project",
+		Project: &apiProjects[0],
+	})
+}
+
+func (c *Client) UnbookmarkProject(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, ok := c.anyProject(w, r)
+	if !ok {
+		return
+	}
+
+	c.unbookmark(w, r, authUser, database.DeleteBookmarkParams{
+		ProjectID: nulls.NewInt32(project.ProjectID),
+	})
+}
+
+func (c *Client) BookmarkEvent(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	event, ok := c.anyEvent(w, r)
+	if !ok {
+		return
+	}
+
+	apiEvent := EventFromDatabase(event)
+	c.bookmark(w, r, authUser, database.InsertBookmarkParams{
+		EventID: nulls.NewInt32(event.EventID),
+	}, Bookmark{
+		Type:  "event",
+		Event: &apiEvent,
+	})
+}
+
+func (c *Client) UnbookmarkEvent(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	event, ok := c.anyEvent(w, r)
+	if !ok {
+		return
+	}
+
+	c.unbookmark(w, r, authUser, database.DeleteBookmarkParams{
+		EventID: nulls.NewInt32(event.EventID),
+	})
+}
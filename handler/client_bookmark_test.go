@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/gofrs/uuid"
+)
+
+func TestClientBookmarks(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	project := api.project(bob, "Kedai Pay Project")
+	event := api.event(bob, "Golang Meetup")
+	ac := api.login(alice)
+
+	var res itemResponse[handler.Bookmark]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/bookmarks/projects/"+project.Uuid.String(), nil, &res)
+	if res.Item.Type != "project" || res.Item.Project == nil || res.Item.Project.Uuid != project.Uuid {
+		t.Fatalf("got %+v, want the project of bob bookmarked", res.Item)
+	}
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/bookmarks/projects/"+project.Uuid.String(), nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodPost, "/client/bookmarks/projects/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+	ac.expect(http.StatusOK, http.MethodPost, "/client/bookmarks/events/"+event.Uuid.String(), nil, nil)
+
+	var list itemsResponse[handler.Bookmark]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/bookmarks", nil, &list)
+	if len(list.Items) != 2 || list.Pagination.Total != 2 {
+		t.Fatalf("got %+v, want both bookmarks", list.Items)
+	}
+	if list.Items[0].Type != "event" || list.Items[0].Event.Uuid != event.Uuid || list.Items[1].Project.Name != project.Name {
+		t.Errorf("got %+v, want the event bookmarked last first", list.Items)
+	}
+
+	// Bookmarks are private to each user.
+	list = itemsResponse[handler.Bookmark]{}
+	api.login(bob).expect(http.StatusOK, http.MethodGet, "/client/bookmarks", nil, &list)
+	if len(list.Items) != 0 {
+		t.Errorf("got %+v, want no bookmark for bob", list.Items)
+	}
+
+	ac.expect(http.StatusOK, http.MethodDelete, "/client/bookmarks/events/"+event.Uuid.String(), nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodDelete, "/client/bookmarks/events/"+event.Uuid.String(), nil, nil)
+
+	// Deleting a project removes its bookmarks.
+	api.login(bob).expect(http.StatusOK, http.MethodDelete, "/client/projects/"+project.Uuid.String(), nil, nil)
+	list = itemsResponse[handler.Bookmark]{}
+	ac.expect(http.StatusOK, http.MethodGet, "/client/bookmarks", nil, &list)
+	if len(list.Items) != 0 {
+		t.Errorf("got %+v, want the bookmarks removed", list.Items)
+	}
+}
@@ -0,0 +1,515 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+type storeCollectionData struct {
+	Name        string `json:"name" validate:"required,min=2,max=191"`
+	Description string `json:"description" validate:"max=2048"`
+	Public      bool   `json:"public"`
+}
+
+type Collection struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Public      bool      `json:"public"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func CollectionFromDatabase(c database.Collection) Collection {
+	return Collection{
+		Uuid:        c.Uuid,
+		Name:        c.Name,
+		Description: c.Description,
+		Public:      c.Public,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+// CollectionItem is a project or event of a collection, only the field of its type is set.
+type CollectionItem struct {
+	Uuid uuid.UUID `json:"uuid"`
+	// Type is project or event.
+	Type      string    `json:"type"`
+	Project   *Project  `json:"project"`
+	Event     *Event    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CollectionWithItems is a collection along with its items in order.
+type CollectionWithItems struct {
+	Collection
+	Items []CollectionItem `json:"items"`
+}
+
+// collectionWithItems fetches the items of a collection along with their project or event.
+func collectionWithItems(ctx context.Context, st store.Store, collection database.Collection) (CollectionWithItems, error) {
+	items, err := st.CollectionItems(ctx, collection.CollectionID)
+	if err != nil {
+		return CollectionWithItems{}, err
+	}
+
+	projectIDs, eventIDs := listingIDs(items, func(ci database.CollectionItem) (nulls.Int32, nulls.Int32) {
+		return ci.ProjectID, ci.EventID
+	})
+	l, err := listingsFromDatabase(ctx, st, projectIDs, eventIDs)
+	if err != nil {
+		return CollectionWithItems{}, err
+	}
+
+	apiCollection := CollectionWithItems{
+		Collection: CollectionFromDatabase(collection),
+		Items:      make([]CollectionItem, len(items)),
+	}
+	for i, ci := range items {
+		apiCollection.Items[i].Uuid = ci.Uuid
+		apiCollection.Items[i].Type, apiCollection.Items[i].Project, apiCollection.Items[i].Event = l.get(ci.ProjectID, ci.EventID)
+		apiCollection.Items[i].CreatedAt = ci.CreatedAt
+	}
+
+	return apiCollection, nil
+}
+
+// authorizeCollection fetches the collection of the request. It writes a not found response
+// and returns false when the collection does not exist or is not owned by the user.
+func (c *Client) authorizeCollection(w http.ResponseWriter, r *http.Request, user database.User) (database.Collection, bool) {
+	collectionUuid, err := uuid.FromString(chi.URLParam(r, "collection"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Collection{}, false
+	}
+
+	collection, err := c.store.CollectionByUUID(r.Context(), collectionUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Collection{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch collection by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection.",
+		})
+		return database.Collection{}, false
+	}
+
+	if collection.UserID != user.UserID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Collection{}, false
+	}
+
+	return collection, true
+}
+
+// decodeCollectionData decodes and validates the collection of the request body. It writes a
+// bad request response and returns false when it is malformed.
+func (c *Client) decodeCollectionData(w http.ResponseWriter, r *http.Request) (storeCollectionData, bool) {
+	var data storeCollectionData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeCollectionData{}, false
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeCollectionData{}, false
+	}
+
+	return data, true
+}
+
+// writeCollection responds with a collection along with its items.
+func (c *Client) writeCollection(w http.ResponseWriter, r *http.Request, collection database.Collection) {
+	apiCollection, err := collectionWithItems(r.Context(), c.store, collection)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch collection items", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection items.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": apiCollection,
+	})
+}
+
+func (c *Client) Collections(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collections, err := c.store.UserCollections(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user collections", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collections.",
+		})
+		return
+	}
+
+	apiCollections := make([]Collection, len(collections))
+	for i, collection := range collections {
+		apiCollections[i] = CollectionFromDatabase(collection)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiCollections,
+	})
+}
+
+func (c *Client) Collection(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	c.writeCollection(w, r, collection)
+}
+
+func (c *Client) StoreCollection(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	data, ok := c.decodeCollectionData(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := c.store.CountUserCollections(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user collections count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user collections count.",
+		})
+		return
+	}
+
+	if count >= maxUserCollections {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You have hit the collection limit, try deleting some unused collections.",
+		})
+		return
+	}
+
+	collection, err := c.store.InsertCollection(r.Context(), database.InsertCollectionParams{
+		Name:        data.Name,
+		Description: data.Description,
+		Public:      data.Public,
+		UserID:      authUser.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert collection", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert collection into database.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": CollectionWithItems{
+			Collection: CollectionFromDatabase(collection),
+			Items:      []CollectionItem{},
+		},
+	})
+}
+
+func (c *Client) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	data, ok := c.decodeCollectionData(w, r)
+	if !ok {
+		return
+	}
+
+	collection, err := c.store.UpdateCollection(r.Context(), database.UpdateCollectionParams{
+		Name:         data.Name,
+		Description:  data.Description,
+		Public:       data.Public,
+		CollectionID: collection.CollectionID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update collection", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update collection.",
+		})
+		return
+	}
+
+	c.writeCollection(w, r, collection)
+}
+
+func (c *Client) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if err := c.store.DeleteCollection(r.Context(), collection.CollectionID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete collection", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete collection.",
+		})
+		return
+	}
+}
+
+// StoreCollectionItem adds a project or event of any user after the last item of the
+// collection.
+func (c *Client) StoreCollectionItem(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	var data struct {
+		Type string `json:"type" validate:"required,oneof=project event"`
+		Uuid string `json:"uuid" validate:"required,uuid"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	count, err := c.store.CountCollectionItems(r.Context(), collection.CollectionID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch collection items count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection items count.",
+		})
+		return
+	}
+
+	if count >= maxCollectionItems {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The collection is full, try removing some items.",
+		})
+		return
+	}
+
+	params := database.InsertCollectionItemParams{CollectionID: collection.CollectionID}
+	if data.Type == "project" {
+		var project database.Project
+		project, err = c.store.ProjectByUUID(r.Context(), uuid.FromStringOrNil(data.Uuid))
+		params.ProjectID = nulls.NewInt32(project.ProjectID)
+	} else {
+		var event database.Event
+		event, err = c.store.EventByUUID(r.Context(), uuid.FromStringOrNil(data.Uuid))
+		params.EventID = nulls.NewInt32(event.EventID)
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The " + data.Type + " could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch "+data.Type+" by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch " + data.Type + ".",
+		})
+		return
+	}
+
+	if _, err := c.store.InsertCollectionItem(r.Context(), params); err != nil {
+		if errors.Is(err, store.ErrCollectionItemExists) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The collection already holds this " + data.Type + ".",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not insert collection item", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert collection item into database.",
+		})
+		return
+	}
+
+	c.writeCollection(w, r, collection)
+}
+
+// OrderCollectionItems moves the items of the collection in the order of their uuids, which
+// must list every item once.
+func (c *Client) OrderCollectionItems(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	var data struct {
+		Items []string `json:"items" validate:"dive,uuid"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	items, err := c.store.CollectionItems(r.Context(), collection.CollectionID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch collection items", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection items.",
+		})
+		return
+	}
+
+	itemIDs := make(map[uuid.UUID]int32, len(items))
+	for _, ci := range items {
+		itemIDs[ci.Uuid] = ci.CollectionItemID
+	}
+
+	ordered := make([]int32, 0, len(data.Items))
+	for _, itemUuid := range data.Items {
+		id, ok := itemIDs[uuid.FromStringOrNil(itemUuid)]
+		if !ok {
+			break
+		}
+		// Dropping the item catches an item listed twice.
+		delete(itemIDs, uuid.FromStringOrNil(itemUuid))
+		ordered = append(ordered, id)
+	}
+	if len(ordered) != len(data.Items) || len(itemIDs) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The items must list every item of the collection once.",
+		})
+		return
+	}
+
+	if err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		for i, id := range ordered {
+			if err := tx.UpdateCollectionItemPosition(r.Context(), id, int32(i+1)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		requestLogger(r, c.logger).Error("could not update collection item positions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update collection items.",
+		})
+		return
+	}
+
+	c.writeCollection(w, r, collection)
+}
+
+func (c *Client) DeleteCollectionItem(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	collection, ok := c.authorizeCollection(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	items, err := c.store.CollectionItems(r.Context(), collection.CollectionID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch collection items", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection items.",
+		})
+		return
+	}
+
+	itemUuid := uuid.FromStringOrNil(chi.URLParam(r, "item"))
+	for _, ci := range items {
+		if ci.Uuid != itemUuid {
+			continue
+		}
+
+		if err := c.store.DeleteCollectionItem(r.Context(), ci.CollectionItemID); err != nil {
+			requestLogger(r, c.logger).Error("could not delete collection item", slog.Any("err", err))
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "Could not delete collection item.",
+			})
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "The resource you are looking for could not be found.",
+	})
+}
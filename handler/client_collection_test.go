@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/gofrs/uuid"
+)
+
+func TestClientCollections(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	first := api.project(bob, "Kedai Pay Project")
+	second := api.event(bob, "Golang Meetup")
+	third := api.project(alice, "Pasar Hub Project")
+	ac := api.login(alice)
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/collections", map[string]any{"name": "x"}, nil)
+
+	var res itemResponse[handler.CollectionWithItems]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/collections", map[string]any{
+		"name":        "Made in Malaysia",
+		"description": "The best of the local scene.",
+	}, &res)
+	if res.Item.Public || len(res.Item.Items) != 0 {
+		t.Fatalf("got %+v, want an empty private collection", res.Item)
+	}
+	path := "/client/collections/" + res.Item.Uuid.String()
+
+	for _, item := range []map[string]any{
+		{"type": "project", "uuid": first.Uuid.String()},
+		{"type": "event", "uuid": second.Uuid.String()},
+		{"type": "project", "uuid": third.Uuid.String()},
+	} {
+		ac.expect(http.StatusOK, http.MethodPost, path+"/items", item, &res)
+	}
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/items", map[string]any{"type": "project", "uuid": first.Uuid.String()}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/items", map[string]any{"type": "event", "uuid": first.Uuid.String()}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/items", map[string]any{"type": "organisation", "uuid": first.Uuid.String()}, nil)
+	if len(res.Item.Items) != 3 || res.Item.Items[0].Project.Uuid != first.Uuid || res.Item.Items[1].Event.Uuid != second.Uuid {
+		t.Fatalf("got %+v, want the items in the order they were added", res.Item.Items)
+	}
+	items := res.Item.Items
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/items/order", map[string]any{
+		"items": []uuid.UUID{items[2].Uuid, items[0].Uuid},
+	}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/items/order", map[string]any{
+		"items": []uuid.UUID{items[2].Uuid, items[0].Uuid, items[0].Uuid},
+	}, nil)
+	res = itemResponse[handler.CollectionWithItems]{}
+	ac.expect(http.StatusOK, http.MethodPost, path+"/items/order", map[string]any{
+		"items": []uuid.UUID{items[2].Uuid, items[0].Uuid, items[1].Uuid},
+	}, &res)
+	if res.Item.Items[0].Uuid != items[2].Uuid || res.Item.Items[2].Uuid != items[1].Uuid {
+		t.Errorf("got %+v, want the items reordered", res.Item.Items)
+	}
+
+	ac.expect(http.StatusOK, http.MethodDelete, path+"/items/"+items[0].Uuid.String(), nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodDelete, path+"/items/"+items[0].Uuid.String(), nil, nil)
+
+	bc := api.login(bob)
+	bc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodPost, path+"/items", map[string]any{"type": "project", "uuid": first.Uuid.String()}, nil)
+
+	var list itemsResponse[handler.Collection]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/collections", nil, &list)
+	if len(list.Items) != 1 || list.Items[0].Name != "Made in Malaysia" {
+		t.Fatalf("got %+v, want the collection of alice", list.Items)
+	}
+
+	ac.expect(http.StatusOK, http.MethodDelete, path, nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+}
+
+func TestPublicCollection(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+	ac := api.login(alice)
+
+	var res itemResponse[handler.CollectionWithItems]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/collections", map[string]any{"name": "Fintech"}, &res)
+	path := "/client/collections/" + res.Item.Uuid.String()
+	publicPath := "/public/collections/" + res.Item.Uuid.String()
+	ac.expect(http.StatusOK, http.MethodPost, path+"/items", map[string]any{"type": "project", "uuid": project.Uuid.String()}, nil)
+
+	// Private collections are only seen by their owner.
+	api.anonymous().expect(http.StatusNotFound, http.MethodGet, publicPath, nil, nil)
+
+	ac.expect(http.StatusOK, http.MethodPost, path, map[string]any{"name": "Fintech", "public": true}, nil)
+	res = itemResponse[handler.CollectionWithItems]{}
+	api.anonymous().expect(http.StatusOK, http.MethodGet, publicPath, nil, &res)
+	if !res.Item.Public || len(res.Item.Items) != 1 || res.Item.Items[0].Project.Name != project.Name {
+		t.Errorf("got %+v, want the shared collection with its project", res.Item)
+	}
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -10,37 +9,35 @@ import (
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
 	"github.com/go-chi/chi/v5"
 	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
 )
 
+type storeEventData struct {
+	Name        string    `json:"name" validate:"required,min=8,max=191"`
+	Description string    `json:"description" validate:"required,min=8,max=512"`
+	Tags        []string  `json:"tags" validate:"min=0,max=6,dive,min=4,max=12"`
+	Website     string    `json:"website" validate:"omitempty,url,max=191"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	EndsAt      time.Time `json:"ends_at" validate:"required"`
+	// Organisation is the uuid of the organisation the event is handed to.
+	Organisation string `json:"organisation" validate:"omitempty,uuid"`
+}
+
 func (c *Client) Events(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
-	orderBy := "desc"
-	if r.URL.Query().Get("orderBy") == "asc" {
-		orderBy = "asc"
-	}
-
-	var events []database.Event
-	var err error
-	switch orderBy {
-	case "asc":
-		events, err = c.queries.UserEventsByAscOffsetLimit(r.Context(), c.database, database.UserEventsByAscOffsetLimitParams{
-			Offset: int32(offset),
-			Limit:  int32(limit),
-			UserID: authUser.UserID,
-		})
-	case "desc":
-		events, err = c.queries.UserEventsByDescOffsetLimit(r.Context(), c.database, database.UserEventsByDescOffsetLimitParams{
-			Offset: int32(offset),
-			Limit:  int32(limit),
-			UserID: authUser.UserID,
-		})
-	}
+	events, err := c.store.UserEvents(r.Context(), authUser.UserID, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
 	if err != nil {
-		c.logger.Error("could not fetch user events by limit offset", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user events by limit offset", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user events.",
@@ -48,9 +45,9 @@ func (c *Client) Events(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	total, err := c.queries.CountUserEvents(r.Context(), c.database, authUser.UserID)
+	total, err := c.store.CountUserEvents(r.Context(), authUser.UserID)
 	if err != nil {
-		c.logger.Error("could not fetch user events count", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user events count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user events count.",
@@ -69,41 +66,67 @@ func (c *Client) Events(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (c *Client) Event(w http.ResponseWriter, r *http.Request) {
-	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
-
+// authorizeEvent fetches the event of the request. It writes a not found response and
+// returns false when the event does not exist or the user is neither its creator nor an
+// owner or admin of its organisation.
+func (c *Client) authorizeEvent(w http.ResponseWriter, r *http.Request, user database.User) (database.Event, bool) {
 	eventUuid, err := uuid.FromString(chi.URLParam(r, "event"))
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "The resource you are looking for could not be found.",
 		})
-		return
+		return database.Event{}, false
 	}
 
-	event, err := c.queries.EventByUUID(r.Context(), c.database, eventUuid)
+	event, err := c.store.EventByUUID(r.Context(), eventUuid)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, store.ErrNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(map[string]string{
 				"message": "The resource you are looking for could not be found.",
 			})
-			return
+			return database.Event{}, false
 		}
 
-		c.logger.Error("could not fetch event by uuid", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch event by uuid", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch event.",
 		})
-		return
+		return database.Event{}, false
+	}
+
+	if event.UserID == user.UserID {
+		return event, true
+	}
+
+	manages, err := c.canManageOrganisationOf(r, user, event.OrganisationID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch organisation member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch event.",
+		})
+		return database.Event{}, false
 	}
 
-	if event.UserID != authUser.UserID {
+	if !manages {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "The resource you are looking for could not be found.",
 		})
+		return database.Event{}, false
+	}
+
+	return event, true
+}
+
+func (c *Client) Event(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	event, ok := c.authorizeEvent(w, r, authUser)
+	if !ok {
 		return
 	}
 
@@ -115,14 +138,7 @@ func (c *Client) Event(w http.ResponseWriter, r *http.Request) {
 func (c *Client) StoreEvent(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	var data struct {
-		Name        string    `json:"name" validate:"required,min=8,max=191"`
-		Description string    `json:"description" validate:"required,min=8,max=512"`
-		Tags        []string  `json:"tags" validate:"min=0,max=6,dive,min=4,max=12"`
-		Website     string    `json:"website" validate:"omitempty,url,max=191"`
-		StartsAt    time.Time `json:"starts_at" validate:"required"`
-		EndsAt      time.Time `json:"ends_at" validate:"required"`
-	}
+	var data storeEventData
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -139,9 +155,9 @@ func (c *Client) StoreEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	count, err := c.queries.CountUserEvents(r.Context(), c.database, authUser.UserID)
+	count, err := c.store.CountUserEvents(r.Context(), authUser.UserID)
 	if err != nil {
-		c.logger.Error("could not fetch user events count", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user events count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user events count.",
@@ -149,7 +165,7 @@ func (c *Client) StoreEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if count >= 20 {
+	if count >= maxUserEvents {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "You have hit the event limit, try deleting some unused events.",
@@ -165,17 +181,44 @@ func (c *Client) StoreEvent(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	event, err := c.queries.InsertEvent(r.Context(), c.database, database.InsertEventParams{
-		Name:        data.Name,
-		Description: data.Description,
-		Tags:        data.Tags,
-		Website:     website,
-		StartsAt:    data.StartsAt,
-		EndsAt:      data.EndsAt,
-		UserID:      authUser.UserID,
+	organisationID, ok := c.organisationFromData(w, r, authUser, data.Organisation, nulls.Int32{})
+	if !ok {
+		return
+	}
+
+	var event database.Event
+	err = c.store.WithTx(r.Context(), func(tx store.Store) error {
+		event, err = tx.InsertEvent(r.Context(), database.InsertEventParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Website:     website,
+			StartsAt:    data.StartsAt,
+			EndsAt:      data.EndsAt,
+			UserID:      authUser.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if organisationID.Valid {
+			event, err = tx.SetEventOrganisation(r.Context(), event.EventID, organisationID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = webhooks.Dispatch(r.Context(), tx, webhooks.EventEventCreated, event.UserID, map[string]any{
+			"event": EventFromDatabase(event),
+		})
+		if err != nil {
+			return err
+		}
+
+		return notify.Enqueue(r.Context(), tx, event.UserID, notify.ListingCreated, notify.EventListing(event, authUser))
 	})
 	if err != nil {
-		c.logger.Error("could not insert event", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not insert event", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not insert event into database.",
@@ -191,38 +234,8 @@ func (c *Client) StoreEvent(w http.ResponseWriter, r *http.Request) {
 func (c *Client) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	eventUuid, err := uuid.FromString(chi.URLParam(r, "event"))
-	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
-		return
-	}
-
-	event, err := c.queries.EventByUUID(r.Context(), c.database, eventUuid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "The resource you are looking for could not be found.",
-			})
-			return
-		}
-
-		c.logger.Error("could not fetch event by uuid", slog.Any("err", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not fetch event.",
-		})
-		return
-	}
-
-	if event.UserID != authUser.UserID {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
+	event, ok := c.authorizeEvent(w, r, authUser)
+	if !ok {
 		return
 	}
 
@@ -233,6 +246,9 @@ func (c *Client) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		Website     string    `json:"website" validate:"omitempty,url,max=191"`
 		StartsAt    time.Time `json:"starts_at" validate:"required"`
 		EndsAt      time.Time `json:"ends_at" validate:"required"`
+		// Organisation is left as is when omitted, an empty uuid takes the event away from
+		// its organisation.
+		Organisation *string `json:"organisation"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -258,17 +274,52 @@ func (c *Client) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	event, err = c.queries.UpdateEvent(r.Context(), c.database, database.UpdateEventParams{
-		Name:        data.Name,
-		Description: data.Description,
-		Tags:        data.Tags,
-		Website:     website,
-		StartsAt:    data.StartsAt,
-		EndsAt:      data.EndsAt,
-		EventID:     event.EventID,
+	organisationID := event.OrganisationID
+	if data.Organisation != nil {
+		organisationID, ok = c.organisationFromData(w, r, authUser, *data.Organisation, event.OrganisationID)
+		if !ok {
+			return
+		}
+	}
+
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		var err error
+		event, err = tx.UpdateEvent(r.Context(), database.UpdateEventParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Website:     website,
+			StartsAt:    data.StartsAt,
+			EndsAt:      data.EndsAt,
+			EventID:     event.EventID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if organisationID != event.OrganisationID {
+			event, err = tx.SetEventOrganisation(r.Context(), event.EventID, organisationID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = webhooks.Dispatch(r.Context(), tx, webhooks.EventEventUpdated, event.UserID, map[string]any{
+			"event": EventFromDatabase(event),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Owners are only told about the edits of others.
+		if authUser.UserID == event.UserID {
+			return nil
+		}
+
+		return notify.Enqueue(r.Context(), tx, event.UserID, notify.ListingUpdated, notify.EventListing(event, authUser))
 	})
 	if err != nil {
-		c.logger.Error("could not update event", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not update event", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not update event.",
@@ -284,43 +335,30 @@ func (c *Client) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 func (c *Client) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	eventUuid, err := uuid.FromString(chi.URLParam(r, "event"))
-	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
+	event, ok := c.authorizeEvent(w, r, authUser)
+	if !ok {
 		return
 	}
 
-	event, err := c.queries.EventByUUID(r.Context(), c.database, eventUuid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "The resource you are looking for could not be found.",
-			})
-			return
-		}
-
-		c.logger.Error("could not fetch event by uuid", slog.Any("err", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not fetch event.",
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		err := webhooks.Dispatch(r.Context(), tx, webhooks.EventEventDeleted, event.UserID, map[string]any{
+			"event": EventFromDatabase(event),
 		})
-		return
-	}
+		if err != nil {
+			return err
+		}
 
-	if event.UserID != authUser.UserID {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
-		return
-	}
+		if authUser.UserID != event.UserID {
+			err = notify.Enqueue(r.Context(), tx, event.UserID, notify.ListingDeleted, notify.EventListing(event, authUser))
+			if err != nil {
+				return err
+			}
+		}
 
-	if err := c.queries.DeleteEvent(r.Context(), c.database, event.EventID); err != nil {
-		c.logger.Error("could not delete event", slog.Any("err", err))
+		return tx.DeleteEvent(r.Context(), event.EventID)
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not delete event", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not delete event.",
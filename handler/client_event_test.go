@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/notify"
+	"github.com/gofrs/uuid"
+)
+
+func eventData(name string) map[string]any {
+	startsAt := time.Date(2026, time.November, 21, 9, 0, 0, 0, time.UTC)
+
+	return map[string]any{
+		"name":        name,
+		"description": "A meetup for the Golang community.",
+		"tags":        []string{"golang"},
+		"starts_at":   startsAt,
+		"ends_at":     startsAt.Add(3 * time.Hour),
+	}
+}
+
+func TestClientEvents(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+
+	first := api.event(alice, "Kuala Lumpur Golang Meetup")
+	api.event(bob, "Penang Fintech Summit")
+	last := api.event(alice, "Cyberjaya Cloud Native Workshop")
+
+	c := api.login(alice)
+
+	var res itemsResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodGet, "/client/events", nil, &res)
+	if len(res.Items) != 2 || res.Pagination.Total != 2 {
+		t.Fatalf("got %d items of %d, want only the events of the user", len(res.Items), res.Pagination.Total)
+	}
+	if res.Items[0].Uuid != last.Uuid {
+		t.Errorf("got %s first, want the latest event first", res.Items[0].Name)
+	}
+
+	res = itemsResponse[handler.Event]{}
+	c.expect(http.StatusOK, http.MethodGet, "/client/events?orderBy=asc&limit=1", nil, &res)
+	if len(res.Items) != 1 || res.Items[0].Uuid != first.Uuid || res.Pagination.Total != 2 {
+		t.Errorf("got %v, want the oldest event alone", res.Items)
+	}
+
+	res = itemsResponse[handler.Event]{}
+	c.expect(http.StatusOK, http.MethodGet, "/client/events?page=2", nil, &res)
+	if len(res.Items) != 0 || res.Pagination.CurrentPage != 2 {
+		t.Errorf("got %d items on page %d, want none past the last page", len(res.Items), res.Pagination.CurrentPage)
+	}
+}
+
+func TestClientEvent(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.event(alice, "Kuala Lumpur Golang Meetup")
+	other := api.event(api.user("bob@example.com"), "Penang Fintech Summit")
+
+	c := api.login(alice)
+
+	var res itemResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodGet, "/client/events/"+own.Uuid.String(), nil, &res)
+	if res.Item.Uuid != own.Uuid {
+		t.Errorf("got %s, want %s", res.Item.Uuid, own.Uuid)
+	}
+
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/events/"+other.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/events/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/events/not-a-uuid", nil, nil)
+}
+
+func TestClientStoreEvent(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	c := api.login(alice)
+
+	data := eventData("Kuala Lumpur Golang Meetup")
+
+	var res itemResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodPost, "/client/events", data, &res)
+	if res.Item.Name != data["name"] || !res.Item.StartsAt.Equal(data["starts_at"].(time.Time)) {
+		t.Errorf("got %+v, want the stored event", res.Item)
+	}
+
+	stored, err := api.store.EventByUUID(context.Background(), res.Item.Uuid)
+	if err != nil {
+		t.Fatalf("could not fetch stored event: %v", err)
+	}
+	if stored.UserID != alice.UserID {
+		t.Errorf("got event of user %d, want %d", stored.UserID, alice.UserID)
+	}
+
+	for name, modify := range map[string]func(data map[string]any){
+		"short name":  func(data map[string]any) { data["name"] = "Meetup" },
+		"no start":    func(data map[string]any) { delete(data, "starts_at") },
+		"no end":      func(data map[string]any) { delete(data, "ends_at") },
+		"short tag":   func(data map[string]any) { data["tags"] = []string{"go"} },
+		"invalid url": func(data map[string]any) { data["website"] = "golang meetup" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := eventData("Kuala Lumpur Golang Meetup")
+			modify(invalid)
+			c.with(t).expect(http.StatusBadRequest, http.MethodPost, "/client/events", invalid, nil)
+		})
+	}
+}
+
+func TestClientStoreEventQuota(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	for i := range 19 {
+		api.event(alice, fmt.Sprintf("Event number %02d", i))
+	}
+
+	c := api.login(alice)
+	c.expect(http.StatusOK, http.MethodPost, "/client/events", eventData("Event number 19"), nil)
+
+	var res messageResponse
+	c.expect(http.StatusBadRequest, http.MethodPost, "/client/events", eventData("Event number 20"), &res)
+	if res.Message == "" {
+		t.Error("expected a message")
+	}
+
+	api.login(api.user("bob@example.com")).expect(http.StatusOK, http.MethodPost, "/client/events", eventData("Event number 20"), nil)
+}
+
+func TestClientUpdateEvent(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.event(alice, "Kuala Lumpur Golang Meetup")
+	other := api.event(api.user("bob@example.com"), "Penang Fintech Summit")
+
+	c := api.login(alice)
+	data := eventData("Kuala Lumpur Golang Meetup Rescheduled")
+	data["website"] = "https://golang.my"
+
+	var res itemResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodPost, "/client/events/"+own.Uuid.String(), data, &res)
+	if res.Item.Uuid != own.Uuid || res.Item.Name != data["name"] || !res.Item.EndsAt.Equal(data["ends_at"].(time.Time)) {
+		t.Errorf("got %+v, want the updated event", res.Item)
+	}
+	if res.Item.Website.String != "https://golang.my" {
+		t.Errorf("got website %v, want https://golang.my", res.Item.Website)
+	}
+
+	if got := api.notifications(notify.ListingUpdated); len(got) != 0 {
+		t.Errorf("got notices to %v, want none for the edits of the owner", got)
+	}
+
+	c.expect(http.StatusBadRequest, http.MethodPost, "/client/events/"+own.Uuid.String(), map[string]any{"name": "Meetup"}, nil)
+
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/events/"+other.Uuid.String(), data, nil)
+	unchanged, err := api.store.EventByUUID(context.Background(), other.Uuid)
+	if err != nil {
+		t.Fatalf("could not fetch event: %v", err)
+	}
+	if unchanged.Name != other.Name {
+		t.Errorf("the event of another user was renamed to %s", unchanged.Name)
+	}
+
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/events/"+uuid.Must(uuid.NewV4()).String(), data, nil)
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/events/not-a-uuid", data, nil)
+}
+
+func TestClientDeleteEvent(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.event(alice, "Kuala Lumpur Golang Meetup")
+	other := api.event(api.user("bob@example.com"), "Penang Fintech Summit")
+
+	c := api.login(alice)
+
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/events/"+other.Uuid.String(), nil, nil)
+	if _, err := api.store.EventByUUID(context.Background(), other.Uuid); err != nil {
+		t.Errorf("the event of another user was deleted: %v", err)
+	}
+
+	c.expect(http.StatusOK, http.MethodDelete, "/client/events/"+own.Uuid.String(), nil, nil)
+	if got := api.notifications(notify.ListingDeleted); len(got) != 0 {
+		t.Errorf("got notices to %v, want none when the owner deletes", got)
+	}
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/events/"+own.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/events/"+own.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/events/not-a-uuid", nil, nil)
+}
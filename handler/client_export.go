@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	transferFormatJSON = "json"
+	transferFormatCSV  = "csv"
+	transferFormatYAML = "yaml"
+)
+
+// transferCSVHeader is the column layout used for CSV exports and imports, projects and
+// events share a single sheet and are told apart by the type column.
+var transferCSVHeader = []string{"type", "uuid", "name", "description", "tags", "repository", "website", "starts_at", "ends_at"}
+
+type Transfer struct {
+	Projects []TransferProject `json:"projects" yaml:"projects"`
+	Events   []TransferEvent   `json:"events" yaml:"events"`
+
+	// invalid are the rows of a CSV import that could not be read.
+	invalid []ImportResult
+}
+
+type TransferProject struct {
+	Uuid        string   `json:"uuid" yaml:"uuid"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	Repository  string   `json:"repository" yaml:"repository"`
+	Website     string   `json:"website" yaml:"website"`
+
+	// row is the line of the row in a CSV import, or its index in the list of projects.
+	row int
+}
+
+func TransferProjectFromDatabase(p database.Project) TransferProject {
+	return TransferProject{
+		Uuid:        p.Uuid.String(),
+		Name:        p.Name,
+		Description: p.Description,
+		Tags:        p.Tags,
+		Repository:  p.Repository.String,
+		Website:     p.Website.String,
+	}
+}
+
+type TransferEvent struct {
+	Uuid        string    `json:"uuid" yaml:"uuid"`
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description" yaml:"description"`
+	Tags        []string  `json:"tags" yaml:"tags"`
+	Website     string    `json:"website" yaml:"website"`
+	StartsAt    time.Time `json:"starts_at" yaml:"starts_at"`
+	EndsAt      time.Time `json:"ends_at" yaml:"ends_at"`
+
+	// row is the line of the row in a CSV import, or its index in the list of events.
+	row int
+}
+
+func TransferEventFromDatabase(e database.Event) TransferEvent {
+	return TransferEvent{
+		Uuid:        e.Uuid.String(),
+		Name:        e.Name,
+		Description: e.Description,
+		Tags:        e.Tags,
+		Website:     e.Website.String,
+		StartsAt:    e.StartsAt,
+		EndsAt:      e.EndsAt,
+	}
+}
+
+// transferFormatFromRequest picks the transfer format from the format query parameter,
+// falling back to the request content type and finally to JSON.
+func transferFormatFromRequest(r *http.Request) string {
+	switch r.URL.Query().Get("format") {
+	case transferFormatCSV:
+		return transferFormatCSV
+	case transferFormatYAML:
+		return transferFormatYAML
+	case transferFormatJSON:
+		return transferFormatJSON
+	}
+
+	switch strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0]) {
+	case "text/csv":
+		return transferFormatCSV
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return transferFormatYAML
+	}
+
+	return transferFormatJSON
+}
+
+func (c *Client) Export(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+	format := transferFormatFromRequest(r)
+
+	projects, err := c.store.AllUserProjects(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user projects", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user projects.",
+		})
+		return
+	}
+
+	events, err := c.store.AllUserEvents(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user events", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user events.",
+		})
+		return
+	}
+
+	transfer := Transfer{
+		Projects: make([]TransferProject, len(projects)),
+		Events:   make([]TransferEvent, len(events)),
+	}
+	for i, p := range projects {
+		transfer.Projects[i] = TransferProjectFromDatabase(p)
+	}
+	for i, e := range events {
+		transfer.Events[i] = TransferEventFromDatabase(e)
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="awesomemy-export.`+format+`"`)
+	switch format {
+	case transferFormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		err = writeTransferCSV(w, transfer)
+	case transferFormatYAML:
+		w.Header().Set("Content-Type", "application/yaml")
+		err = yaml.NewEncoder(w).Encode(transfer)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(transfer)
+	}
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not encode user export", slog.Any("err", err), slog.String("format", format))
+	}
+}
+
+func writeTransferCSV(w http.ResponseWriter, transfer Transfer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(transferCSVHeader); err != nil {
+		return err
+	}
+
+	for _, p := range transfer.Projects {
+		if err := cw.Write([]string{
+			"project", p.Uuid, p.Name, p.Description, strings.Join(p.Tags, ","), p.Repository, p.Website, "", "",
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range transfer.Events {
+		if err := cw.Write([]string{
+			"event", e.Uuid, e.Name, e.Description, strings.Join(e.Tags, ","), "", e.Website,
+			e.StartsAt.Format(time.RFC3339), e.EndsAt.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+)
+
+// FeedItem is a project or event of the feed, only the field of its type is set.
+type FeedItem struct {
+	// Type is project or event.
+	Type      string    `json:"type"`
+	Project   *Project  `json:"project"`
+	Event     *Event    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// feedCursor is the position of the last item of a page of the feed, items created at the
+// same time are ordered projects first then by ID, newest first.
+type feedCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	Type      string    `json:"type"`
+	ID        int32     `json:"id"`
+}
+
+// String encodes the cursor as handed to clients, which should not rely on its content.
+func (fc feedCursor) String() string {
+	b, _ := json.Marshal(fc)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func parseFeedCursor(s string) (feedCursor, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return feedCursor{}, false
+	}
+
+	var fc feedCursor
+	if err := json.Unmarshal(b, &fc); err != nil || fc.CreatedAt.IsZero() || (fc.Type != "project" && fc.Type != "event") {
+		return feedCursor{}, false
+	}
+
+	return fc, true
+}
+
+// before returns where the list of records of type typ resumes after the cursor, the zero
+// cursor starting at the newest record.
+func (fc feedCursor) before(typ string) store.Cursor {
+	switch {
+	case fc.CreatedAt.IsZero() || fc.Type == typ:
+		return store.Cursor{CreatedAt: fc.CreatedAt, ID: fc.ID}
+	case typ == "project":
+		// The projects created along with the event of the cursor came before it.
+		return store.Cursor{CreatedAt: fc.CreatedAt, ID: 0}
+	default:
+		// The events created along with the project of the cursor come after it.
+		return store.Cursor{CreatedAt: fc.CreatedAt, ID: math.MaxInt32}
+	}
+}
+
+// Feed lists the new projects and events of the users, organisations and tags followed by
+// the user, newest first.
+func (c *Client) Feed(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+	_, limit, _ := awesomemy.PageLimitOffsetFromRequest(r)
+
+	var cursor feedCursor
+	if s := r.URL.Query().Get("cursor"); s != "" {
+		var ok bool
+		if cursor, ok = parseFeedCursor(s); !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The cursor is invalid.",
+			})
+			return
+		}
+	}
+
+	// Fetching one more item than the page holds tells whether there is a next page.
+	projects, err := c.store.FeedProjects(r.Context(), authUser.UserID, cursor.before("project"), int32(limit+1))
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch feed projects", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch feed.",
+		})
+		return
+	}
+
+	events, err := c.store.FeedEvents(r.Context(), authUser.UserID, cursor.before("event"), int32(limit+1))
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch feed events", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch feed.",
+		})
+		return
+	}
+
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, projects)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
+	// Merge the projects and events, both newest first, in the order of the cursor.
+	var items []FeedItem
+	var cursors []feedCursor
+	for len(projects)+len(events) > 0 && len(items) <= limit {
+		if len(events) == 0 || len(projects) > 0 && !projects[0].CreatedAt.Before(events[0].CreatedAt) {
+			items = append(items, FeedItem{Type: "project", Project: &apiProjects[0], CreatedAt: projects[0].CreatedAt})
+			cursors = append(cursors, feedCursor{CreatedAt: projects[0].CreatedAt, Type: "project", ID: projects[0].ProjectID})
+			projects, apiProjects = projects[1:], apiProjects[1:]
+		} else {
+			event := EventFromDatabase(events[0])
+			items = append(items, FeedItem{Type: "event", Event: &event, CreatedAt: events[0].CreatedAt})
+			cursors = append(cursors, feedCursor{CreatedAt: events[0].CreatedAt, Type: "event", ID: events[0].EventID})
+			events = events[1:]
+		}
+	}
+
+	var pagination awesomemy.CursorPaginationMeta
+	if len(items) > limit {
+		items = items[:limit]
+		pagination.NextCursor = cursors[limit-1].String()
+	}
+	if items == nil {
+		items = []FeedItem{}
+	}
+	pagination.Count = len(items)
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      items,
+		"pagination": pagination,
+	})
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// Follow is a user, organisation or tag followed, only the field of its type is set.
+type Follow struct {
+	// Type is user, organisation or tag.
+	Type         string                `json:"type"`
+	User         *FollowedUser         `json:"user"`
+	Organisation *FollowedOrganisation `json:"organisation"`
+	Tag          nulls.String          `json:"tag"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+type FollowedUser struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	GitHubEmail string    `json:"github_email"`
+}
+
+type FollowedOrganisation struct {
+	Uuid uuid.UUID `json:"uuid"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
+
+func FollowFromDatabase(f database.FollowsRow) Follow {
+	follow := Follow{
+		Tag:       f.FollowedTag,
+		CreatedAt: f.CreatedAt,
+	}
+
+	switch {
+	case f.FollowedUserID.Valid:
+		follow.Type = "user"
+		follow.User = &FollowedUser{
+			Uuid:        f.UserUuid.UUID,
+			GitHubEmail: f.UserGithubEmail.String,
+		}
+	case f.FollowedOrganisationID.Valid:
+		follow.Type = "organisation"
+		follow.Organisation = &FollowedOrganisation{
+			Uuid: f.OrganisationUuid.UUID,
+			Slug: f.OrganisationSlug.String,
+			Name: f.OrganisationName.String,
+		}
+	default:
+		follow.Type = "tag"
+	}
+
+	return follow
+}
+
+func (c *Client) Follows(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	follows, err := c.store.Follows(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch follows", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch follows.",
+		})
+		return
+	}
+
+	apiFollows := make([]Follow, len(follows))
+	for i, f := range follows {
+		apiFollows[i] = FollowFromDatabase(f)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiFollows,
+	})
+}
+
+// followedUser fetches the user of the request. It writes a not found response and returns
+// false when the user does not exist.
+func (c *Client) followedUser(w http.ResponseWriter, r *http.Request) (database.User, bool) {
+	userUuid, err := uuid.FromString(chi.URLParam(r, "user"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.User{}, false
+	}
+
+	user, err := c.store.UserByUUID(r.Context(), userUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.User{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch user by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user.",
+		})
+		return database.User{}, false
+	}
+
+	return user, true
+}
+
+// followedOrganisation fetches the organisation of the request, which anyone can follow. It
+// writes a not found response and returns false when the organisation does not exist.
+func (c *Client) followedOrganisation(w http.ResponseWriter, r *http.Request) (database.Organisation, bool) {
+	organisationUuid, err := uuid.FromString(chi.URLParam(r, "organisation"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Organisation{}, false
+	}
+
+	organisation, err := c.store.OrganisationByUUID(r.Context(), organisationUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Organisation{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch organisation by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation.",
+		})
+		return database.Organisation{}, false
+	}
+
+	return organisation, true
+}
+
+// followedTag returns the tag of the request, which is validated like the tags of projects
+// and events. It writes a bad request response and returns false when it is invalid.
+func (c *Client) followedTag(w http.ResponseWriter, r *http.Request) (string, bool) {
+	tag := chi.URLParam(r, "tag")
+	if err := c.validator.VarCtx(r.Context(), tag, "min=4,max=12"); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The tag must be between 4 and 12 characters.",
+		})
+		return "", false
+	}
+
+	return tag, true
+}
+
+// follow has the user follow what params point at, responding with follow once it is
+// followed.
+func (c *Client) follow(w http.ResponseWriter, r *http.Request, user database.User, params database.InsertFollowParams, follow Follow) {
+	count, err := c.store.CountFollows(r.Context(), user.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch follows count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch follows count.",
+		})
+		return
+	}
+
+	if count >= maxUserFollows {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You have hit the follow limit, try unfollowing some users, organisations or tags.",
+		})
+		return
+	}
+
+	params.FollowerID = user.UserID
+	inserted, err := c.store.InsertFollow(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, store.ErrFollowExists) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "You already follow this " + follow.Type + ".",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not insert follow", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert follow into database.",
+		})
+		return
+	}
+
+	follow.CreatedAt = inserted.CreatedAt
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": follow,
+	})
+}
+
+// unfollow has the user unfollow what params point at.
+func (c *Client) unfollow(w http.ResponseWriter, r *http.Request, user database.User, params database.DeleteFollowParams) {
+	params.FollowerID = user.UserID
+	if err := c.store.DeleteFollow(r.Context(), params); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not delete follow", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete follow.",
+		})
+		return
+	}
+}
+
+func (c *Client) FollowUser(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	user, ok := c.followedUser(w, r)
+	if !ok {
+		return
+	}
+
+	if user.UserID == authUser.UserID {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You cannot follow yourself.",
+		})
+		return
+	}
+
+	c.follow(w, r, authUser, database.InsertFollowParams{
+		FollowedUserID: nulls.NewInt32(user.UserID),
+	}, Follow{
+		Type: "user",
+		User: &FollowedUser{Uuid: user.Uuid, GitHubEmail: user.GithubEmail},
+	})
+}
+
+func (c *Client) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	user, ok := c.followedUser(w, r)
+	if !ok {
+		return
+	}
+
+	c.unfollow(w, r, authUser, database.DeleteFollowParams{
+		FollowedUserID: nulls.NewInt32(user.UserID),
+	})
+}
+
+func (c *Client) FollowOrganisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, ok := c.followedOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	c.follow(w, r, authUser, database.InsertFollowParams{
+		FollowedOrganisationID: nulls.NewInt32(organisation.OrganisationID),
+	}, Follow{
+		Type: "organisation",
+		Organisation: &FollowedOrganisation{
+			Uuid: organisation.Uuid,
+			Slug: organisation.Slug,
+			Name: organisation.Name,
+		},
+	})
+}
+
+func (c *Client) UnfollowOrganisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, ok := c.followedOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	c.unfollow(w, r, authUser, database.DeleteFollowParams{
+		FollowedOrganisationID: nulls.NewInt32(organisation.OrganisationID),
+	})
+}
+
+func (c *Client) FollowTag(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	tag, ok := c.followedTag(w, r)
+	if !ok {
+		return
+	}
+
+	c.follow(w, r, authUser, database.InsertFollowParams{
+		FollowedTag: nulls.NewString(tag),
+	}, Follow{
+		Type: "tag",
+		Tag:  nulls.NewString(tag),
+	})
+}
+
+func (c *Client) UnfollowTag(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	tag, ok := c.followedTag(w, r)
+	if !ok {
+		return
+	}
+
+	c.unfollow(w, r, authUser, database.DeleteFollowParams{
+		FollowedTag: nulls.NewString(tag),
+	})
+}
@@ -0,0 +1,121 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+type feedResponse struct {
+	Items      []handler.FeedItem `json:"items"`
+	Pagination struct {
+		NextCursor string `json:"next_cursor"`
+		Count      int    `json:"count"`
+	} `json:"pagination"`
+}
+
+func TestClientFollows(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	organisation := api.organisation(bob, "Kedai Pay", "fintech", "selangor")
+	ac := api.login(alice)
+
+	var res itemResponse[handler.Follow]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/users/"+bob.Uuid.String(), nil, &res)
+	if res.Item.Type != "user" || res.Item.User == nil || res.Item.User.GitHubEmail != bob.GithubEmail {
+		t.Fatalf("got %+v, want bob followed", res.Item)
+	}
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/follows/users/"+bob.Uuid.String(), nil, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/follows/users/"+alice.Uuid.String(), nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodPost, "/client/follows/users/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/organisations/"+organisation.Uuid.String(), nil, nil)
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/tags/golang", nil, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/follows/tags/go", nil, nil)
+
+	var list itemsResponse[handler.Follow]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/follows", nil, &list)
+	if len(list.Items) != 3 {
+		t.Fatalf("got %+v, want the user, organisation and tag followed", list.Items)
+	}
+	if o := list.Items[1].Organisation; o == nil || o.Slug != organisation.Slug || list.Items[2].Tag.String != "golang" {
+		t.Errorf("got %+v, want the organisation then the tag", list.Items[1:])
+	}
+
+	ac.expect(http.StatusOK, http.MethodDelete, "/client/follows/tags/golang", nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodDelete, "/client/follows/tags/golang", nil, nil)
+	ac.expect(http.StatusOK, http.MethodDelete, "/client/follows/users/"+bob.Uuid.String(), nil, nil)
+
+	list = itemsResponse[handler.Follow]{}
+	ac.expect(http.StatusOK, http.MethodGet, "/client/follows", nil, &list)
+	if len(list.Items) != 1 || list.Items[0].Type != "organisation" {
+		t.Errorf("got %+v, want only the organisation followed", list.Items)
+	}
+}
+
+func TestClientFeed(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	carol := api.user("carol@example.com")
+	organisation := api.organisation(carol, "Kedai Pay", "fintech", "selangor")
+	ac := api.login(alice)
+
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/users/"+bob.Uuid.String(), nil, nil)
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/organisations/"+organisation.Uuid.String(), nil, nil)
+	ac.expect(http.StatusOK, http.MethodPost, "/client/follows/tags/golang", nil, nil)
+
+	var want []uuid.UUID
+	want = append(want, api.project(bob, "Kedai Pay Project").Uuid)
+	want = append(want, api.event(bob, "Golang Meetup").Uuid)
+	want = append(want, api.event(carol, "Rust Meetup", "golang").Uuid)
+	organisationProject := api.project(carol, "Pasar Hub Project")
+	if _, err := api.store.SetProjectOrganisation(context.Background(), organisationProject.ProjectID, nulls.NewInt32(organisation.OrganisationID)); err != nil {
+		t.Fatalf("could not set project organisation: %v", err)
+	}
+	want = append(want, organisationProject.Uuid)
+
+	// Neither what alice creates herself nor what she does not follow shows up.
+	api.project(alice, "Lepak Labs Project", "golang")
+	api.project(carol, "Teh Tarik Project", "agritech")
+
+	var got []uuid.UUID
+	path := "/client/feed?limit=3"
+	for pages := 0; path != ""; pages++ {
+		if pages == 2 {
+			t.Fatalf("got a third page, want two")
+		}
+
+		var res feedResponse
+		ac.expect(http.StatusOK, http.MethodGet, path, nil, &res)
+		for _, item := range res.Items {
+			switch item.Type {
+			case "project":
+				got = append(got, item.Project.Uuid)
+			case "event":
+				got = append(got, item.Event.Uuid)
+			}
+		}
+
+		path = ""
+		if res.Pagination.NextCursor != "" {
+			path = "/client/feed?limit=3&cursor=" + res.Pagination.NextCursor
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[len(want)-1-i] {
+			t.Errorf("got %s at %d, want %s", got[i], i, want[len(want)-1-i])
+		}
+	}
+
+	ac.expect(http.StatusBadRequest, http.MethodGet, "/client/feed?cursor=nope", nil, nil)
+}
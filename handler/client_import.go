@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/goccy/go-yaml"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	importActionCreated = "created"
+	importActionUpdated = "updated"
+	importActionFailed  = "failed"
+)
+
+// errImportRollback rolls back an import that failed validation or is a dry run.
+var errImportRollback = errors.New("import rolled back")
+
+type ImportResult struct {
+	Type string `json:"type"`
+	// Row is the line of the row in a CSV import, or its index in the list of its type.
+	Row     int       `json:"row"`
+	Uuid    uuid.UUID `json:"uuid"`
+	Name    string    `json:"name"`
+	Action  string    `json:"action"`
+	Message string    `json:"message,omitempty"`
+}
+
+func (c *Client) Import(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	transfer, err := readTransfer(transferFormatFromRequest(r), http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	var failed bool
+	var results []ImportResult
+	err = c.store.WithTx(r.Context(), func(tx store.Store) error {
+		projectCount, err := tx.CountUserProjects(r.Context(), authUser.UserID)
+		if err != nil {
+			return fmt.Errorf("could not fetch user projects count: %w", err)
+		}
+
+		eventCount, err := tx.CountUserEvents(r.Context(), authUser.UserID)
+		if err != nil {
+			return fmt.Errorf("could not fetch user events count: %w", err)
+		}
+
+		results = make([]ImportResult, 0, len(transfer.Projects)+len(transfer.Events)+len(transfer.invalid))
+		for _, tp := range transfer.Projects {
+			result, err := c.importProject(r.Context(), tx, authUser, tp, &projectCount)
+			if err != nil {
+				return fmt.Errorf("could not import project: %w", err)
+			}
+
+			result.Row = tp.row
+			failed = failed || result.Action == importActionFailed
+			results = append(results, result)
+		}
+		for _, te := range transfer.Events {
+			result, err := c.importEvent(r.Context(), tx, authUser, te, &eventCount)
+			if err != nil {
+				return fmt.Errorf("could not import event: %w", err)
+			}
+
+			result.Row = te.row
+			failed = failed || result.Action == importActionFailed
+			results = append(results, result)
+		}
+		if len(transfer.invalid) > 0 {
+			failed = true
+			results = append(results, transfer.invalid...)
+		}
+
+		if failed || dryRun {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		requestLogger(r, c.logger).Error("could not import transfer", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not import into database.",
+		})
+		return
+	}
+
+	if failed {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message":   "Some rows could not be imported, no changes have been made.",
+			"dry_run":   dryRun,
+			"committed": false,
+			"items":     results,
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"dry_run":   dryRun,
+		"committed": !dryRun,
+		"items":     results,
+	})
+}
+
+// importProject upserts a single project row by its UUID, or by its name when no UUID is
+// given. Rows that fail validation or ownership checks are reported through the returned
+// result, only unexpected database failures are returned as errors.
+func (c *Client) importProject(ctx context.Context, tx store.Store, user database.User, tp TransferProject, count *int64) (ImportResult, error) {
+	result := ImportResult{Type: "project", Name: tp.Name}
+
+	data := storeProjectData{
+		Name:        tp.Name,
+		Description: tp.Description,
+		Tags:        tp.Tags,
+		Repository:  tp.Repository,
+		Website:     tp.Website,
+	}
+	if err := c.validator.StructCtx(ctx, data); err != nil {
+		result.Action = importActionFailed
+		result.Message = "The row is in malformed format."
+		return result, nil
+	}
+
+	var project database.Project
+	var err error
+	if tp.Uuid != "" {
+		projectUuid, uuidErr := uuid.FromString(tp.Uuid)
+		if uuidErr == nil {
+			project, err = tx.ProjectByUUID(ctx, projectUuid)
+		}
+		if uuidErr != nil || errors.Is(err, store.ErrNotFound) || (err == nil && project.UserID != user.UserID) {
+			result.Action = importActionFailed
+			result.Message = "The project could not be found."
+			return result, nil
+		}
+	} else {
+		project, err = tx.UserProjectByName(ctx, user.UserID, data.Name)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return result, err
+	}
+
+	if err == nil {
+		project, err = tx.UpdateProject(ctx, database.UpdateProjectParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Repository:  nullString(data.Repository),
+			Website:     nullString(data.Website),
+			ProjectID:   project.ProjectID,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Action = importActionUpdated
+		result.Uuid = project.Uuid
+		return result, nil
+	}
+
+	if *count >= maxUserProjects {
+		result.Action = importActionFailed
+		result.Message = "You have hit the project limit, try deleting some unused projects."
+		return result, nil
+	}
+
+	project, err = tx.InsertProject(ctx, database.InsertProjectParams{
+		Name:        data.Name,
+		Description: data.Description,
+		Tags:        data.Tags,
+		Repository:  nullString(data.Repository),
+		Website:     nullString(data.Website),
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		return result, err
+	}
+	*count++
+
+	result.Action = importActionCreated
+	result.Uuid = project.Uuid
+	return result, nil
+}
+
+// importEvent upserts a single event row, see importProject.
+func (c *Client) importEvent(ctx context.Context, tx store.Store, user database.User, te TransferEvent, count *int64) (ImportResult, error) {
+	result := ImportResult{Type: "event", Name: te.Name}
+
+	data := storeEventData{
+		Name:        te.Name,
+		Description: te.Description,
+		Tags:        te.Tags,
+		Website:     te.Website,
+		StartsAt:    te.StartsAt,
+		EndsAt:      te.EndsAt,
+	}
+	if err := c.validator.StructCtx(ctx, data); err != nil {
+		result.Action = importActionFailed
+		result.Message = "The row is in malformed format."
+		return result, nil
+	}
+
+	var event database.Event
+	var err error
+	if te.Uuid != "" {
+		eventUuid, uuidErr := uuid.FromString(te.Uuid)
+		if uuidErr == nil {
+			event, err = tx.EventByUUID(ctx, eventUuid)
+		}
+		if uuidErr != nil || errors.Is(err, store.ErrNotFound) || (err == nil && event.UserID != user.UserID) {
+			result.Action = importActionFailed
+			result.Message = "The event could not be found."
+			return result, nil
+		}
+	} else {
+		event, err = tx.UserEventByName(ctx, user.UserID, data.Name)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return result, err
+	}
+
+	if err == nil {
+		event, err = tx.UpdateEvent(ctx, database.UpdateEventParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Website:     nullString(data.Website),
+			StartsAt:    data.StartsAt,
+			EndsAt:      data.EndsAt,
+			EventID:     event.EventID,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Action = importActionUpdated
+		result.Uuid = event.Uuid
+		return result, nil
+	}
+
+	if *count >= maxUserEvents {
+		result.Action = importActionFailed
+		result.Message = "You have hit the event limit, try deleting some unused events."
+		return result, nil
+	}
+
+	event, err = tx.InsertEvent(ctx, database.InsertEventParams{
+		Name:        data.Name,
+		Description: data.Description,
+		Tags:        data.Tags,
+		Website:     nullString(data.Website),
+		StartsAt:    data.StartsAt,
+		EndsAt:      data.EndsAt,
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		return result, err
+	}
+	*count++
+
+	result.Action = importActionCreated
+	result.Uuid = event.Uuid
+	return result, nil
+}
+
+func readTransfer(format string, r io.Reader) (Transfer, error) {
+	var transfer Transfer
+	switch format {
+	case transferFormatCSV:
+		return readTransferCSV(r)
+	case transferFormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&transfer); err != nil {
+			return transfer, err
+		}
+	default:
+		if err := json.NewDecoder(r).Decode(&transfer); err != nil {
+			return transfer, err
+		}
+	}
+
+	for i := range transfer.Projects {
+		transfer.Projects[i].row = i
+	}
+	for i := range transfer.Events {
+		transfer.Events[i].row = i
+	}
+
+	return transfer, nil
+}
+
+func readTransferCSV(r io.Reader) (Transfer, error) {
+	var transfer Transfer
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return transfer, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := columns["type"]; !ok {
+		return transfer, errors.New("csv is missing the type column")
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return transfer, err
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		var tags []string
+		if field("tags") != "" {
+			tags = strings.Split(field("tags"), ",")
+		}
+
+		switch field("type") {
+		case "project":
+			transfer.Projects = append(transfer.Projects, TransferProject{
+				Uuid:        field("uuid"),
+				Name:        field("name"),
+				Description: field("description"),
+				Tags:        tags,
+				Repository:  field("repository"),
+				Website:     field("website"),
+				row:         line,
+			})
+		case "event":
+			invalid := ImportResult{
+				Type:   "event",
+				Row:    line,
+				Name:   field("name"),
+				Action: importActionFailed,
+			}
+
+			startsAt, err := time.Parse(time.RFC3339, field("starts_at"))
+			if err != nil {
+				invalid.Message = "The starts_at column is not an RFC 3339 time."
+				transfer.invalid = append(transfer.invalid, invalid)
+				continue
+			}
+
+			endsAt, err := time.Parse(time.RFC3339, field("ends_at"))
+			if err != nil {
+				invalid.Message = "The ends_at column is not an RFC 3339 time."
+				transfer.invalid = append(transfer.invalid, invalid)
+				continue
+			}
+
+			transfer.Events = append(transfer.Events, TransferEvent{
+				Uuid:        field("uuid"),
+				Name:        field("name"),
+				Description: field("description"),
+				Tags:        tags,
+				Website:     field("website"),
+				StartsAt:    startsAt,
+				EndsAt:      endsAt,
+				row:         line,
+			})
+		default:
+			transfer.invalid = append(transfer.invalid, ImportResult{
+				Type:    field("type"),
+				Row:     line,
+				Name:    field("name"),
+				Action:  importActionFailed,
+				Message: "The type column must be project or event.",
+			})
+		}
+	}
+
+	return transfer, nil
+}
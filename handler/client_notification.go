@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+)
+
+type NotificationPreferences struct {
+	ListingCreated bool      `json:"listing_created"`
+	ListingChanges bool      `json:"listing_changes"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NotificationPreferencesFromDatabase(np database.NotificationPreference) NotificationPreferences {
+	return NotificationPreferences{
+		ListingCreated: np.ListingCreated,
+		ListingChanges: np.ListingChanges,
+		UpdatedAt:      np.UpdatedAt,
+	}
+}
+
+func (c *Client) NotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	prefs, err := c.store.NotificationPreferences(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch notification preferences", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch notification preferences.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": NotificationPreferencesFromDatabase(prefs),
+	})
+}
+
+func (c *Client) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	// Omitted preferences are left as is.
+	var data struct {
+		ListingCreated *bool `json:"listing_created"`
+		ListingChanges *bool `json:"listing_changes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	prefs, err := c.store.NotificationPreferences(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch notification preferences", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch notification preferences.",
+		})
+		return
+	}
+
+	params := database.UpdateNotificationPreferencesParams{
+		UserID:         authUser.UserID,
+		ListingCreated: prefs.ListingCreated,
+		ListingChanges: prefs.ListingChanges,
+	}
+	if data.ListingCreated != nil {
+		params.ListingCreated = *data.ListingCreated
+	}
+	if data.ListingChanges != nil {
+		params.ListingChanges = *data.ListingChanges
+	}
+
+	prefs, err = c.store.UpdateNotificationPreferences(r.Context(), params)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update notification preferences", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update notification preferences.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": NotificationPreferencesFromDatabase(prefs),
+	})
+}
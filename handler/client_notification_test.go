@@ -0,0 +1,53 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+)
+
+func TestClientNotificationPreferences(t *testing.T) {
+	api := newTestAPI(t)
+	c := api.login(api.user("alice@example.com"))
+
+	var res itemResponse[handler.NotificationPreferences]
+	c.expect(http.StatusOK, http.MethodGet, "/client/account/notifications", nil, &res)
+	if !res.Item.ListingCreated || !res.Item.ListingChanges {
+		t.Fatalf("got %+v, want every notification enabled by default", res.Item)
+	}
+
+	res = itemResponse[handler.NotificationPreferences]{}
+	c.expect(http.StatusOK, http.MethodPost, "/client/account/notifications", map[string]bool{"listing_created": false}, &res)
+	if res.Item.ListingCreated || !res.Item.ListingChanges {
+		t.Errorf("got %+v, want only the listing confirmations turned off", res.Item)
+	}
+}
+
+func TestPublicUnsubscribe(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+
+	prefs, err := api.store.NotificationPreferences(context.Background(), alice.UserID)
+	if err != nil {
+		t.Fatalf("could not fetch notification preferences: %v", err)
+	}
+	path := "/public/notifications/unsubscribe/" + prefs.UnsubscribeToken.String()
+
+	c := api.anonymous()
+	c.expect(http.StatusNotFound, http.MethodPost, "/public/notifications/unsubscribe/"+alice.Uuid.String(), nil, nil)
+	c.expect(http.StatusBadRequest, http.MethodPost, path+"?category=welcome", nil, nil)
+
+	var res itemResponse[handler.NotificationPreferences]
+	c.expect(http.StatusOK, http.MethodPost, path+"?category=listing_changes", nil, &res)
+	if !res.Item.ListingCreated || res.Item.ListingChanges {
+		t.Errorf("got %+v, want only the listing changes turned off", res.Item)
+	}
+
+	res = itemResponse[handler.NotificationPreferences]{}
+	c.expect(http.StatusOK, http.MethodPost, path, nil, &res)
+	if res.Item.ListingCreated || res.Item.ListingChanges {
+		t.Errorf("got %+v, want every notification turned off", res.Item)
+	}
+}
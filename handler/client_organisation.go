@@ -0,0 +1,674 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// Owners and admins manage an organisation along with its projects and events, only owners
+// can delete it or hand out the owner role. Members are listed on the organisation.
+const (
+	organisationRoleOwner  = "owner"
+	organisationRoleAdmin  = "admin"
+	organisationRoleMember = "member"
+)
+
+var organisationSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type storeOrganisationData struct {
+	Name        string `json:"name" validate:"required,min=2,max=191"`
+	Slug        string `json:"slug" validate:"required,min=2,max=64"`
+	Logo        string `json:"logo" validate:"omitempty,url,max=191"`
+	Description string `json:"description" validate:"required,min=8,max=2048"`
+	Website     string `json:"website" validate:"omitempty,url,max=191"`
+	Industry    string `json:"industry" validate:"required,min=2,max=64"`
+	HQState     string `json:"hq_state" validate:"required,oneof=johor kedah kelantan kuala-lumpur labuan melaka negeri-sembilan pahang perak perlis pulau-pinang putrajaya sabah sarawak selangor terengganu"`
+	FoundedYear int32  `json:"founded_year" validate:"required,min=1900,max=2100"`
+	TeamSize    string `json:"team_size" validate:"required,oneof=1-10 11-50 51-200 201-500 501+"`
+}
+
+type OrganisationMember struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	GitHubEmail string    `json:"github_email"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func OrganisationMemberFromDatabase(om database.OrganisationMembersRow) OrganisationMember {
+	return OrganisationMember{
+		Uuid:        om.UserUuid,
+		GitHubEmail: om.GithubEmail,
+		Role:        om.Role,
+		CreatedAt:   om.CreatedAt,
+	}
+}
+
+// authorizeOrganisation fetches the organisation of the request along with the role of the
+// user on it. It writes a not found response and returns false when the organisation does
+// not exist or the user is not a member.
+func (c *Client) authorizeOrganisation(w http.ResponseWriter, r *http.Request, user database.User) (database.Organisation, string, bool) {
+	organisationUuid, err := uuid.FromString(chi.URLParam(r, "organisation"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Organisation{}, "", false
+	}
+
+	organisation, err := c.store.OrganisationByUUID(r.Context(), organisationUuid)
+	if err == nil {
+		var member database.OrganisationMember
+		member, err = c.store.OrganisationMember(r.Context(), organisation.OrganisationID, user.UserID)
+		if err == nil {
+			return organisation, member.Role, true
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Organisation{}, "", false
+	}
+
+	requestLogger(r, c.logger).Error("could not fetch organisation member", slog.Any("err", err))
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Could not fetch organisation.",
+	})
+	return database.Organisation{}, "", false
+}
+
+func canManageOrganisation(role string) bool {
+	return role == organisationRoleOwner || role == organisationRoleAdmin
+}
+
+func writeOrganisationForbidden(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Your role on the organisation does not allow this action.",
+	})
+}
+
+// organisationFromData resolves the organisation a project or event is handed to, an empty
+// uuid takes it away from its organisation. Only owners and admins of an organisation can
+// hand anything other than current to it. It writes an error response and returns false
+// otherwise.
+func (c *Client) organisationFromData(w http.ResponseWriter, r *http.Request, user database.User, organisationUuid string, current nulls.Int32) (nulls.Int32, bool) {
+	if organisationUuid == "" {
+		return nulls.Int32{}, true
+	}
+
+	organisation, err := c.store.OrganisationByUUID(r.Context(), uuid.FromStringOrNil(organisationUuid))
+	if err == nil {
+		if current.Valid && current.Int32 == organisation.OrganisationID {
+			return current, true
+		}
+
+		var member database.OrganisationMember
+		member, err = c.store.OrganisationMember(r.Context(), organisation.OrganisationID, user.UserID)
+		if err == nil {
+			if !canManageOrganisation(member.Role) {
+				writeOrganisationForbidden(w)
+				return nulls.Int32{}, false
+			}
+			return nulls.NewInt32(organisation.OrganisationID), true
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The organisation could not be found.",
+		})
+		return nulls.Int32{}, false
+	}
+
+	requestLogger(r, c.logger).Error("could not fetch organisation member", slog.Any("err", err))
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Could not fetch organisation.",
+	})
+	return nulls.Int32{}, false
+}
+
+// canManageOrganisationOf reports whether user is an owner or admin of the organisation of
+// a project or event, which is false when it does not belong to any.
+func (c *Client) canManageOrganisationOf(r *http.Request, user database.User, organisationID nulls.Int32) (bool, error) {
+	if !organisationID.Valid {
+		return false, nil
+	}
+
+	member, err := c.store.OrganisationMember(r.Context(), organisationID.Int32, user.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return canManageOrganisation(member.Role), nil
+}
+
+// decodeOrganisationData decodes and validates the organisation of the request body. It
+// writes a bad request response and returns false when it is malformed.
+func (c *Client) decodeOrganisationData(w http.ResponseWriter, r *http.Request) (storeOrganisationData, bool) {
+	var data storeOrganisationData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeOrganisationData{}, false
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil || !organisationSlugPattern.MatchString(data.Slug) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeOrganisationData{}, false
+	}
+
+	return data, true
+}
+
+// slugTaken reports whether slug is used by an organisation other than organisationID. It
+// writes an error response when it is taken or could not be checked.
+func (c *Client) slugTaken(w http.ResponseWriter, r *http.Request, slug string, organisationID int32) bool {
+	organisation, err := c.store.OrganisationBySlug(r.Context(), slug)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch organisation by slug", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation.",
+		})
+		return true
+	}
+
+	if organisation.OrganisationID == organisationID {
+		return false
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "The slug is already taken.",
+	})
+	return true
+}
+
+func (c *Client) Organisations(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisations, err := c.store.UserOrganisations(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user organisations", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user organisations.",
+		})
+		return
+	}
+
+	apiOrganisations := make([]Organisation, len(organisations))
+	for i, o := range organisations {
+		apiOrganisations[i] = OrganisationFromDatabase(o)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiOrganisations,
+	})
+}
+
+func (c *Client) Organisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, _, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationFromDatabase(organisation),
+	})
+}
+
+// StoreOrganisation creates an organisation with the user as its owner.
+func (c *Client) StoreOrganisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	data, ok := c.decodeOrganisationData(w, r)
+	if !ok {
+		return
+	}
+
+	if c.slugTaken(w, r, data.Slug, 0) {
+		return
+	}
+
+	var organisation database.Organisation
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		var err error
+		organisation, err = tx.InsertOrganisation(r.Context(), database.InsertOrganisationParams{
+			Name:        data.Name,
+			Slug:        data.Slug,
+			Logo:        nullString(data.Logo),
+			Description: data.Description,
+			Website:     nullString(data.Website),
+			Industry:    data.Industry,
+			HqState:     data.HQState,
+			FoundedYear: data.FoundedYear,
+			TeamSize:    data.TeamSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.InsertOrganisationMember(r.Context(), database.InsertOrganisationMemberParams{
+			Role:           organisationRoleOwner,
+			OrganisationID: organisation.OrganisationID,
+			UserID:         authUser.UserID,
+		})
+		return err
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert organisation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert organisation into database.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationFromDatabase(organisation),
+	})
+}
+
+func (c *Client) UpdateOrganisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, role, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageOrganisation(role) {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	data, ok := c.decodeOrganisationData(w, r)
+	if !ok {
+		return
+	}
+
+	if c.slugTaken(w, r, data.Slug, organisation.OrganisationID) {
+		return
+	}
+
+	organisation, err := c.store.UpdateOrganisation(r.Context(), database.UpdateOrganisationParams{
+		Name:           data.Name,
+		Slug:           data.Slug,
+		Logo:           nullString(data.Logo),
+		Description:    data.Description,
+		Website:        nullString(data.Website),
+		Industry:       data.Industry,
+		HqState:        data.HQState,
+		FoundedYear:    data.FoundedYear,
+		TeamSize:       data.TeamSize,
+		OrganisationID: organisation.OrganisationID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update organisation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update organisation.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationFromDatabase(organisation),
+	})
+}
+
+// DeleteOrganisation deletes an organisation, its projects and events stay with the users
+// who created them.
+func (c *Client) DeleteOrganisation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, role, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if role != organisationRoleOwner {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	if err := c.store.DeleteOrganisation(r.Context(), organisation.OrganisationID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete organisation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete organisation.",
+		})
+		return
+	}
+}
+
+func (c *Client) OrganisationMembers(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, _, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	members, err := c.store.OrganisationMembers(r.Context(), organisation.OrganisationID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch organisation members", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation members.",
+		})
+		return
+	}
+
+	apiMembers := make([]OrganisationMember, len(members))
+	for i, om := range members {
+		apiMembers[i] = OrganisationMemberFromDatabase(om)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiMembers,
+	})
+}
+
+// StoreOrganisationMember adds a user to an organisation by the email of their GitHub
+// account, only owners can add other owners.
+func (c *Client) StoreOrganisationMember(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, role, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageOrganisation(role) {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	var data struct {
+		Email string `json:"email" validate:"required,email,max=191"`
+		Role  string `json:"role" validate:"required,oneof=owner admin member"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if data.Role == organisationRoleOwner && role != organisationRoleOwner {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	user, err := c.store.UserByGithubEmail(r.Context(), strings.ToLower(data.Email))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "There is no user with that email.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch user by github email", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user.",
+		})
+		return
+	}
+
+	_, err = c.store.OrganisationMember(r.Context(), organisation.OrganisationID, user.UserID)
+	if err == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The user is already a member of the organisation.",
+		})
+		return
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		requestLogger(r, c.logger).Error("could not fetch organisation member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation member.",
+		})
+		return
+	}
+
+	member, err := c.store.InsertOrganisationMember(r.Context(), database.InsertOrganisationMemberParams{
+		Role:           data.Role,
+		OrganisationID: organisation.OrganisationID,
+		UserID:         user.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert organisation member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert organisation member into database.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationMember{
+			Uuid:        user.Uuid,
+			GitHubEmail: user.GithubEmail,
+			Role:        member.Role,
+			CreatedAt:   member.CreatedAt,
+		},
+	})
+}
+
+// organisationMemberFromRequest fetches the member of the request on organisation. It writes
+// a not found response and returns false when the user is not a member.
+func (c *Client) organisationMemberFromRequest(w http.ResponseWriter, r *http.Request, organisation database.Organisation) (database.User, database.OrganisationMember, bool) {
+	userUuid, err := uuid.FromString(chi.URLParam(r, "user"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.User{}, database.OrganisationMember{}, false
+	}
+
+	user, err := c.store.UserByUUID(r.Context(), userUuid)
+	if err == nil {
+		var member database.OrganisationMember
+		member, err = c.store.OrganisationMember(r.Context(), organisation.OrganisationID, user.UserID)
+		if err == nil {
+			return user, member, true
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.User{}, database.OrganisationMember{}, false
+	}
+
+	requestLogger(r, c.logger).Error("could not fetch organisation member", slog.Any("err", err))
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Could not fetch organisation member.",
+	})
+	return database.User{}, database.OrganisationMember{}, false
+}
+
+// lastOwner reports whether member is the only owner left of the organisation. It writes
+// an error response when it is or could not be checked.
+func (c *Client) lastOwner(w http.ResponseWriter, r *http.Request, member database.OrganisationMember) bool {
+	if member.Role != organisationRoleOwner {
+		return false
+	}
+
+	count, err := c.store.CountOrganisationOwners(r.Context(), member.OrganisationID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch organisation owners count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation owners count.",
+		})
+		return true
+	}
+
+	if count > 1 {
+		return false
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Organisations must keep at least one owner.",
+	})
+	return true
+}
+
+// UpdateOrganisationMember changes the role of a member, only owners can change the role of
+// an owner or make someone an owner.
+func (c *Client) UpdateOrganisationMember(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, role, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageOrganisation(role) {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	user, member, ok := c.organisationMemberFromRequest(w, r, organisation)
+	if !ok {
+		return
+	}
+
+	var data struct {
+		Role string `json:"role" validate:"required,oneof=owner admin member"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if (data.Role == organisationRoleOwner || member.Role == organisationRoleOwner) && role != organisationRoleOwner {
+		writeOrganisationForbidden(w)
+		return
+	}
+
+	if data.Role != organisationRoleOwner && c.lastOwner(w, r, member) {
+		return
+	}
+
+	member, err := c.store.UpdateOrganisationMemberRole(r.Context(), database.UpdateOrganisationMemberRoleParams{
+		Role:           data.Role,
+		OrganisationID: organisation.OrganisationID,
+		UserID:         user.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update organisation member role", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update organisation member.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationMember{
+			Uuid:        user.Uuid,
+			GitHubEmail: user.GithubEmail,
+			Role:        member.Role,
+			CreatedAt:   member.CreatedAt,
+		},
+	})
+}
+
+// DeleteOrganisationMember removes a member from an organisation, members can always remove
+// themselves as long as an owner is left.
+func (c *Client) DeleteOrganisationMember(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	organisation, role, ok := c.authorizeOrganisation(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	user, member, ok := c.organisationMemberFromRequest(w, r, organisation)
+	if !ok {
+		return
+	}
+
+	if user.UserID != authUser.UserID {
+		if !canManageOrganisation(role) || (member.Role == organisationRoleOwner && role != organisationRoleOwner) {
+			writeOrganisationForbidden(w)
+			return
+		}
+	}
+
+	if c.lastOwner(w, r, member) {
+		return
+	}
+
+	if err := c.store.DeleteOrganisationMember(r.Context(), organisation.OrganisationID, user.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete organisation member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete organisation member.",
+		})
+		return
+	}
+}
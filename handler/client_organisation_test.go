@@ -0,0 +1,144 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend/handler"
+)
+
+func organisationData(name, slug string) map[string]any {
+	return map[string]any{
+		"name":         name,
+		"slug":         slug,
+		"description":  "A startup made in Malaysia.",
+		"industry":     "fintech",
+		"hq_state":     "selangor",
+		"founded_year": 2019,
+		"team_size":    "11-50",
+	}
+}
+
+func TestClientStoreOrganisation(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	ac := api.login(alice)
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/organisations", organisationData("Kedai Pay", "Kedai Pay"), nil)
+
+	var res itemResponse[handler.Organisation]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/organisations", organisationData("Kedai Pay", "kedai-pay"), &res)
+	if res.Item.Slug != "kedai-pay" || res.Item.HQState != "selangor" {
+		t.Fatalf("got %+v, want kedai-pay in selangor", res.Item)
+	}
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/organisations", organisationData("Kedai Pay Too", "kedai-pay"), nil)
+
+	var members itemsResponse[handler.OrganisationMember]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/organisations/"+res.Item.Uuid.String()+"/members", nil, &members)
+	if len(members.Items) != 1 || members.Items[0].Uuid != alice.Uuid || members.Items[0].Role != "owner" {
+		t.Fatalf("got %v, want alice as owner", members.Items)
+	}
+
+	var organisations itemsResponse[handler.Organisation]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/organisations", nil, &organisations)
+	if len(organisations.Items) != 1 || organisations.Items[0].Uuid != res.Item.Uuid {
+		t.Errorf("got %v, want the organisation of alice", organisations.Items)
+	}
+
+	api.login(api.user("bob@example.com")).expect(http.StatusNotFound, http.MethodGet, "/client/organisations/"+res.Item.Uuid.String(), nil, nil)
+}
+
+func TestClientOrganisationMemberRoles(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	carol := api.user("carol@example.com")
+	organisation := api.organisation(alice, "Kedai Pay", "fintech", "selangor")
+	path := "/client/organisations/" + organisation.Uuid.String()
+
+	ac := api.login(alice)
+	bc := api.login(bob)
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/members", map[string]string{"email": "dave@example.com", "role": "member"}, nil)
+	ac.expect(http.StatusOK, http.MethodPost, path+"/members", map[string]string{"email": "bob@example.com", "role": "admin"}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/members", map[string]string{"email": "bob@example.com", "role": "member"}, nil)
+
+	// Admins manage the organisation and its members but not its owners.
+	bc.expect(http.StatusOK, http.MethodPost, path, organisationData("Kedai Pay Sdn Bhd", "kedai-pay"), nil)
+	bc.expect(http.StatusForbidden, http.MethodPost, path+"/members", map[string]string{"email": "carol@example.com", "role": "owner"}, nil)
+	bc.expect(http.StatusOK, http.MethodPost, path+"/members", map[string]string{"email": "carol@example.com", "role": "member"}, nil)
+	bc.expect(http.StatusForbidden, http.MethodPost, path+"/members/"+alice.Uuid.String(), map[string]string{"role": "member"}, nil)
+	bc.expect(http.StatusForbidden, http.MethodDelete, path+"/members/"+alice.Uuid.String(), nil, nil)
+	bc.expect(http.StatusForbidden, http.MethodDelete, path, nil, nil)
+
+	// Members only look.
+	cc := api.login(carol)
+	cc.expect(http.StatusOK, http.MethodGet, path+"/members", nil, nil)
+	cc.expect(http.StatusForbidden, http.MethodPost, path, organisationData("Kedai Pay", "kedai-pay"), nil)
+
+	// Organisations keep at least one owner.
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/members/"+alice.Uuid.String(), map[string]string{"role": "admin"}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodDelete, path+"/members/"+alice.Uuid.String(), nil, nil)
+	ac.expect(http.StatusOK, http.MethodPost, path+"/members/"+bob.Uuid.String(), map[string]string{"role": "owner"}, nil)
+	ac.expect(http.StatusOK, http.MethodDelete, path+"/members/"+alice.Uuid.String(), nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+
+	bc.expect(http.StatusOK, http.MethodDelete, path, nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+}
+
+func TestClientOrganisationProjectsAndEvents(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	carol := api.user("carol@example.com")
+	organisation := api.organisation(alice, "Kedai Pay", "fintech", "selangor")
+	path := "/client/organisations/" + organisation.Uuid.String()
+
+	ac := api.login(alice)
+	ac.expect(http.StatusOK, http.MethodPost, path+"/members", map[string]string{"email": "bob@example.com", "role": "admin"}, nil)
+	ac.expect(http.StatusOK, http.MethodPost, path+"/members", map[string]string{"email": "carol@example.com", "role": "member"}, nil)
+
+	project := map[string]any{"name": "Kedai Pay Gateway", "description": "A payment gateway for hawkers.", "organisation": organisation.Uuid.String()}
+	cc := api.login(carol)
+	cc.expect(http.StatusForbidden, http.MethodPost, "/client/projects", project, nil)
+
+	var res itemResponse[handler.Project]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/projects", project, &res)
+
+	var projects itemsResponse[handler.Project]
+	api.anonymous().expect(http.StatusOK, http.MethodGet, "/public/organisations/kedai-pay/projects", nil, &projects)
+	if len(projects.Items) != 1 || projects.Items[0].Uuid != res.Item.Uuid {
+		t.Fatalf("got %v, want the project of the organisation", projects.Items)
+	}
+
+	// Admins of the organisation maintain its projects, members do not.
+	bc := api.login(bob)
+	bc.expect(http.StatusOK, http.MethodGet, "/client/projects/"+res.Item.Uuid.String()+"/members", nil, nil)
+	bc.expect(http.StatusForbidden, http.MethodDelete, "/client/projects/"+res.Item.Uuid.String(), nil, nil)
+	cc.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+res.Item.Uuid.String(), nil, nil)
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	var event itemResponse[handler.Event]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/events", map[string]any{
+		"name":         "Kedai Pay Launch Party",
+		"description":  "The launch of the Kedai Pay gateway.",
+		"starts_at":    startsAt,
+		"ends_at":      startsAt.Add(2 * time.Hour),
+		"organisation": organisation.Uuid.String(),
+	}, &event)
+
+	var events itemsResponse[handler.Event]
+	api.anonymous().expect(http.StatusOK, http.MethodGet, "/public/organisations/kedai-pay/events", nil, &events)
+	if len(events.Items) != 1 || events.Items[0].Uuid != event.Item.Uuid {
+		t.Fatalf("got %v, want the event of the organisation", events.Items)
+	}
+
+	cc.expect(http.StatusNotFound, http.MethodDelete, "/client/events/"+event.Item.Uuid.String(), nil, nil)
+	bc.expect(http.StatusOK, http.MethodDelete, "/client/events/"+event.Item.Uuid.String(), nil, nil)
+
+	// Taking a project away from its organisation takes it away from the admins too.
+	ac.expect(http.StatusOK, http.MethodPost, "/client/projects/"+res.Item.Uuid.String(), map[string]any{"name": "Kedai Pay Gateway", "description": "A payment gateway for hawkers.", "organisation": ""}, nil)
+	bc.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+res.Item.Uuid.String(), nil, nil)
+}
@@ -1,46 +1,39 @@
 package handler
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
-	"github.com/go-chi/chi/v5"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
 	"github.com/gobuffalo/nulls"
-	"github.com/gofrs/uuid"
 )
 
+type storeProjectData struct {
+	Name        string   `json:"name" validate:"required,min=8,max=191"`
+	Description string   `json:"description" validate:"required,min=8,max=512"`
+	Tags        []string `json:"tags" validate:"min=0,max=6,dive,min=4,max=12"`
+	Repository  string   `json:"repository" validate:"omitempty,url,max=191"`
+	Website     string   `json:"website" validate:"omitempty,url,max=191"`
+	// Organisation is the uuid of the organisation the project is handed to.
+	Organisation string `json:"organisation" validate:"omitempty,uuid"`
+}
+
 func (c *Client) Projects(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
 
-	orderBy := "desc"
-	if r.URL.Query().Get("orderBy") == "asc" {
-		orderBy = "asc"
-	}
-
-	var projects []database.Project
-	var err error
-	switch orderBy {
-	case "asc":
-		projects, err = c.queries.UserProjectsByAscOffsetLimit(r.Context(), c.database, database.UserProjectsByAscOffsetLimitParams{
-			Offset: int32(offset),
-			Limit:  int32(limit),
-			UserID: authUser.UserID,
-		})
-	case "desc":
-		projects, err = c.queries.UserProjectsByDescOffsetLimit(r.Context(), c.database, database.UserProjectsByDescOffsetLimitParams{
-			Offset: int32(offset),
-			Limit:  int32(limit),
-			UserID: authUser.UserID,
-		})
-	}
+	projects, err := c.store.UserProjects(r.Context(), authUser.UserID, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
 	if err != nil {
-		c.logger.Error("could not fetch user projects by limit offset", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user projects by limit offset", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user projects.",
@@ -48,9 +41,9 @@ func (c *Client) Projects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	total, err := c.queries.CountUserProjects(r.Context(), c.database, authUser.UserID)
+	total, err := c.store.CountUserProjects(r.Context(), authUser.UserID)
 	if err != nil {
-		c.logger.Error("could not fetch user projects count", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user projects count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user projects count.",
@@ -58,9 +51,14 @@ func (c *Client) Projects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apiProjects := make([]Project, len(projects))
-	for i, p := range projects {
-		apiProjects[i] = ProjectFromDatabase(p)
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, projects)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]any{
@@ -72,56 +70,30 @@ func (c *Client) Projects(w http.ResponseWriter, r *http.Request) {
 func (c *Client) Project(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	projectUuid, err := uuid.FromString(chi.URLParam(r, "project"))
-	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
+	project, _, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
 		return
 	}
 
-	project, err := c.queries.ProjectByUUID(r.Context(), c.database, projectUuid)
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, []database.Project{project})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "The resource you are looking for could not be found.",
-			})
-			return
-		}
-
-		c.logger.Error("could not fetch project by uuid", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not fetch project.",
-		})
-		return
-	}
-
-	if project.UserID != authUser.UserID {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
+			"message": "Could not fetch project repository stats.",
 		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]any{
-		"item": ProjectFromDatabase(project),
+		"item": apiProjects[0],
 	})
 }
 
 func (c *Client) StoreProject(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	var data struct {
-		Name        string   `json:"name" validate:"required,min=8,max=191"`
-		Description string   `json:"description" validate:"required,min=8,max=512"`
-		Tags        []string `json:"tags" validate:"min=0,max=6,dive,min=4,max=12"`
-		Repository  string   `json:"repository" validate:"omitempty,url,max=191"`
-		Website     string   `json:"website" validate:"omitempty,url,max=191"`
-	}
+	var data storeProjectData
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -138,9 +110,9 @@ func (c *Client) StoreProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	count, err := c.queries.CountUserProjects(r.Context(), c.database, authUser.UserID)
+	count, err := c.store.CountUserProjects(r.Context(), authUser.UserID)
 	if err != nil {
-		c.logger.Error("could not fetch user projects count", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not fetch user projects count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch user projects count.",
@@ -148,7 +120,7 @@ func (c *Client) StoreProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if count >= 20 {
+	if count >= maxUserProjects {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "You have hit the project limit, try deleting some unused projects.",
@@ -172,16 +144,43 @@ func (c *Client) StoreProject(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	project, err := c.queries.InsertProject(r.Context(), c.database, database.InsertProjectParams{
-		Name:        data.Name,
-		Description: data.Description,
-		Tags:        data.Tags,
-		Repository:  repository,
-		Website:     website,
-		UserID:      authUser.UserID,
+	organisationID, ok := c.organisationFromData(w, r, authUser, data.Organisation, nulls.Int32{})
+	if !ok {
+		return
+	}
+
+	var project database.Project
+	err = c.store.WithTx(r.Context(), func(tx store.Store) error {
+		project, err = tx.InsertProject(r.Context(), database.InsertProjectParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Repository:  repository,
+			Website:     website,
+			UserID:      authUser.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if organisationID.Valid {
+			project, err = tx.SetProjectOrganisation(r.Context(), project.ProjectID, organisationID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = webhooks.Dispatch(r.Context(), tx, webhooks.EventProjectCreated, project.UserID, map[string]any{
+			"project": ProjectFromDatabase(project),
+		})
+		if err != nil {
+			return err
+		}
+
+		return notify.Enqueue(r.Context(), tx, project.UserID, notify.ListingCreated, notify.ProjectListing(project, authUser))
 	})
 	if err != nil {
-		c.logger.Error("could not insert project", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not insert project", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not insert project into database.",
@@ -197,38 +196,8 @@ func (c *Client) StoreProject(w http.ResponseWriter, r *http.Request) {
 func (c *Client) UpdateProject(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	projectUuid, err := uuid.FromString(chi.URLParam(r, "project"))
-	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
-		return
-	}
-
-	project, err := c.queries.ProjectByUUID(r.Context(), c.database, projectUuid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "The resource you are looking for could not be found.",
-			})
-			return
-		}
-
-		c.logger.Error("could not fetch project by uuid", slog.Any("err", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not fetch project.",
-		})
-		return
-	}
-
-	if project.UserID != authUser.UserID {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
 		return
 	}
 
@@ -238,6 +207,9 @@ func (c *Client) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		Tags        []string `json:"tags" validate:"dive,min=4,max=12"`
 		Repository  string   `json:"repository" validate:"omitempty,url,max=191"`
 		Website     string   `json:"website" validate:"omitempty,url,max=191"`
+		// Organisation is left as is when omitted, an empty uuid takes the project away from
+		// its organisation.
+		Organisation *string `json:"organisation"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -271,16 +243,56 @@ func (c *Client) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	project, err = c.queries.UpdateProject(r.Context(), c.database, database.UpdateProjectParams{
-		Name:        data.Name,
-		Description: data.Description,
-		Tags:        data.Tags,
-		Repository:  repository,
-		Website:     website,
-		ProjectID:   project.ProjectID,
+	organisationID := project.OrganisationID
+	if data.Organisation != nil {
+		organisationID, ok = c.organisationFromData(w, r, authUser, *data.Organisation, project.OrganisationID)
+		if !ok {
+			return
+		}
+
+		if organisationID != project.OrganisationID && !canManageProject(role) {
+			writeProjectForbidden(w)
+			return
+		}
+	}
+
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		var err error
+		project, err = tx.UpdateProject(r.Context(), database.UpdateProjectParams{
+			Name:        data.Name,
+			Description: data.Description,
+			Tags:        data.Tags,
+			Repository:  repository,
+			Website:     website,
+			ProjectID:   project.ProjectID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if organisationID != project.OrganisationID {
+			project, err = tx.SetProjectOrganisation(r.Context(), project.ProjectID, organisationID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = webhooks.Dispatch(r.Context(), tx, webhooks.EventProjectUpdated, project.UserID, map[string]any{
+			"project": ProjectFromDatabase(project),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Owners are only told about the edits of others.
+		if authUser.UserID == project.UserID {
+			return nil
+		}
+
+		return notify.Enqueue(r.Context(), tx, project.UserID, notify.ListingUpdated, notify.ProjectListing(project, authUser))
 	})
 	if err != nil {
-		c.logger.Error("could not update project", slog.Any("err", err))
+		requestLogger(r, c.logger).Error("could not update project", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not update project.",
@@ -288,51 +300,47 @@ func (c *Client) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, []database.Project{project})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
 	json.NewEncoder(w).Encode(map[string]any{
-		"item": ProjectFromDatabase(project),
+		"item": apiProjects[0],
 	})
 }
 
 func (c *Client) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
 
-	projectUuid, err := uuid.FromString(chi.URLParam(r, "project"))
-	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
-		})
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
 		return
 	}
 
-	project, err := c.queries.ProjectByUUID(r.Context(), c.database, projectUuid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "The resource you are looking for could not be found.",
-			})
-			return
-		}
-
-		c.logger.Error("could not fetch project by uuid", slog.Any("err", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Could not fetch project.",
-		})
+	if role != projectRoleOwner {
+		writeProjectForbidden(w)
 		return
 	}
 
-	if project.UserID != authUser.UserID {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "The resource you are looking for could not be found.",
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		err := webhooks.Dispatch(r.Context(), tx, webhooks.EventProjectDeleted, project.UserID, map[string]any{
+			"project": ProjectFromDatabase(project),
 		})
-		return
-	}
+		if err != nil {
+			return err
+		}
 
-	if err := c.queries.DeleteProject(r.Context(), c.database, project.ProjectID); err != nil {
-		c.logger.Error("could not delete project", slog.Any("err", err))
+		// Only the owner deletes a project, so there is nobody else to tell.
+		return tx.DeleteProject(r.Context(), project.ProjectID)
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not delete project", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not delete project.",
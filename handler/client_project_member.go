@@ -0,0 +1,778 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+)
+
+// The owner of a project is the user of the project, the other roles are held by its members.
+// Any role can view and edit a project, owners and maintainers manage its members and
+// invitations, and only owners can delete or transfer it.
+const (
+	projectRoleOwner      = "owner"
+	projectRoleMaintainer = "maintainer"
+	projectRoleEditor     = "editor"
+)
+
+type ProjectMember struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	GitHubEmail string    `json:"github_email"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ProjectMemberFromDatabase(pm database.ProjectMembersRow) ProjectMember {
+	return ProjectMember{
+		Uuid:        pm.UserUuid,
+		GitHubEmail: pm.GithubEmail,
+		Role:        pm.Role,
+		CreatedAt:   pm.CreatedAt,
+	}
+}
+
+type ProjectInvitation struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	ProjectUuid uuid.UUID `json:"project_uuid"`
+	ProjectName string    `json:"project_name"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ProjectInvitationFromDatabase(pi database.ProjectInvitation, p database.Project) ProjectInvitation {
+	return ProjectInvitation{
+		Uuid:        pi.Uuid,
+		Email:       pi.Email,
+		Role:        pi.Role,
+		ProjectUuid: p.Uuid,
+		ProjectName: p.Name,
+		CreatedAt:   pi.CreatedAt,
+	}
+}
+
+// authorizeProject fetches the project of the request along with the role of the user on
+// it, owners and admins of its organisation maintain it. It writes a not found response and
+// returns false when the project does not exist or the user has no role on it.
+func (c *Client) authorizeProject(w http.ResponseWriter, r *http.Request, user database.User) (database.Project, string, bool) {
+	projectUuid, err := uuid.FromString(chi.URLParam(r, "project"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Project{}, "", false
+	}
+
+	project, err := c.store.ProjectByUUID(r.Context(), projectUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Project{}, "", false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch project by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project.",
+		})
+		return database.Project{}, "", false
+	}
+
+	if project.UserID == user.UserID {
+		return project, projectRoleOwner, true
+	}
+
+	member, err := c.store.ProjectMember(r.Context(), project.ProjectID, user.UserID)
+	if err == nil {
+		return project, member.Role, true
+	}
+
+	if errors.Is(err, store.ErrNotFound) {
+		var manages bool
+		if manages, err = c.canManageOrganisationOf(r, user, project.OrganisationID); err == nil {
+			if manages {
+				return project, projectRoleMaintainer, true
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Project{}, "", false
+		}
+	}
+
+	requestLogger(r, c.logger).Error("could not fetch project member", slog.Any("err", err))
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Could not fetch project member.",
+	})
+	return database.Project{}, "", false
+}
+
+func canManageProject(role string) bool {
+	return role == projectRoleOwner || role == projectRoleMaintainer
+}
+
+func writeProjectForbidden(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Your role on the project does not allow this action.",
+	})
+}
+
+func (c *Client) SharedProjects(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	projects, err := c.store.MemberProjects(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch member projects", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch shared projects.",
+		})
+		return
+	}
+
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, projects)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiProjects,
+	})
+}
+
+func (c *Client) ProjectMembers(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, _, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	owner, err := c.store.UserByID(r.Context(), project.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project owner", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project owner.",
+		})
+		return
+	}
+
+	members, err := c.store.ProjectMembers(r.Context(), project.ProjectID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project members", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project members.",
+		})
+		return
+	}
+
+	apiMembers := make([]ProjectMember, 0, len(members)+1)
+	apiMembers = append(apiMembers, ProjectMember{
+		Uuid:        owner.Uuid,
+		GitHubEmail: owner.GithubEmail,
+		Role:        projectRoleOwner,
+		CreatedAt:   project.CreatedAt,
+	})
+	for _, pm := range members {
+		apiMembers = append(apiMembers, ProjectMemberFromDatabase(pm))
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiMembers,
+	})
+}
+
+// projectMemberFromRequest fetches the member of the request on project. It writes a not
+// found response and returns false when the user is not a member.
+func (c *Client) projectMemberFromRequest(w http.ResponseWriter, r *http.Request, project database.Project) (database.User, database.ProjectMember, bool) {
+	userUuid, err := uuid.FromString(chi.URLParam(r, "user"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.User{}, database.ProjectMember{}, false
+	}
+
+	user, err := c.store.UserByUUID(r.Context(), userUuid)
+	if err == nil {
+		var member database.ProjectMember
+		member, err = c.store.ProjectMember(r.Context(), project.ProjectID, user.UserID)
+		if err == nil {
+			return user, member, true
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.User{}, database.ProjectMember{}, false
+	}
+
+	requestLogger(r, c.logger).Error("could not fetch project member", slog.Any("err", err))
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Could not fetch project member.",
+	})
+	return database.User{}, database.ProjectMember{}, false
+}
+
+func (c *Client) UpdateProjectMember(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageProject(role) {
+		writeProjectForbidden(w)
+		return
+	}
+
+	user, _, ok := c.projectMemberFromRequest(w, r, project)
+	if !ok {
+		return
+	}
+
+	var data struct {
+		Role string `json:"role" validate:"required,oneof=maintainer editor"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	member, err := c.store.UpdateProjectMemberRole(r.Context(), database.UpdateProjectMemberRoleParams{
+		Role:      data.Role,
+		ProjectID: project.ProjectID,
+		UserID:    user.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update project member role", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update project member.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": ProjectMember{
+			Uuid:        user.Uuid,
+			GitHubEmail: user.GithubEmail,
+			Role:        member.Role,
+			CreatedAt:   member.CreatedAt,
+		},
+	})
+}
+
+// DeleteProjectMember removes a member from a project, members can always remove themselves.
+func (c *Client) DeleteProjectMember(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	user, _, ok := c.projectMemberFromRequest(w, r, project)
+	if !ok {
+		return
+	}
+
+	if !canManageProject(role) && user.UserID != authUser.UserID {
+		writeProjectForbidden(w)
+		return
+	}
+
+	if err := c.store.DeleteProjectMember(r.Context(), project.ProjectID, user.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete project member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete project member.",
+		})
+		return
+	}
+}
+
+// TransferProject hands a project over to one of its members, the previous owner stays on
+// as a maintainer.
+func (c *Client) TransferProject(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if role != projectRoleOwner {
+		writeProjectForbidden(w)
+		return
+	}
+
+	var data struct {
+		User string `json:"user" validate:"required,uuid"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	user, err := c.store.UserByUUID(r.Context(), uuid.FromStringOrNil(data.User))
+	if err == nil {
+		_, err = c.store.ProjectMember(r.Context(), project.ProjectID, user.UserID)
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "Projects can only be transferred to their members.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch project member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project member.",
+		})
+		return
+	}
+
+	count, err := c.store.CountUserProjects(r.Context(), user.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user projects count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user projects count.",
+		})
+		return
+	}
+
+	if count >= maxUserProjects {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The member has hit the project limit.",
+		})
+		return
+	}
+
+	err = c.store.WithTx(r.Context(), func(tx store.Store) error {
+		project, err = tx.TransferProject(r.Context(), project.ProjectID, user.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteProjectMember(r.Context(), project.ProjectID, user.UserID); err != nil {
+			return err
+		}
+
+		_, err = tx.InsertProjectMember(r.Context(), database.InsertProjectMemberParams{
+			Role:      projectRoleMaintainer,
+			ProjectID: project.ProjectID,
+			UserID:    authUser.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return webhooks.Dispatch(r.Context(), tx, webhooks.EventProjectUpdated, project.UserID, map[string]any{
+			"project": ProjectFromDatabase(project),
+		})
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not transfer project", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not transfer project.",
+		})
+		return
+	}
+
+	apiProjects, err := projectsFromDatabase(r.Context(), c.store, []database.Project{project})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": apiProjects[0],
+	})
+}
+
+func (c *Client) ProjectInvitations(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageProject(role) {
+		writeProjectForbidden(w)
+		return
+	}
+
+	invitations, err := c.store.ProjectInvitations(r.Context(), project.ProjectID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch project invitations", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project invitations.",
+		})
+		return
+	}
+
+	apiInvitations := make([]ProjectInvitation, len(invitations))
+	for i, pi := range invitations {
+		apiInvitations[i] = ProjectInvitationFromDatabase(pi, project)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiInvitations,
+	})
+}
+
+// StoreProjectInvitation invites a user to a project by the email of their GitHub account,
+// the user does not need to have signed up yet. Users cannot be invited by their GitHub login
+// as logins are not recorded.
+func (c *Client) StoreProjectInvitation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageProject(role) {
+		writeProjectForbidden(w)
+		return
+	}
+
+	var data struct {
+		Email string `json:"email" validate:"required,email,max=191"`
+		Role  string `json:"role" validate:"required,oneof=maintainer editor"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return
+	}
+	email := strings.ToLower(data.Email)
+
+	user, err := c.store.UserByGithubEmail(r.Context(), email)
+	if err == nil {
+		if user.UserID == project.UserID {
+			err = nil
+		} else {
+			_, err = c.store.ProjectMember(r.Context(), project.ProjectID, user.UserID)
+		}
+	}
+	if err == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The user is already a member of the project.",
+		})
+		return
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		requestLogger(r, c.logger).Error("could not fetch project member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project member.",
+		})
+		return
+	}
+
+	_, err = c.store.ProjectInvitationByEmail(r.Context(), project.ProjectID, email)
+	if err == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The user has already been invited to the project.",
+		})
+		return
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		requestLogger(r, c.logger).Error("could not fetch project invitation by email", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project invitation.",
+		})
+		return
+	}
+
+	invitation, err := c.store.InsertProjectInvitation(r.Context(), database.InsertProjectInvitationParams{
+		Email:     email,
+		Role:      data.Role,
+		ProjectID: project.ProjectID,
+		InvitedBy: authUser.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert project invitation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert project invitation into database.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": ProjectInvitationFromDatabase(invitation, project),
+	})
+}
+
+func (c *Client) DeleteProjectInvitation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	project, role, ok := c.authorizeProject(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if !canManageProject(role) {
+		writeProjectForbidden(w)
+		return
+	}
+
+	invitation, ok := c.invitationFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if invitation.ProjectID != project.ProjectID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return
+	}
+
+	if err := c.store.DeleteProjectInvitation(r.Context(), invitation.ProjectInvitationID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete project invitation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete project invitation.",
+		})
+		return
+	}
+}
+
+// invitationFromRequest fetches the invitation of the request. It writes a not found
+// response and returns false when it does not exist.
+func (c *Client) invitationFromRequest(w http.ResponseWriter, r *http.Request) (database.ProjectInvitation, bool) {
+	invitationUuid, err := uuid.FromString(chi.URLParam(r, "invitation"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.ProjectInvitation{}, false
+	}
+
+	invitation, err := c.store.ProjectInvitationByUUID(r.Context(), invitationUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.ProjectInvitation{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch project invitation by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project invitation.",
+		})
+		return database.ProjectInvitation{}, false
+	}
+
+	return invitation, true
+}
+
+// userInvitationFromRequest fetches the invitation of the request sent to the user. It
+// writes a not found response and returns false when it does not exist.
+func (c *Client) userInvitationFromRequest(w http.ResponseWriter, r *http.Request, user database.User) (database.ProjectInvitation, bool) {
+	invitation, ok := c.invitationFromRequest(w, r)
+	if !ok {
+		return database.ProjectInvitation{}, false
+	}
+
+	if invitation.Email != strings.ToLower(user.GithubEmail) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.ProjectInvitation{}, false
+	}
+
+	return invitation, true
+}
+
+func (c *Client) Invitations(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	invitations, err := c.store.UserProjectInvitations(r.Context(), strings.ToLower(authUser.GithubEmail))
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user project invitations", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch invitations.",
+		})
+		return
+	}
+
+	apiInvitations := make([]ProjectInvitation, len(invitations))
+	for i, pi := range invitations {
+		apiInvitations[i] = ProjectInvitation{
+			Uuid:        pi.Uuid,
+			Email:       pi.Email,
+			Role:        pi.Role,
+			ProjectUuid: pi.ProjectUuid,
+			ProjectName: pi.ProjectName,
+			CreatedAt:   pi.CreatedAt,
+		}
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiInvitations,
+	})
+}
+
+func (c *Client) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	invitation, ok := c.userInvitationFromRequest(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	_, err := c.store.ProjectMember(r.Context(), invitation.ProjectID, authUser.UserID)
+	if err == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You are already a member of the project.",
+		})
+		return
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		requestLogger(r, c.logger).Error("could not fetch project member", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project member.",
+		})
+		return
+	}
+
+	var member database.ProjectMember
+	err = c.store.WithTx(r.Context(), func(tx store.Store) error {
+		member, err = tx.InsertProjectMember(r.Context(), database.InsertProjectMemberParams{
+			Role:      invitation.Role,
+			ProjectID: invitation.ProjectID,
+			UserID:    authUser.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.DeleteProjectInvitation(r.Context(), invitation.ProjectInvitationID)
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not accept project invitation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not accept invitation.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": ProjectMember{
+			Uuid:        authUser.Uuid,
+			GitHubEmail: authUser.GithubEmail,
+			Role:        member.Role,
+			CreatedAt:   member.CreatedAt,
+		},
+	})
+}
+
+func (c *Client) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	invitation, ok := c.userInvitationFromRequest(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if err := c.store.DeleteProjectInvitation(r.Context(), invitation.ProjectInvitationID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete project invitation", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not decline invitation.",
+		})
+		return
+	}
+}
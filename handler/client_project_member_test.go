@@ -0,0 +1,152 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/notify"
+)
+
+func TestClientProjectInvitation(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+	path := "/client/projects/" + project.Uuid.String()
+
+	ac := api.login(alice)
+	bc := api.login(bob)
+
+	// Non members cannot see the project, let alone invite to it.
+	bc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodPost, path+"/invitations", map[string]string{"email": "bob@example.com", "role": "maintainer"}, nil)
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/invitations", map[string]string{"email": "bob@example.com", "role": "owner"}, nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/invitations", map[string]string{"email": "alice@example.com", "role": "editor"}, nil)
+
+	var invitation itemResponse[handler.ProjectInvitation]
+	ac.expect(http.StatusOK, http.MethodPost, path+"/invitations", map[string]string{"email": "BOB@example.com", "role": "editor"}, &invitation)
+	if invitation.Item.Email != "bob@example.com" || invitation.Item.ProjectUuid != project.Uuid {
+		t.Fatalf("got %+v, want an invitation of bob to the project", invitation.Item)
+	}
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/invitations", map[string]string{"email": "bob@example.com", "role": "editor"}, nil)
+
+	// Members are found whatever the case of the email of their GitHub account.
+	api.join(project, api.user("Dave@Example.com"), "editor")
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/invitations", map[string]string{"email": "dave@example.com", "role": "editor"}, nil)
+
+	var invitations itemsResponse[handler.ProjectInvitation]
+	bc.expect(http.StatusOK, http.MethodGet, "/client/invitations", nil, &invitations)
+	if len(invitations.Items) != 1 || invitations.Items[0].Uuid != invitation.Item.Uuid || invitations.Items[0].ProjectName != project.Name {
+		t.Fatalf("got %v, want the invitation of bob", invitations.Items)
+	}
+
+	// Invitations can only be answered by their recipient.
+	api.login(api.user("carol@example.com")).expect(http.StatusNotFound, http.MethodPost, "/client/invitations/"+invitation.Item.Uuid.String()+"/accept", nil, nil)
+
+	var member itemResponse[handler.ProjectMember]
+	bc.expect(http.StatusOK, http.MethodPost, "/client/invitations/"+invitation.Item.Uuid.String()+"/accept", nil, &member)
+	if member.Item.Uuid != bob.Uuid || member.Item.Role != "editor" {
+		t.Fatalf("got %+v, want bob as an editor", member.Item)
+	}
+	bc.expect(http.StatusNotFound, http.MethodPost, "/client/invitations/"+invitation.Item.Uuid.String()+"/accept", nil, nil)
+
+	var members itemsResponse[handler.ProjectMember]
+	bc.expect(http.StatusOK, http.MethodGet, path+"/members", nil, &members)
+	if len(members.Items) != 3 || members.Items[0].Uuid != alice.Uuid || members.Items[0].Role != "owner" || members.Items[2].Uuid != bob.Uuid {
+		t.Fatalf("got %v, want alice as owner then dave and bob as editors", members.Items)
+	}
+
+	var shared itemsResponse[handler.Project]
+	bc.expect(http.StatusOK, http.MethodGet, "/client/projects/shared", nil, &shared)
+	if len(shared.Items) != 1 || shared.Items[0].Uuid != project.Uuid {
+		t.Errorf("got %v, want the project shared with bob", shared.Items)
+	}
+}
+
+func TestClientDeclineProjectInvitation(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+
+	var invitation itemResponse[handler.ProjectInvitation]
+	api.login(alice).expect(http.StatusOK, http.MethodPost, "/client/projects/"+project.Uuid.String()+"/invitations", map[string]string{"email": "bob@example.com", "role": "maintainer"}, &invitation)
+
+	bc := api.login(bob)
+	bc.expect(http.StatusOK, http.MethodPost, "/client/invitations/"+invitation.Item.Uuid.String()+"/decline", nil, nil)
+
+	var invitations itemsResponse[handler.ProjectInvitation]
+	bc.expect(http.StatusOK, http.MethodGet, "/client/invitations", nil, &invitations)
+	if len(invitations.Items) != 0 {
+		t.Errorf("got %v, want no invitations left", invitations.Items)
+	}
+	bc.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+project.Uuid.String(), nil, nil)
+}
+
+func TestClientProjectMemberRoles(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	carol := api.user("carol@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+	path := "/client/projects/" + project.Uuid.String()
+
+	ac := api.login(alice)
+	bc := api.join(project, bob, "editor")
+	cc := api.join(project, carol, "maintainer")
+
+	// Editors can edit the project but not manage it.
+	data := map[string]any{"name": "Kedai Pay Project v2", "description": "A payment gateway for hawkers."}
+	bc.expect(http.StatusOK, http.MethodPost, path, data, nil)
+	if got := api.notifications(notify.ListingUpdated); len(got) != 1 || got[0] != alice.UserID {
+		t.Errorf("got notices to %v, want alice told about the edit", got)
+	}
+	bc.expect(http.StatusForbidden, http.MethodGet, path+"/invitations", nil, nil)
+	bc.expect(http.StatusForbidden, http.MethodPost, path+"/members/"+carol.Uuid.String(), map[string]string{"role": "editor"}, nil)
+	bc.expect(http.StatusForbidden, http.MethodDelete, path, nil, nil)
+
+	// Maintainers manage members but cannot delete or transfer the project.
+	var member itemResponse[handler.ProjectMember]
+	cc.expect(http.StatusOK, http.MethodPost, path+"/members/"+bob.Uuid.String(), map[string]string{"role": "maintainer"}, &member)
+	if member.Item.Role != "maintainer" {
+		t.Errorf("got role %s, want maintainer", member.Item.Role)
+	}
+	cc.expect(http.StatusNotFound, http.MethodPost, path+"/members/"+alice.Uuid.String(), map[string]string{"role": "editor"}, nil)
+	cc.expect(http.StatusForbidden, http.MethodDelete, path, nil, nil)
+	cc.expect(http.StatusForbidden, http.MethodPost, path+"/transfer", map[string]string{"user": carol.Uuid.String()}, nil)
+
+	// Members can leave on their own.
+	cc.expect(http.StatusOK, http.MethodDelete, path+"/members/"+carol.Uuid.String(), nil, nil)
+	cc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+
+	ac.expect(http.StatusOK, http.MethodDelete, path+"/members/"+bob.Uuid.String(), nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+}
+
+func TestClientTransferProject(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+	project := api.project(alice, "Kedai Pay Project")
+	path := "/client/projects/" + project.Uuid.String()
+
+	ac := api.login(alice)
+	ac.expect(http.StatusBadRequest, http.MethodPost, path+"/transfer", map[string]string{"user": bob.Uuid.String()}, nil)
+
+	bc := api.join(project, bob, "editor")
+
+	var res itemResponse[handler.Project]
+	ac.expect(http.StatusOK, http.MethodPost, path+"/transfer", map[string]string{"user": bob.Uuid.String()}, &res)
+
+	var members itemsResponse[handler.ProjectMember]
+	ac.expect(http.StatusOK, http.MethodGet, path+"/members", nil, &members)
+	if len(members.Items) != 2 || members.Items[0].Uuid != bob.Uuid || members.Items[1].Uuid != alice.Uuid || members.Items[1].Role != "maintainer" {
+		t.Fatalf("got %v, want bob as owner and alice as maintainer", members.Items)
+	}
+
+	ac.expect(http.StatusForbidden, http.MethodDelete, path, nil, nil)
+	bc.expect(http.StatusOK, http.MethodDelete, path, nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+}
@@ -0,0 +1,195 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/notify"
+	"github.com/gofrs/uuid"
+)
+
+func TestClientProjects(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+
+	first := api.project(alice, "Kedai Pay Project")
+	api.project(bob, "Pasar Hub Project")
+	last := api.project(alice, "Lepak Labs Project")
+
+	c := api.login(alice)
+
+	var res itemsResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodGet, "/client/projects", nil, &res)
+	if len(res.Items) != 2 || res.Pagination.Total != 2 {
+		t.Fatalf("got %d items of %d, want only the projects of the user", len(res.Items), res.Pagination.Total)
+	}
+	if res.Items[0].Uuid != last.Uuid {
+		t.Errorf("got %s first, want the latest project first", res.Items[0].Name)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/client/projects?orderBy=asc&limit=1", nil, &res)
+	if len(res.Items) != 1 || res.Items[0].Uuid != first.Uuid || res.Pagination.Total != 2 {
+		t.Errorf("got %v, want the oldest project alone", res.Items)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/client/projects?page=2", nil, &res)
+	if len(res.Items) != 0 || res.Pagination.CurrentPage != 2 {
+		t.Errorf("got %d items on page %d, want none past the last page", len(res.Items), res.Pagination.CurrentPage)
+	}
+}
+
+func TestClientProject(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.project(alice, "Kedai Pay Project")
+	other := api.project(api.user("bob@example.com"), "Pasar Hub Project")
+
+	c := api.login(alice)
+
+	var res itemResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodGet, "/client/projects/"+own.Uuid.String(), nil, &res)
+	if res.Item.Uuid != own.Uuid {
+		t.Errorf("got %s, want %s", res.Item.Uuid, own.Uuid)
+	}
+
+	// Projects of other users are indistinguishable from projects that do not exist.
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+other.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/projects/not-a-uuid", nil, nil)
+}
+
+func TestClientStoreProject(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	c := api.login(alice)
+
+	data := map[string]any{
+		"name":        "Kedai Pay Project",
+		"description": "Cashless payments for hawkers.",
+		"tags":        []string{"fintech", "golang"},
+		"repository":  "https://github.com/awesome-my/kedai-pay",
+	}
+
+	var res itemResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodPost, "/client/projects", data, &res)
+	if res.Item.Name != data["name"] || !slices.Equal(res.Item.Tags, []string{"fintech", "golang"}) {
+		t.Errorf("got %+v, want the stored project", res.Item)
+	}
+	if !res.Item.Repository.Valid || res.Item.Website.Valid {
+		t.Errorf("got repository %v and website %v, want only a repository", res.Item.Repository, res.Item.Website)
+	}
+
+	stored, err := api.store.ProjectByUUID(context.Background(), res.Item.Uuid)
+	if err != nil {
+		t.Fatalf("could not fetch stored project: %v", err)
+	}
+	if stored.UserID != alice.UserID {
+		t.Errorf("got project of user %d, want %d", stored.UserID, alice.UserID)
+	}
+
+	for name, invalid := range map[string]map[string]any{
+		"short name":    {"name": "Kedai", "description": "Cashless payments for hawkers."},
+		"no name":       {"description": "Cashless payments for hawkers."},
+		"short tag":     {"name": "Kedai Pay Project", "description": "Cashless payments for hawkers.", "tags": []string{"go"}},
+		"too many tags": {"name": "Kedai Pay Project", "description": "Cashless payments for hawkers.", "tags": []string{"tag1", "tag2", "tag3", "tag4", "tag5", "tag6", "tag7"}},
+		"invalid url":   {"name": "Kedai Pay Project", "description": "Cashless payments for hawkers.", "website": "kedai pay"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c.with(t).expect(http.StatusBadRequest, http.MethodPost, "/client/projects", invalid, nil)
+		})
+	}
+}
+
+func TestClientStoreProjectQuota(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	for i := range 19 {
+		api.project(alice, fmt.Sprintf("Project number %02d", i))
+	}
+
+	c := api.login(alice)
+	data := map[string]any{
+		"name":        "Project number 19",
+		"description": "The last project within the quota.",
+	}
+	c.expect(http.StatusOK, http.MethodPost, "/client/projects", data, nil)
+
+	data["name"] = "Project number 20"
+	var res messageResponse
+	c.expect(http.StatusBadRequest, http.MethodPost, "/client/projects", data, &res)
+	if res.Message == "" {
+		t.Error("expected a message")
+	}
+
+	// The quota is per user.
+	api.login(api.user("bob@example.com")).expect(http.StatusOK, http.MethodPost, "/client/projects", data, nil)
+}
+
+func TestClientUpdateProject(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.project(alice, "Kedai Pay Project", "fintech")
+	other := api.project(api.user("bob@example.com"), "Pasar Hub Project")
+
+	c := api.login(alice)
+	data := map[string]any{
+		"name":        "Kedai Pay Project Renamed",
+		"description": "Cashless payments for hawkers and stalls.",
+		"tags":        []string{"e-wallet"},
+		"website":     "https://kedaipay.my",
+	}
+
+	var res itemResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodPost, "/client/projects/"+own.Uuid.String(), data, &res)
+	if res.Item.Uuid != own.Uuid || res.Item.Name != data["name"] || !slices.Equal(res.Item.Tags, []string{"e-wallet"}) {
+		t.Errorf("got %+v, want the updated project", res.Item)
+	}
+	// An omitted repository clears it.
+	if res.Item.Repository.Valid || res.Item.Website.String != "https://kedaipay.my" {
+		t.Errorf("got repository %v and website %v, want only a website", res.Item.Repository, res.Item.Website)
+	}
+
+	if got := api.notifications(notify.ListingUpdated); len(got) != 0 {
+		t.Errorf("got notices to %v, want none for the edits of the owner", got)
+	}
+
+	c.expect(http.StatusBadRequest, http.MethodPost, "/client/projects/"+own.Uuid.String(), map[string]any{"name": "Kedai"}, nil)
+
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/projects/"+other.Uuid.String(), data, nil)
+	unchanged, err := api.store.ProjectByUUID(context.Background(), other.Uuid)
+	if err != nil {
+		t.Fatalf("could not fetch project: %v", err)
+	}
+	if unchanged.Name != other.Name {
+		t.Errorf("the project of another user was renamed to %s", unchanged.Name)
+	}
+
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/projects/"+uuid.Must(uuid.NewV4()).String(), data, nil)
+	c.expect(http.StatusNotFound, http.MethodPost, "/client/projects/not-a-uuid", data, nil)
+}
+
+func TestClientDeleteProject(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	own := api.project(alice, "Kedai Pay Project")
+	other := api.project(api.user("bob@example.com"), "Pasar Hub Project")
+
+	c := api.login(alice)
+
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/projects/"+other.Uuid.String(), nil, nil)
+	if _, err := api.store.ProjectByUUID(context.Background(), other.Uuid); err != nil {
+		t.Errorf("the project of another user was deleted: %v", err)
+	}
+
+	c.expect(http.StatusOK, http.MethodDelete, "/client/projects/"+own.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/client/projects/"+own.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/projects/"+own.Uuid.String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodDelete, "/client/projects/not-a-uuid", nil, nil)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+)
+
+type Session struct {
+	Uuid       uuid.UUID `json:"uuid"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func SessionFromDatabase(s database.UserSession, currentUuid string) Session {
+	return Session{
+		Uuid:       s.Uuid,
+		IPAddress:  s.IpAddress,
+		UserAgent:  s.UserAgent,
+		Current:    s.Uuid.String() == currentUuid,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+}
+
+func (c *Client) Sessions(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	sessions, err := c.store.UserSessions(r.Context(), authUser.UserID, time.Now())
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user sessions.",
+		})
+		return
+	}
+
+	currentUuid := c.sessionManager.GetString(r.Context(), "session:uuid")
+	apiSessions := make([]Session, len(sessions))
+	for i, s := range sessions {
+		apiSessions[i] = SessionFromDatabase(s, currentUuid)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiSessions,
+	})
+}
+
+func (c *Client) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	sessionUuid, err := uuid.FromString(chi.URLParam(r, "session"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return
+	}
+
+	session, err := c.store.UserSessionByUUID(r.Context(), sessionUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch user session by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user session.",
+		})
+		return
+	}
+
+	if session.UserID != authUser.UserID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return
+	}
+
+	if c.sessionManager.GetString(r.Context(), "session:uuid") == session.Uuid.String() {
+		err = c.sessionManager.Destroy(r.Context())
+	} else {
+		err = destroySessionTokens(r.Context(), c.sessionManager, session.Token)
+	}
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not destroy user session", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not revoke user session.",
+		})
+		return
+	}
+
+	if err := c.store.DeleteUserSession(r.Context(), session.Uuid); err != nil {
+		requestLogger(r, c.logger).Error("could not delete user session", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete user session.",
+		})
+		return
+	}
+}
+
+func (c *Client) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	if err := destroyUserSessions(r.Context(), c.store, c.sessionManager, authUser.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not destroy user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not revoke user sessions.",
+		})
+		return
+	}
+
+	if err := c.sessionManager.Destroy(r.Context()); err != nil {
+		requestLogger(r, c.logger).Error("could not destroy request session", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not revoke user sessions.",
+		})
+		return
+	}
+
+	if err := c.store.DeleteUserSessions(r.Context(), authUser.UserID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete user sessions", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete user sessions.",
+		})
+		return
+	}
+}
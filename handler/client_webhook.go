@@ -0,0 +1,485 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+type storeWebhookData struct {
+	Url        string   `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,max=6,unique"`
+	// Site webhooks are notified of the changes of every user, only administrators manage them.
+	Site   bool  `json:"site"`
+	Active *bool `json:"active"`
+}
+
+type Webhook struct {
+	Uuid       uuid.UUID `json:"uuid"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Site       bool      `json:"site"`
+	Active     bool      `json:"active"`
+	// Secret is only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func WebhookFromDatabase(wh database.Webhook) Webhook {
+	return Webhook{
+		Uuid:       wh.Uuid,
+		Url:        wh.Url,
+		EventTypes: wh.EventTypes,
+		Site:       wh.Site,
+		Active:     wh.Active,
+		CreatedAt:  wh.CreatedAt,
+	}
+}
+
+type WebhookDelivery struct {
+	Uuid           uuid.UUID       `json:"uuid"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	ResponseStatus nulls.Int32     `json:"response_status"`
+	Error          nulls.String    `json:"error"`
+	DurationMs     nulls.Int32     `json:"duration_ms"`
+	DeliveredAt    nulls.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	ResponseBody   nulls.String    `json:"response_body,omitempty"`
+}
+
+// WebhookDeliveryFromDatabase converts a delivery, the payload and response body are only
+// included in full deliveries.
+func WebhookDeliveryFromDatabase(wd database.WebhookDelivery, full bool) WebhookDelivery {
+	delivery := WebhookDelivery{
+		Uuid:           wd.Uuid,
+		EventType:      wd.EventType,
+		Status:         wd.Status,
+		Attempts:       wd.Attempts,
+		ResponseStatus: wd.ResponseStatus,
+		Error:          wd.Error,
+		DurationMs:     wd.DurationMs,
+		DeliveredAt:    wd.DeliveredAt,
+		CreatedAt:      wd.CreatedAt,
+	}
+	if full {
+		delivery.Payload = wd.Payload
+		delivery.ResponseBody = wd.ResponseBody
+	}
+
+	return delivery
+}
+
+// authorizeWebhook fetches the webhook of the request. It writes a not found response and
+// returns false when the webhook does not exist or belongs to another user.
+func (c *Client) authorizeWebhook(w http.ResponseWriter, r *http.Request, user database.User) (database.Webhook, bool) {
+	webhookUuid, err := uuid.FromString(chi.URLParam(r, "webhook"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Webhook{}, false
+	}
+
+	webhook, err := c.store.WebhookByUUID(r.Context(), webhookUuid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Webhook{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch webhook by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch webhook.",
+		})
+		return database.Webhook{}, false
+	}
+
+	if webhook.UserID != user.UserID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return database.Webhook{}, false
+	}
+
+	return webhook, true
+}
+
+// authorizeWebhookDelivery fetches the delivery of the request among the deliveries of
+// webhook. It writes a not found response and returns false when there is none.
+func (c *Client) authorizeWebhookDelivery(w http.ResponseWriter, r *http.Request, webhook database.Webhook) (database.WebhookDelivery, bool) {
+	delivery, err := c.store.WebhookDeliveryByUUID(r.Context(), uuid.FromStringOrNil(chi.URLParam(r, "delivery")))
+	if err == nil && delivery.WebhookID != webhook.WebhookID {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.WebhookDelivery{}, false
+		}
+
+		requestLogger(r, c.logger).Error("could not fetch webhook delivery by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch webhook delivery.",
+		})
+		return database.WebhookDelivery{}, false
+	}
+
+	return delivery, true
+}
+
+// decodeWebhookData decodes and validates the webhook of the request body, only
+// administrators may ask for a site webhook. It writes an error response and returns false
+// otherwise.
+func (c *Client) decodeWebhookData(w http.ResponseWriter, r *http.Request, user database.User) (storeWebhookData, bool) {
+	var data storeWebhookData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeWebhookData{}, false
+	}
+
+	if err := c.validator.StructCtx(r.Context(), data); err != nil || !webhooks.ValidEventTypes(data.EventTypes) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The request body is in malformed format.",
+		})
+		return storeWebhookData{}, false
+	}
+
+	if data.Site && !c.config.Account.IsAdmin(user.GithubEmail) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Only administrators can manage site webhooks.",
+		})
+		return storeWebhookData{}, false
+	}
+
+	return data, true
+}
+
+func (c *Client) Webhooks(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	userWebhooks, err := c.store.UserWebhooks(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user webhooks", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user webhooks.",
+		})
+		return
+	}
+
+	apiWebhooks := make([]Webhook, 0, len(userWebhooks))
+	for _, wh := range userWebhooks {
+		apiWebhooks = append(apiWebhooks, WebhookFromDatabase(wh))
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items": apiWebhooks,
+	})
+}
+
+func (c *Client) Webhook(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": WebhookFromDatabase(webhook),
+	})
+}
+
+func (c *Client) StoreWebhook(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	data, ok := c.decodeWebhookData(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	count, err := c.store.CountUserWebhooks(r.Context(), authUser.UserID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch user webhooks count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch user webhooks count.",
+		})
+		return
+	}
+
+	if count >= maxUserWebhooks {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "You have hit the webhook limit, try deleting some unused webhooks.",
+		})
+		return
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		requestLogger(r, c.logger).Error("could not generate webhook secret", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not generate webhook secret.",
+		})
+		return
+	}
+
+	webhook, err := c.store.InsertWebhook(r.Context(), database.InsertWebhookParams{
+		Url:        data.Url,
+		Secret:     hex.EncodeToString(secret),
+		EventTypes: data.EventTypes,
+		Site:       data.Site,
+		Active:     data.Active == nil || *data.Active,
+		UserID:     authUser.UserID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert webhook", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert webhook into database.",
+		})
+		return
+	}
+
+	apiWebhook := WebhookFromDatabase(webhook)
+	apiWebhook.Secret = webhook.Secret
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": apiWebhook,
+	})
+}
+
+func (c *Client) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	data, ok := c.decodeWebhookData(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	active := webhook.Active
+	if data.Active != nil {
+		active = *data.Active
+	}
+
+	webhook, err := c.store.UpdateWebhook(r.Context(), database.UpdateWebhookParams{
+		Url:        data.Url,
+		EventTypes: data.EventTypes,
+		Site:       data.Site,
+		Active:     active,
+		WebhookID:  webhook.WebhookID,
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not update webhook", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update webhook.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": WebhookFromDatabase(webhook),
+	})
+}
+
+func (c *Client) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	if err := c.store.DeleteWebhook(r.Context(), webhook.WebhookID); err != nil {
+		requestLogger(r, c.logger).Error("could not delete webhook", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not delete webhook.",
+		})
+		return
+	}
+}
+
+// PingWebhook delivers a ping to the webhook straight away, answering with the outcome of
+// the delivery, which is not retried.
+func (c *Client) PingWebhook(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	delivery, err := webhooks.InsertDelivery(r.Context(), c.store, webhook, webhooks.EventPing, map[string]any{
+		"webhook": WebhookFromDatabase(webhook),
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not insert webhook delivery", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not insert webhook delivery into database.",
+		})
+		return
+	}
+
+	delivery, err = c.deliverer.Deliver(r.Context(), delivery.WebhookDeliveryID)
+	if err != nil && delivery.Status != "failed" {
+		requestLogger(r, c.logger).Error("could not deliver webhook", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not deliver webhook.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": WebhookDeliveryFromDatabase(delivery, true),
+	})
+}
+
+func (c *Client) WebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	deliveries, err := c.store.WebhookDeliveries(r.Context(), webhook.WebhookID, store.Page{
+		Offset: int32(offset),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch webhook deliveries by limit offset", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch webhook deliveries.",
+		})
+		return
+	}
+
+	total, err := c.store.CountWebhookDeliveries(r.Context(), webhook.WebhookID)
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not fetch webhook deliveries count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch webhook deliveries count.",
+		})
+		return
+	}
+
+	apiDeliveries := make([]WebhookDelivery, 0, len(deliveries))
+	for _, wd := range deliveries {
+		apiDeliveries = append(apiDeliveries, WebhookDeliveryFromDatabase(wd, false))
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      apiDeliveries,
+		"pagination": awesomemy.NewPaginationMeta(page, len(deliveries), int(total)),
+	})
+}
+
+func (c *Client) WebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	delivery, ok := c.authorizeWebhookDelivery(w, r, webhook)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": WebhookDeliveryFromDatabase(delivery, true),
+	})
+}
+
+// RedeliverWebhookDelivery delivers the payload of a past delivery again as a new delivery,
+// which is retried like any other.
+func (c *Client) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	authUser := awesomemy.MustContextValue[database.User](r.Context(), awesomemy.CtxKeyAuthUser)
+
+	webhook, ok := c.authorizeWebhook(w, r, authUser)
+	if !ok {
+		return
+	}
+
+	delivery, ok := c.authorizeWebhookDelivery(w, r, webhook)
+	if !ok {
+		return
+	}
+
+	var payload webhooks.Payload
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		requestLogger(r, c.logger).Error("could not decode webhook delivery payload", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not redeliver webhook delivery.",
+		})
+		return
+	}
+
+	err := c.store.WithTx(r.Context(), func(tx store.Store) error {
+		var err error
+		delivery, err = webhooks.InsertDelivery(r.Context(), tx, webhook, delivery.EventType, payload.Data)
+		if err != nil {
+			return err
+		}
+
+		return webhooks.Enqueue(r.Context(), tx, delivery)
+	})
+	if err != nil {
+		requestLogger(r, c.logger).Error("could not redeliver webhook delivery", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not redeliver webhook delivery.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": WebhookDeliveryFromDatabase(delivery, true),
+	})
+}
@@ -0,0 +1,128 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/webhooks"
+)
+
+func webhookData(url string, eventTypes ...string) map[string]any {
+	return map[string]any{
+		"url":         url,
+		"event_types": eventTypes,
+	}
+}
+
+func TestClientWebhooks(t *testing.T) {
+	api := newTestAPI(t)
+	ac := api.login(api.user("alice@example.com"))
+
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/webhooks", webhookData("ftp://example.com/hook", webhooks.EventProjectCreated), nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/webhooks", webhookData("https://example.com/hook", "project.renamed"), nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/webhooks", webhookData("https://example.com/hook", webhooks.EventPing), nil)
+	ac.expect(http.StatusBadRequest, http.MethodPost, "/client/webhooks", webhookData("https://example.com/hook"), nil)
+
+	site := webhookData("https://example.com/hook", webhooks.EventProjectCreated)
+	site["site"] = true
+	ac.expect(http.StatusForbidden, http.MethodPost, "/client/webhooks", site, nil)
+	api.login(api.user("admin@example.com")).expect(http.StatusOK, http.MethodPost, "/client/webhooks", site, nil)
+
+	var res itemResponse[handler.Webhook]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/webhooks", webhookData("https://example.com/hook", webhooks.EventProjectCreated, webhooks.EventEventDeleted), &res)
+	if len(res.Item.Secret) != 64 || !res.Item.Active || res.Item.Site {
+		t.Fatalf("got %+v, want an active user webhook with its secret", res.Item)
+	}
+	path := "/client/webhooks/" + res.Item.Uuid.String()
+
+	// The secret is only shown once.
+	var list itemsResponse[handler.Webhook]
+	ac.expect(http.StatusOK, http.MethodGet, "/client/webhooks", nil, &list)
+	if len(list.Items) != 1 || list.Items[0].Uuid != res.Item.Uuid || list.Items[0].Secret != "" {
+		t.Fatalf("got %+v, want the webhook of alice without its secret", list.Items)
+	}
+
+	update := webhookData("https://example.com/other", webhooks.EventEventCreated)
+	update["active"] = false
+	res = itemResponse[handler.Webhook]{}
+	ac.expect(http.StatusOK, http.MethodPost, path, update, &res)
+	if res.Item.Url != "https://example.com/other" || res.Item.Active || len(res.Item.EventTypes) != 1 {
+		t.Errorf("got %+v, want the webhook updated and deactivated", res.Item)
+	}
+
+	bc := api.login(api.user("bob@example.com"))
+	bc.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodDelete, path, nil, nil)
+
+	ac.expect(http.StatusOK, http.MethodDelete, path, nil, nil)
+	ac.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+}
+
+func TestClientWebhookDeliveries(t *testing.T) {
+	api := newTestAPI(t)
+	ac := api.login(api.user("alice@example.com"))
+
+	var signed atomic.Bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signed.Store(r.Header.Get(webhooks.SignatureHeader) == webhooks.Sign(r.URL.Query().Get("secret"), body))
+		w.Write([]byte("pong"))
+	}))
+	t.Cleanup(receiver.Close)
+
+	var webhook itemResponse[handler.Webhook]
+	ac.expect(http.StatusOK, http.MethodPost, "/client/webhooks", webhookData(receiver.URL, webhooks.EventProjectCreated), &webhook)
+	path := "/client/webhooks/" + webhook.Item.Uuid.String()
+
+	// Point the webhook at a URL telling the receiver the secret to check the signature with.
+	ac.expect(http.StatusOK, http.MethodPost, path, webhookData(receiver.URL+"?secret="+webhook.Item.Secret, webhooks.EventProjectCreated), nil)
+
+	var ping itemResponse[handler.WebhookDelivery]
+	ac.expect(http.StatusOK, http.MethodPost, path+"/ping", nil, &ping)
+	if ping.Item.Status != "succeeded" || ping.Item.ResponseStatus.Int32 != http.StatusOK || ping.Item.ResponseBody.String != "pong" || !signed.Load() {
+		t.Fatalf("got %+v, want a signed ping answered by the receiver", ping.Item)
+	}
+
+	// Changes are delivered by the job workers, which are not running.
+	ac.expect(http.StatusOK, http.MethodPost, "/client/projects", map[string]any{
+		"name":        "Kedai Pay Project",
+		"description": "A payment gateway for kedai.",
+	}, nil)
+
+	var deliveries itemsResponse[handler.WebhookDelivery]
+	ac.expect(http.StatusOK, http.MethodGet, path+"/deliveries", nil, &deliveries)
+	if len(deliveries.Items) != 2 || deliveries.Pagination.Total != 2 {
+		t.Fatalf("got %+v, want the ping and the project deliveries", deliveries.Items)
+	}
+	created := deliveries.Items[0]
+	if created.EventType != webhooks.EventProjectCreated || created.Status != "pending" || created.Attempts != 0 || created.Payload != nil {
+		t.Fatalf("got %+v, want the pending project delivery first without its payload", created)
+	}
+
+	var delivery itemResponse[handler.WebhookDelivery]
+	ac.expect(http.StatusOK, http.MethodGet, path+"/deliveries/"+created.Uuid.String(), nil, &delivery)
+	var payload struct {
+		Event string `json:"event"`
+		Data  struct {
+			Project handler.Project `json:"project"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(delivery.Item.Payload, &payload); err != nil || payload.Data.Project.Name != "Kedai Pay Project" {
+		t.Fatalf("got %s, %v, want the payload of the project", delivery.Item.Payload, err)
+	}
+
+	var redelivery itemResponse[handler.WebhookDelivery]
+	ac.expect(http.StatusOK, http.MethodPost, path+"/deliveries/"+ping.Item.Uuid.String()+"/redeliver", nil, &redelivery)
+	if redelivery.Item.Uuid == ping.Item.Uuid || redelivery.Item.EventType != webhooks.EventPing || redelivery.Item.Status != "pending" {
+		t.Errorf("got %+v, want a new pending ping", redelivery.Item)
+	}
+
+	bc := api.login(api.user("bob@example.com"))
+	bc.expect(http.StatusNotFound, http.MethodGet, path+"/deliveries", nil, nil)
+	bc.expect(http.StatusNotFound, http.MethodPost, path+"/deliveries/"+created.Uuid.String()+"/redeliver", nil, nil)
+}
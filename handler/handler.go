@@ -2,24 +2,26 @@ package handler
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"slices"
-	"time"
+	"strings"
 
-	"github.com/alexedwards/scs/redisstore"
 	"github.com/alexedwards/scs/v2"
 	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/store"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/httprate"
+	"github.com/gobuffalo/nulls"
 	"github.com/gomodule/redigo/redis"
 	"github.com/google/go-github/v55/github"
 	"golang.org/x/oauth2"
 )
 
-func New(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB) http.Handler {
+// New creates the HTTP handler of the API over the store, the redis pool may be nil when redis
+// is not in use.
+func New(logger *slog.Logger, cfg awesomemy.Config, st store.Store, pool *redis.Pool, sessionStore scs.Store, metrics *Metrics) (http.Handler, error) {
 	sameSite := http.SameSiteLaxMode
 	switch cfg.Authentication.Session.SameSite {
 	case "strict":
@@ -29,12 +31,7 @@ func New(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB) http.Handler {
 	}
 
 	sm := scs.New()
-	sm.Store = redisstore.NewWithPrefix(&redis.Pool{
-		MaxIdle: 10,
-		Dial: func() (redis.Conn, error) {
-			return redis.Dial("tcp", cfg.Redis.ConnnectionString())
-		},
-	}, cfg.Authentication.Session.Prefix)
+	sm.Store = NewTracedStore(sessionStore, cfg.Authentication.Session.Store)
 	sm.Lifetime = cfg.Authentication.Session.Lifetime.Duration
 	sm.Cookie = scs.SessionCookie{
 		Name:     cfg.Authentication.Session.Name,
@@ -52,24 +49,36 @@ func New(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB) http.Handler {
 		})
 	})
 	r.Use(
-		httprate.Limit(
-			50,
-			1*time.Minute,
-			httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusTooManyRequests)
-				json.NewEncoder(w).Encode(map[string]string{
-					"message": "You have hit the rate limit, try again later.",
-				})
-			}),
-		),
-		sm.LoadAndSave,
-		corsMiddleware(cfg),
+		Trace,
+		RequestID,
+		AccessLog(logger),
+		metrics.Instrument,
 	)
-	r.Mount("/public", NewPublic(logger, cfg, db))
-	r.Mount("/auth", NewAuth(logger, cfg, db, sm))
-	r.Mount("/client", NewClient(logger, cfg, db, sm))
 
-	return r
+	if cfg.Http.Metrics.Enabled && cfg.Http.Admin.Port == 0 {
+		r.Handle("/metrics", metrics.Handler())
+	}
+
+	health := NewHealth(logger, st, pool)
+	r.Get("/healthz", health.Liveness)
+	r.Get("/readyz", health.Readiness)
+
+	limiter, err := NewRateLimiter(logger, cfg.Http.RateLimit, pool, sm, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(
+			sm.LoadAndSave,
+			corsMiddleware(cfg),
+		)
+		r.With(limiter.Limit("public", cfg.Http.RateLimit.Public)).Mount("/public", NewPublic(logger, cfg, st))
+		r.With(limiter.Limit("auth", cfg.Http.RateLimit.Auth)).Mount("/auth", NewAuth(logger, cfg, st, sm, metrics))
+		r.With(limiter.Limit("client", cfg.Http.RateLimit.Client)).Mount("/client", NewClient(logger, cfg, st, sm))
+	})
+
+	return r, nil
 }
 
 func corsMiddleware(cfg awesomemy.Config) func(next http.Handler) http.Handler {
@@ -82,7 +91,8 @@ func corsMiddleware(cfg awesomemy.Config) func(next http.Handler) http.Handler {
 
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Authorization, X-Request-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After")
 			w.Header().Set("Access-Control-Max-Age", "7200")
 
 			if r.Method == http.MethodOptions {
@@ -94,9 +104,21 @@ func corsMiddleware(cfg awesomemy.Config) func(next http.Handler) http.Handler {
 	}
 }
 
-func githubOAuth2Email(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token) (string, error) {
-	githubEmails, _, err := github.NewClient(cfg.Client(ctx, token)).
-		WithAuthToken(token.AccessToken).Users.ListEmails(ctx, &github.ListOptions{})
+// githubOAuth2Email fetches the primary email of the GitHub account, apiURL overrides the
+// GitHub API base URL when set.
+func githubOAuth2Email(ctx context.Context, cfg *oauth2.Config, apiURL string, token *oauth2.Token) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "github.oauth2_email")
+	defer func() { endSpan(span, err) }()
+
+	client := github.NewClient(cfg.Client(ctx, token)).WithAuthToken(token.AccessToken)
+	if apiURL != "" {
+		client.BaseURL, err = url.Parse(strings.TrimSuffix(apiURL, "/") + "/")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	githubEmails, _, err := client.Users.ListEmails(ctx, &github.ListOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -110,3 +132,12 @@ func githubOAuth2Email(ctx context.Context, cfg *oauth2.Config, token *oauth2.To
 
 	return primaryEmail, nil
 }
+
+// nullString converts an optional string into a nulls.String, treating the empty string as null.
+func nullString(s string) nulls.String {
+	if s == "" {
+		return nulls.String{}
+	}
+
+	return nulls.NewString(s)
+}
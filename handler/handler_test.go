@@ -0,0 +1,425 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2/memstore"
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+)
+
+// testDB is the database the integration tests run against, it is nil when TEST_DATABASE_URL
+// is not set and the tests run against the in-memory store instead.
+var testDB *sql.DB
+
+// TestMain migrates the database named by TEST_DATABASE_URL, if any, before running the tests.
+// The database is truncated before every test, so it must be a disposable one.
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		return m.Run()
+	}
+
+	db, err := database.Open(dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not open test database: %v\n", err)
+		return 1
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := database.Migrate(context.Background(), db); err != nil {
+		fmt.Fprintf(os.Stderr, "could not migrate test database: %v\n", err)
+		return 1
+	}
+
+	testDB = db
+	return m.Run()
+}
+
+type itemResponse[T any] struct {
+	Item T `json:"item"`
+}
+
+type itemsResponse[T any] struct {
+	Items      []T                      `json:"items"`
+	Pagination awesomemy.PaginationMeta `json:"pagination"`
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+// testAPI serves the API over an empty store, sessions are kept in memory and users log in
+// through the development login.
+type testAPI struct {
+	t      *testing.T
+	server *httptest.Server
+	store  store.Store
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+
+	var st store.Store = store.NewMemory()
+	if testDB != nil {
+		if err := database.New().TruncateAll(context.Background(), testDB); err != nil {
+			t.Fatalf("could not truncate test database: %v", err)
+		}
+		st = store.NewPostgres(testDB)
+	}
+
+	var cfg awesomemy.Config
+	cfg.Authentication.Session.Name = "awesomemy_test"
+	cfg.Authentication.Session.Store = "memory"
+	cfg.Authentication.Session.Lifetime = awesomemy.Duration{Duration: time.Hour}
+	cfg.Authentication.Dev.Enabled = true
+	cfg.Account.DeletionGracePeriod = awesomemy.Duration{Duration: 24 * time.Hour}
+	cfg.Account.AdminEmails = []string{"admin@example.com"}
+	// Webhooks are delivered to test servers listening on the loopback address.
+	cfg.Webhooks.AllowPrivateNetworks = true
+	// The tests make far more requests than a person would, they must never be rate limited.
+	unlimited := awesomemy.RateLimitPolicy{Requests: 1 << 20}
+	cfg.Http.RateLimit.Public = unlimited
+	cfg.Http.RateLimit.Auth = unlimited
+	cfg.Http.RateLimit.Client = unlimited
+
+	logger := slog.New(slog.NewTextHandler(testLogWriter{t}, nil))
+	h, err := handler.New(logger, cfg, st, nil, memstore.NewWithCleanupInterval(0), handler.NewMetrics(logger, st, testDB, nil))
+	if err != nil {
+		t.Fatalf("could not create handler: %v", err)
+	}
+
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	return &testAPI{
+		t:      t,
+		server: server,
+		store:  st,
+	}
+}
+
+// anonymous returns a client without a session.
+func (api *testAPI) anonymous() *testClient {
+	api.t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		api.t.Fatalf("could not create cookie jar: %v", err)
+	}
+
+	return &testClient{
+		t:    api.t,
+		api:  api,
+		http: &http.Client{Jar: jar},
+	}
+}
+
+// login returns a client with a session of user.
+func (api *testAPI) login(user database.User) *testClient {
+	api.t.Helper()
+
+	c := api.anonymous()
+	if status := c.do(http.MethodPost, "/auth/dev/login", map[string]string{"email": user.GithubEmail}, nil); status != http.StatusOK {
+		api.t.Fatalf("could not log in as %s: status %d", user.GithubEmail, status)
+	}
+
+	return c
+}
+
+func (api *testAPI) user(email string) database.User {
+	api.t.Helper()
+
+	user, err := api.store.InsertUser(context.Background(), email)
+	if err != nil {
+		api.t.Fatalf("could not insert user: %v", err)
+	}
+
+	return user
+}
+
+func (api *testAPI) project(user database.User, name string, tags ...string) database.Project {
+	api.t.Helper()
+
+	project, err := api.store.InsertProject(context.Background(), database.InsertProjectParams{
+		Name:        name,
+		Description: "The description of " + name + ".",
+		Tags:        tags,
+		Repository:  nulls.NewString("https://github.com/awesome-my/" + strings.ReplaceAll(strings.ToLower(name), " ", "-")),
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		api.t.Fatalf("could not insert project: %v", err)
+	}
+
+	return project
+}
+
+// join makes user a member of project with role and logs in as them.
+func (api *testAPI) join(project database.Project, user database.User, role string) *testClient {
+	api.t.Helper()
+
+	if _, err := api.store.InsertProjectMember(context.Background(), database.InsertProjectMemberParams{
+		Role:      role,
+		ProjectID: project.ProjectID,
+		UserID:    user.UserID,
+	}); err != nil {
+		api.t.Fatalf("could not insert project member: %v", err)
+	}
+
+	return api.login(user)
+}
+
+// organisation inserts an organisation owned by user, its slug is derived from name.
+func (api *testAPI) organisation(user database.User, name, industry, hqState string) database.Organisation {
+	api.t.Helper()
+
+	organisation, err := api.store.InsertOrganisation(context.Background(), database.InsertOrganisationParams{
+		Name:        name,
+		Slug:        strings.ReplaceAll(strings.ToLower(name), " ", "-"),
+		Description: "The description of " + name + ".",
+		Industry:    industry,
+		HqState:     hqState,
+		FoundedYear: 2020,
+		TeamSize:    "11-50",
+	})
+	if err != nil {
+		api.t.Fatalf("could not insert organisation: %v", err)
+	}
+
+	if _, err := api.store.InsertOrganisationMember(context.Background(), database.InsertOrganisationMemberParams{
+		Role:           "owner",
+		OrganisationID: organisation.OrganisationID,
+		UserID:         user.UserID,
+	}); err != nil {
+		api.t.Fatalf("could not insert organisation member: %v", err)
+	}
+
+	return organisation
+}
+
+func (api *testAPI) event(user database.User, name string, tags ...string) database.Event {
+	api.t.Helper()
+
+	startsAt := time.Now().UTC().Truncate(time.Second).Add(7 * 24 * time.Hour)
+	event, err := api.store.InsertEvent(context.Background(), database.InsertEventParams{
+		Name:        name,
+		Description: "The description of " + name + ".",
+		Tags:        tags,
+		StartsAt:    startsAt,
+		EndsAt:      startsAt.Add(3 * time.Hour),
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		api.t.Fatalf("could not insert event: %v", err)
+	}
+
+	return event
+}
+
+// notifications returns the recipients of the notifications n queued since the previous call,
+// claiming every queued job.
+func (api *testAPI) notifications(n notify.Notification) []int32 {
+	api.t.Helper()
+
+	now := time.Now()
+	jobs, err := api.store.ClaimJobs(context.Background(), now, now, 1, 1000)
+	if err != nil {
+		api.t.Fatalf("could not claim jobs: %v", err)
+	}
+
+	var recipients []int32
+	for _, job := range jobs {
+		var payload notify.SendPayload
+		if job.Kind != string(notify.SendJob) || json.Unmarshal(job.Payload, &payload) != nil || payload.Notification != n {
+			continue
+		}
+		recipients = append(recipients, payload.UserID)
+	}
+
+	return recipients
+}
+
+type testClient struct {
+	t    *testing.T
+	api  *testAPI
+	http *http.Client
+}
+
+// with returns a copy of the client reporting failures to t, for use in subtests.
+func (c *testClient) with(t *testing.T) *testClient {
+	cc := *c
+	cc.t = t
+	return &cc
+}
+
+// do sends a request with body encoded as JSON, when out is not nil the response body is
+// decoded into it. It returns the response status code.
+func (c *testClient) do(method, path string, body, out any) int {
+	c.t.Helper()
+
+	var b []byte
+	var contentType string
+	if body != nil {
+		var err error
+		b, err = json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("could not encode request body: %v", err)
+		}
+		contentType = "application/json"
+	}
+
+	status, _, resBody := c.raw(method, path, contentType, b)
+	if out != nil {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			c.t.Fatalf("could not decode response of %s %s: %v", method, path, err)
+		}
+	}
+
+	return status
+}
+
+// raw sends a request with the given body, which is left out when nil. It returns the status
+// code, headers and body of the response.
+func (c *testClient) raw(method, path, contentType string, body []byte) (int, http.Header, []byte) {
+	c.t.Helper()
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, c.api.server.URL+path, r)
+	if err != nil {
+		c.t.Fatalf("could not create request: %v", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		c.t.Fatalf("could not send request %s %s: %v", method, path, err)
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(res.Body)
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		c.t.Fatalf("could not read response of %s %s: %v", method, path, err)
+	}
+
+	return res.StatusCode, res.Header, resBody
+}
+
+// expect sends a request like do and fails the test unless the response has the given status.
+func (c *testClient) expect(status int, method, path string, body, out any) {
+	c.t.Helper()
+
+	if got := c.do(method, path, body, out); got != status {
+		c.t.Fatalf("%s %s: got status %d, want %d", method, path, got, status)
+	}
+}
+
+// testLogWriter writes the logs of the API to the test log so they show up for failing tests.
+type testLogWriter struct {
+	t *testing.T
+}
+
+func (w testLogWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+func TestHealth(t *testing.T) {
+	api := newTestAPI(t)
+	c := api.anonymous()
+
+	c.expect(http.StatusOK, http.MethodGet, "/healthz", nil, nil)
+	c.expect(http.StatusOK, http.MethodGet, "/readyz", nil, nil)
+}
+
+func TestNotFound(t *testing.T) {
+	api := newTestAPI(t)
+
+	var res messageResponse
+	api.anonymous().expect(http.StatusNotFound, http.MethodGet, "/unknown", nil, &res)
+	if res.Message == "" {
+		t.Error("expected a message")
+	}
+}
+
+func TestDevLogin(t *testing.T) {
+	api := newTestAPI(t)
+	user := api.user("ali@example.com")
+	c := api.anonymous()
+
+	c.expect(http.StatusBadRequest, http.MethodPost, "/auth/dev/login", map[string]string{}, nil)
+	c.expect(http.StatusNotFound, http.MethodPost, "/auth/dev/login", map[string]string{"email": "nobody@example.com"}, nil)
+
+	var res itemResponse[handler.User]
+	c.expect(http.StatusOK, http.MethodPost, "/auth/dev/login", map[string]string{"email": user.GithubEmail}, &res)
+	if res.Item.Uuid != user.Uuid {
+		t.Errorf("got user %s, want %s", res.Item.Uuid, user.Uuid)
+	}
+
+	c.expect(http.StatusOK, http.MethodGet, "/client/account", nil, nil)
+	c.expect(http.StatusOK, http.MethodPost, "/auth/logout", nil, nil)
+	c.expect(http.StatusUnauthorized, http.MethodGet, "/client/account", nil, nil)
+}
+
+func TestClientRequiresAuthentication(t *testing.T) {
+	api := newTestAPI(t)
+	c := api.anonymous()
+
+	for _, route := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/client/account"},
+		{http.MethodDelete, "/client/account"},
+		{http.MethodPost, "/client/account/restore"},
+		{http.MethodGet, "/client/account/export"},
+		{http.MethodGet, "/client/account/sessions"},
+		{http.MethodDelete, "/client/account/sessions"},
+		{http.MethodDelete, "/client/account/sessions/00000000-0000-0000-0000-000000000000"},
+		{http.MethodGet, "/client/export"},
+		{http.MethodPost, "/client/import"},
+		{http.MethodGet, "/client/projects"},
+		{http.MethodPost, "/client/projects"},
+		{http.MethodGet, "/client/projects/00000000-0000-0000-0000-000000000000"},
+		{http.MethodPost, "/client/projects/00000000-0000-0000-0000-000000000000"},
+		{http.MethodDelete, "/client/projects/00000000-0000-0000-0000-000000000000"},
+		{http.MethodGet, "/client/events"},
+		{http.MethodPost, "/client/events"},
+		{http.MethodGet, "/client/events/00000000-0000-0000-0000-000000000000"},
+		{http.MethodPost, "/client/events/00000000-0000-0000-0000-000000000000"},
+		{http.MethodDelete, "/client/events/00000000-0000-0000-0000-000000000000"},
+	} {
+		c.expect(http.StatusUnauthorized, route.method, route.path, nil, nil)
+	}
+}
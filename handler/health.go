@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend/store"
+	"github.com/gomodule/redigo/redis"
+)
+
+// healthCheckTimeout bounds how long a single readiness check may take.
+const healthCheckTimeout = 2 * time.Second
+
+type Health struct {
+	logger *slog.Logger
+	store  store.Store
+	pool   *redis.Pool
+}
+
+// NewHealth creates the liveness and readiness handlers, the redis pool may be nil when
+// redis is not in use.
+func NewHealth(logger *slog.Logger, st store.Store, pool *redis.Pool) *Health {
+	return &Health{
+		logger: logger,
+		store:  st,
+		pool:   pool,
+	}
+}
+
+func (h *Health) Liveness(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"status": "ok",
+	})
+}
+
+func (h *Health) Readiness(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]func(ctx context.Context) error{
+		"postgres": h.store.Ping,
+	}
+	if h.pool != nil {
+		checks["redis"] = func(ctx context.Context) error {
+			return PingRedis(ctx, h.pool)
+		}
+	}
+
+	status := "ok"
+	results := make(map[string]string, len(checks))
+	for name, check := range checks {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		err := check(ctx)
+		cancel()
+
+		if err != nil {
+			requestLogger(r, h.logger).Error("readiness check failed", slog.String("check", name), slog.Any("err", err))
+			status = "unavailable"
+			results[name] = "unavailable"
+			continue
+		}
+
+		results[name] = "ok"
+	}
+
+	if status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"status": status,
+		"checks": results,
+	})
+}
+
+// PingRedis checks that a connection can be taken from the pool and answers a PING.
+func PingRedis(ctx context.Context, pool *redis.Pool) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(conn redis.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	_, err = redis.DoContext(conn, ctx, "PING")
+
+	return err
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxRequestIDLength bounds the length of an incoming X-Request-ID header that is honoured.
+const maxRequestIDLength = 128
+
+// requestLogEntry holds the details of a request that are only known deeper in the
+// handler chain, such as the authenticated user, for the access log.
+type requestLogEntry struct {
+	userUuid string
+}
+
+type ctxKeyRequestLogEntry struct{}
+
+// RequestID assigns an ID to every request, honouring a valid incoming X-Request-ID header,
+// and echoes it in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-ID")
+		if !validRequestID(requestID) {
+			requestID = uuid.Must(uuid.NewV4()).String()
+		}
+
+		w.Header().Set("X-Request-ID", requestID)
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("request.id", requestID))
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), awesomemy.CtxKeyRequestID, requestID)))
+	})
+}
+
+// AccessLog stores a request-scoped logger carrying the request ID in the context and logs
+// every request once it has been served.
+func AccessLog(logger *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestLogger := logger
+			if requestID, ok := r.Context().Value(awesomemy.CtxKeyRequestID).(string); ok {
+				requestLogger = logger.With(slog.String("request_id", requestID))
+			}
+
+			entry := &requestLogEntry{}
+			ctx := context.WithValue(r.Context(), awesomemy.CtxKeyLogger, requestLogger)
+			ctx = context.WithValue(ctx, ctxKeyRequestLogEntry{}, entry)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			requestLogger.Info("http request",
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("user_uuid", entry.userUuid),
+			)
+		})
+	}
+}
+
+// requestLogger returns the request-scoped logger stored by AccessLog, or the fallback logger
+// when the request did not pass through it.
+func requestLogger(r *http.Request, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := r.Context().Value(awesomemy.CtxKeyLogger).(*slog.Logger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// withRequestUser records the authenticated user in the access log and adds it to the
+// request-scoped logger.
+func withRequestUser(r *http.Request, fallback *slog.Logger, userUuid string) context.Context {
+	if entry, ok := r.Context().Value(ctxKeyRequestLogEntry{}).(*requestLogEntry); ok {
+		entry.userUuid = userUuid
+	}
+
+	logger := requestLogger(r, fallback).With(slog.String("user_uuid", userUuid))
+
+	return context.WithValue(r.Context(), awesomemy.CtxKeyLogger, logger)
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
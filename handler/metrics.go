@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "awesomemy"
+
+// metricsQueryTimeout bounds how long the business gauges may query the database.
+const metricsQueryTimeout = 2 * time.Second
+
+// metricsCountInterval is how long the business gauges reuse a count, so that scrapes put
+// no more than a query per gauge and interval on the database.
+const metricsCountInterval = time.Minute
+
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	rateLimited     prometheus.Counter
+	oauth2Logins    *prometheus.CounterVec
+}
+
+// NewMetrics creates and registers the collectors of the API, whose business gauges count the
+// records of the store. The database of the connection pool gauges may be nil when the store
+// is not Postgres, and the redis pool when redis is not in use.
+func NewMetrics(logger *slog.Logger, st store.Store, db *sql.DB, pool *redis.Pool) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of HTTP requests by route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		rateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_rate_limited_total",
+			Help:      "Total number of HTTP requests rejected by the rate limiter.",
+		}),
+		oauth2Logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "oauth2_logins_total",
+			Help:      "Total number of OAuth2 login callbacks by provider and outcome.",
+		}, []string{"provider", "outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.rateLimited,
+		m.oauth2Logins,
+	)
+
+	if db != nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+	}
+
+	if pool != nil {
+		m.registry.MustRegister(
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "redis_pool_active_connections",
+				Help:      "Number of connections in the redis pool, both idle and in use.",
+			}, func() float64 {
+				return float64(pool.Stats().ActiveCount)
+			}),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "redis_pool_idle_connections",
+				Help:      "Number of idle connections in the redis pool.",
+			}, func() float64 {
+				return float64(pool.Stats().IdleCount)
+			}),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "redis_pool_wait_total",
+				Help:      "Total number of connections waited for in the redis pool.",
+			}, func() float64 {
+				return float64(pool.Stats().WaitCount)
+			}),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "redis_pool_wait_seconds_total",
+				Help:      "Total time spent waiting for connections in the redis pool.",
+			}, func() float64 {
+				return pool.Stats().WaitDuration.Seconds()
+			}),
+		)
+	}
+
+	for name, count := range map[string]func(ctx context.Context) (int64, error){
+		"users": st.CountUsers,
+		"projects": func(ctx context.Context) (int64, error) {
+			return st.CountProjects(ctx, nil)
+		},
+		"events": func(ctx context.Context) (int64, error) {
+			return st.CountEvents(ctx, nil)
+		},
+	} {
+		cached := &cachedCount{}
+		m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      name,
+			Help:      "Total number of " + name + ".",
+		}, func() float64 {
+			return cached.get(func() (int64, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), metricsQueryTimeout)
+				defer cancel()
+
+				total, err := count(ctx)
+				if err != nil {
+					logger.Error("could not count "+name+" for metrics", slog.Any("err", err))
+				}
+
+				return total, err
+			})
+		}))
+	}
+
+	return m
+}
+
+// cachedCount is a count refreshed at most once every metricsCountInterval.
+type cachedCount struct {
+	mu        sync.Mutex
+	value     float64
+	updatedAt time.Time
+}
+
+// get returns the cached count, refreshing it with count when it is stale. The stale count
+// is kept when count fails.
+func (cc *cachedCount) get(count func() (int64, error)) float64 {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if time.Since(cc.updatedAt) < metricsCountInterval {
+		return cc.value
+	}
+
+	if total, err := count(); err == nil {
+		cc.value = float64(total)
+	}
+	// Failures are retried after the interval too, so a struggling database is not hammered.
+	cc.updatedAt = time.Now()
+
+	return cc.value
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Instrument records the count and duration of requests labelled by their chi route pattern.
+func (m *Metrics) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		labels := prometheus.Labels{
+			"method": r.Method,
+			"route":  route,
+			"status": strconv.Itoa(status),
+		}
+		m.requests.With(labels).Inc()
+		m.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RateLimited records a request rejected by the rate limiter.
+func (m *Metrics) RateLimited() {
+	m.rateLimited.Inc()
+}
+
+// OAuth2Login records the outcome of an OAuth2 login callback.
+func (m *Metrics) OAuth2Login(provider, outcome string) {
+	m.oauth2Logins.WithLabelValues(provider, outcome).Inc()
+}
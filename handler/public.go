@@ -1,28 +1,25 @@
 package handler
 
 import (
-	"database/sql"
 	"log/slog"
 	"net/http"
 
 	"github.com/awesome-my/backend"
-	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
 	"github.com/go-chi/chi/v5"
 )
 
 type Public struct {
-	logger   *slog.Logger
-	config   awesomemy.Config
-	database *sql.DB
-	queries  *database.Queries
+	logger *slog.Logger
+	config awesomemy.Config
+	store  store.Store
 }
 
-func NewPublic(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB) http.Handler {
+func NewPublic(logger *slog.Logger, cfg awesomemy.Config, st store.Store) http.Handler {
 	p := &Public{
-		logger:   logger,
-		config:   cfg,
-		database: db,
-		queries:  database.New(),
+		logger: logger,
+		config: cfg,
+		store:  st,
 	}
 
 	r := chi.NewRouter()
@@ -34,6 +31,16 @@ func NewPublic(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB) http.Handl
 		r.Get("/", p.Events)
 		r.Get("/{event}", p.Event)
 	})
+	r.Route("/organisations", func(r chi.Router) {
+		r.Get("/", p.Organisations)
+		r.Route("/{organisation}", func(r chi.Router) {
+			r.Get("/", p.Organisation)
+			r.Get("/projects", p.OrganisationProjects)
+			r.Get("/events", p.OrganisationEvents)
+		})
+	})
+	r.Get("/collections/{collection}", p.Collection)
+	r.Post("/notifications/unsubscribe/{token}", p.Unsubscribe)
 
 	return r
 }
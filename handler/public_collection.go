@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+)
+
+// Collection shares a public collection along with its items, private collections are not
+// found.
+func (p *Public) Collection(w http.ResponseWriter, r *http.Request) {
+	collectionUuid, err := uuid.FromString(chi.URLParam(r, "collection"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The resource you are looking for could not be found.",
+		})
+		return
+	}
+
+	collection, err := p.store.CollectionByUUID(r.Context(), collectionUuid)
+	if err == nil && !collection.Public {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, p.logger).Error("could not fetch collection by uuid", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection.",
+		})
+		return
+	}
+
+	apiCollection, err := collectionWithItems(r.Context(), p.store, collection)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch collection items", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch collection items.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": apiCollection,
+	})
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
@@ -48,43 +48,13 @@ func (p *Public) Events(w http.ResponseWriter, r *http.Request) {
 		tags = strings.Split(r.URL.Query().Get("tags"), ",")
 	}
 
-	orderBy := "desc"
-	if r.URL.Query().Get("orderBy") == "asc" {
-		orderBy = "asc"
-	}
-
-	var err error
-	var events []database.Event
-	switch orderBy {
-	case "asc":
-		if len(tags) > 0 {
-			events, err = p.queries.EventsByTagsAscOffsetLimit(r.Context(), p.database, database.EventsByTagsAscOffsetLimitParams{
-				Tags:   tags,
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		} else {
-			events, err = p.queries.EventsByAscOffsetLimit(r.Context(), p.database, database.EventsByAscOffsetLimitParams{
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		}
-	case "desc":
-		if len(tags) > 0 {
-			events, err = p.queries.EventsByTagsDescOffsetLimit(r.Context(), p.database, database.EventsByTagsDescOffsetLimitParams{
-				Tags:   tags,
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		} else {
-			events, err = p.queries.EventsByDescOffsetLimit(r.Context(), p.database, database.EventsByDescOffsetLimitParams{
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		}
-	}
+	events, err := p.store.Events(r.Context(), tags, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
 	if err != nil {
-		p.logger.Error("could not fetch events by limit offset", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch events by limit offset", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch events.",
@@ -92,14 +62,9 @@ func (p *Public) Events(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var total int64
-	if len(tags) > 0 {
-		total, err = p.queries.CountEventsByTags(r.Context(), p.database, tags)
-	} else {
-		total, err = p.queries.CountEvents(r.Context(), p.database)
-	}
+	total, err := p.store.CountEvents(r.Context(), tags)
 	if err != nil {
-		p.logger.Error("could not fetch events count", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch events count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch events count.",
@@ -128,9 +93,9 @@ func (p *Public) Event(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event, err := p.queries.EventByUUID(r.Context(), p.database, eventUuid)
+	event, err := p.store.EventByUUID(r.Context(), eventUuid)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, store.ErrNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(map[string]string{
 				"message": "The resource you are looking for could not be found.",
@@ -138,7 +103,7 @@ func (p *Public) Event(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		p.logger.Error("could not fetch event by uuid", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch event by uuid", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch event.",
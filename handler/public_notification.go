@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+)
+
+// Unsubscribe turns off the notifications of the category query parameter, or every
+// notification when it is omitted, for the user of the unsubscribe token of the emails. It
+// answers the one-click unsubscribe posts of mail clients too.
+func (p *Public) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+	if category != "" && category != notify.CategoryListingCreated && category != notify.CategoryListingChanges {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "The notification category is invalid.",
+		})
+		return
+	}
+
+	prefs, err := p.store.NotificationPreferencesByUnsubscribeToken(r.Context(), uuid.FromStringOrNil(chi.URLParam(r, "token")))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return
+		}
+
+		requestLogger(r, p.logger).Error("could not fetch notification preferences by unsubscribe token", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch notification preferences.",
+		})
+		return
+	}
+
+	params := database.UpdateNotificationPreferencesParams{
+		UserID:         prefs.UserID,
+		ListingCreated: prefs.ListingCreated && category == notify.CategoryListingChanges,
+		ListingChanges: prefs.ListingChanges && category == notify.CategoryListingCreated,
+	}
+	prefs, err = p.store.UpdateNotificationPreferences(r.Context(), params)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not update notification preferences", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not update notification preferences.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": NotificationPreferencesFromDatabase(prefs),
+	})
+}
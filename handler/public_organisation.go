@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+type Organisation struct {
+	Uuid        uuid.UUID    `json:"uuid"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	Logo        nulls.String `json:"logo"`
+	Description string       `json:"description"`
+	Website     nulls.String `json:"website"`
+	Industry    string       `json:"industry"`
+	HQState     string       `json:"hq_state"`
+	FoundedYear int32        `json:"founded_year"`
+	TeamSize    string       `json:"team_size"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+func OrganisationFromDatabase(o database.Organisation) Organisation {
+	return Organisation{
+		Uuid:        o.Uuid,
+		Name:        o.Name,
+		Slug:        o.Slug,
+		Logo:        o.Logo,
+		Description: o.Description,
+		Website:     o.Website,
+		Industry:    o.Industry,
+		HQState:     o.HqState,
+		FoundedYear: o.FoundedYear,
+		TeamSize:    o.TeamSize,
+		CreatedAt:   o.CreatedAt,
+	}
+}
+
+func (p *Public) Organisations(w http.ResponseWriter, r *http.Request) {
+	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
+	filter := store.OrganisationFilter{
+		Industry: r.URL.Query().Get("industry"),
+		HqState:  r.URL.Query().Get("hq_state"),
+	}
+
+	organisations, err := p.store.Organisations(r.Context(), filter, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch organisations by limit offset", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisations.",
+		})
+		return
+	}
+
+	total, err := p.store.CountOrganisations(r.Context(), filter)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch organisations count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisations count.",
+		})
+		return
+	}
+
+	apiOrganisations := make([]Organisation, len(organisations))
+	for i, o := range organisations {
+		apiOrganisations[i] = OrganisationFromDatabase(o)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      apiOrganisations,
+		"pagination": awesomemy.NewPaginationMeta(page, len(organisations), int(total)),
+	})
+}
+
+// organisationFromRequest fetches the organisation of the request by its slug. It writes a
+// not found response and returns false when it does not exist.
+func (p *Public) organisationFromRequest(w http.ResponseWriter, r *http.Request) (database.Organisation, bool) {
+	organisation, err := p.store.OrganisationBySlug(r.Context(), chi.URLParam(r, "organisation"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "The resource you are looking for could not be found.",
+			})
+			return database.Organisation{}, false
+		}
+
+		requestLogger(r, p.logger).Error("could not fetch organisation by slug", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation.",
+		})
+		return database.Organisation{}, false
+	}
+
+	return organisation, true
+}
+
+func (p *Public) Organisation(w http.ResponseWriter, r *http.Request) {
+	organisation, ok := p.organisationFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"item": OrganisationFromDatabase(organisation),
+	})
+}
+
+func (p *Public) OrganisationProjects(w http.ResponseWriter, r *http.Request) {
+	organisation, ok := p.organisationFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
+	projects, err := p.store.OrganisationProjects(r.Context(), organisation.OrganisationID, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch organisation projects by limit offset", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation projects.",
+		})
+		return
+	}
+
+	total, err := p.store.CountOrganisationProjects(r.Context(), organisation.OrganisationID)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch organisation projects count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation projects count.",
+		})
+		return
+	}
+
+	apiProjects, err := projectsFromDatabase(r.Context(), p.store, projects)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      apiProjects,
+		"pagination": awesomemy.NewPaginationMeta(page, len(projects), int(total)),
+	})
+}
+
+// OrganisationEvents lists the events of an organisation that have not ended yet, soonest
+// first.
+func (p *Public) OrganisationEvents(w http.ResponseWriter, r *http.Request) {
+	organisation, ok := p.organisationFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
+	events, err := p.store.UpcomingOrganisationEvents(r.Context(), organisation.OrganisationID, now, store.Page{
+		Offset: int32(offset),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch upcoming organisation events by limit offset", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation events.",
+		})
+		return
+	}
+
+	total, err := p.store.CountUpcomingOrganisationEvents(r.Context(), organisation.OrganisationID, now)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch upcoming organisation events count", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch organisation events count.",
+		})
+		return
+	}
+
+	apiEvents := make([]Event, len(events))
+	for i, e := range events {
+		apiEvents[i] = EventFromDatabase(e)
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":      apiEvents,
+		"pagination": awesomemy.NewPaginationMeta(page, len(events), int(total)),
+	})
+}
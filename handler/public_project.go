@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"database/sql"
+	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -11,6 +11,7 @@ import (
 
 	"github.com/awesome-my/backend"
 	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/gobuffalo/nulls"
 	"github.com/gofrs/uuid"
@@ -24,6 +25,8 @@ type Project struct {
 	Repository  nulls.String `json:"repository"`
 	Website     nulls.String `json:"website"`
 	CreatedAt   time.Time    `json:"created_at"`
+	// RepositoryStats is null until the stats of a GitHub repository were fetched.
+	RepositoryStats *RepositoryStats `json:"repository_stats"`
 }
 
 func ProjectFromDatabase(p database.Project) Project {
@@ -38,6 +41,62 @@ func ProjectFromDatabase(p database.Project) Project {
 	}
 }
 
+// RepositoryStats are the stats of the GitHub repository of a project as of FetchedAt.
+type RepositoryStats struct {
+	Stars     int32        `json:"stars"`
+	Forks     int32        `json:"forks"`
+	Language  nulls.String `json:"language"`
+	Topics    []string     `json:"topics"`
+	License   nulls.String `json:"license"`
+	PushedAt  nulls.Time   `json:"pushed_at"`
+	FetchedAt time.Time    `json:"fetched_at"`
+}
+
+func RepositoryStatsFromDatabase(rs database.ProjectRepositoryStat) RepositoryStats {
+	return RepositoryStats{
+		Stars:     rs.Stars,
+		Forks:     rs.Forks,
+		Language:  rs.Language,
+		Topics:    rs.Topics,
+		License:   rs.License,
+		PushedAt:  rs.PushedAt,
+		FetchedAt: rs.FetchedAt,
+	}
+}
+
+// projectsFromDatabase converts projects along with the stats of their GitHub repository,
+// when they were fetched.
+func projectsFromDatabase(ctx context.Context, st store.RepositoryStatsStore, projects []database.Project) ([]Project, error) {
+	apiProjects := make([]Project, len(projects))
+	if len(projects) == 0 {
+		return apiProjects, nil
+	}
+
+	projectIDs := make([]int32, len(projects))
+	for i, p := range projects {
+		projectIDs[i] = p.ProjectID
+	}
+
+	stats, err := st.ProjectRepositoryStats(ctx, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	statsByProject := make(map[int32]RepositoryStats, len(stats))
+	for _, rs := range stats {
+		statsByProject[rs.ProjectID] = RepositoryStatsFromDatabase(rs)
+	}
+
+	for i, p := range projects {
+		apiProjects[i] = ProjectFromDatabase(p)
+		if rs, ok := statsByProject[p.ProjectID]; ok {
+			apiProjects[i].RepositoryStats = &rs
+		}
+	}
+
+	return apiProjects, nil
+}
+
 func (p *Public) Projects(w http.ResponseWriter, r *http.Request) {
 	page, limit, offset := awesomemy.PageLimitOffsetFromRequest(r)
 
@@ -46,43 +105,13 @@ func (p *Public) Projects(w http.ResponseWriter, r *http.Request) {
 		tags = strings.Split(r.URL.Query().Get("tags"), ",")
 	}
 
-	orderBy := "desc"
-	if r.URL.Query().Get("orderBy") == "asc" {
-		orderBy = "asc"
-	}
-
-	var err error
-	var projects []database.Project
-	switch orderBy {
-	case "asc":
-		if len(tags) > 0 {
-			projects, err = p.queries.ProjectsByTagsAscOffsetLimit(r.Context(), p.database, database.ProjectsByTagsAscOffsetLimitParams{
-				Tags:   tags,
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		} else {
-			projects, err = p.queries.ProjectsByAscOffsetLimit(r.Context(), p.database, database.ProjectsByAscOffsetLimitParams{
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		}
-	case "desc":
-		if len(tags) > 0 {
-			projects, err = p.queries.ProjectsByTagsDescOffsetLimit(r.Context(), p.database, database.ProjectsByTagsDescOffsetLimitParams{
-				Tags:   tags,
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		} else {
-			projects, err = p.queries.ProjectsByDescOffsetLimit(r.Context(), p.database, database.ProjectsByDescOffsetLimitParams{
-				Offset: int32(offset),
-				Limit:  int32(limit),
-			})
-		}
-	}
+	projects, err := p.store.Projects(r.Context(), tags, store.Page{
+		Ascending: r.URL.Query().Get("orderBy") == "asc",
+		Offset:    int32(offset),
+		Limit:     int32(limit),
+	})
 	if err != nil {
-		p.logger.Error("could not fetch projects by limit offset", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch projects by limit offset", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch projects.",
@@ -90,14 +119,9 @@ func (p *Public) Projects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var total int64
-	if len(tags) > 0 {
-		total, err = p.queries.CountProjectsByTags(r.Context(), p.database, tags)
-	} else {
-		total, err = p.queries.CountProjects(r.Context(), p.database)
-	}
+	total, err := p.store.CountProjects(r.Context(), tags)
 	if err != nil {
-		p.logger.Error("could not fetch projects count", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch projects count", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch projects count.",
@@ -105,9 +129,14 @@ func (p *Public) Projects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apiProjects := make([]Project, len(projects))
-	for i, p := range projects {
-		apiProjects[i] = ProjectFromDatabase(p)
+	apiProjects, err := projectsFromDatabase(r.Context(), p.store, projects)
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]any{
@@ -126,9 +155,9 @@ func (p *Public) Project(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	project, err := p.queries.ProjectByUUID(r.Context(), p.database, projectUuid)
+	project, err := p.store.ProjectByUUID(r.Context(), projectUuid)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, store.ErrNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			json.NewEncoder(w).Encode(map[string]string{
 				"message": "The resource you are looking for could not be found.",
@@ -136,7 +165,7 @@ func (p *Public) Project(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		p.logger.Error("could not fetch project by uuid", slog.Any("err", err))
+		requestLogger(r, p.logger).Error("could not fetch project by uuid", slog.Any("err", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
 			"message": "Could not fetch project.",
@@ -144,7 +173,17 @@ func (p *Public) Project(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	apiProjects, err := projectsFromDatabase(r.Context(), p.store, []database.Project{project})
+	if err != nil {
+		requestLogger(r, p.logger).Error("could not fetch project repository stats", slog.Any("err", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Could not fetch project repository stats.",
+		})
+		return
+	}
+
 	json.NewEncoder(w).Encode(map[string]any{
-		"item": ProjectFromDatabase(project),
+		"item": apiProjects[0],
 	})
 }
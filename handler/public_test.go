@@ -0,0 +1,284 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/handler"
+	"github.com/awesome-my/backend/store"
+	"github.com/gofrs/uuid"
+)
+
+func TestPublicProjects(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+
+	first := api.project(alice, "Kedai Pay Project", "fintech", "golang")
+	api.project(bob, "Pasar Hub Project", "agritech")
+	last := api.project(alice, "Lepak Labs Project", "golang")
+
+	c := api.anonymous()
+
+	var res itemsResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects", nil, &res)
+	if len(res.Items) != 3 || res.Pagination.Total != 3 || res.Pagination.Count != 3 {
+		t.Fatalf("got %d items of %d, want the projects of every user", len(res.Items), res.Pagination.Total)
+	}
+	if res.Items[0].Uuid != last.Uuid {
+		t.Errorf("got %s first, want the latest project first", res.Items[0].Name)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects?orderBy=asc", nil, &res)
+	if len(res.Items) != 3 || res.Items[0].Uuid != first.Uuid {
+		t.Errorf("got %v, want the oldest project first", res.Items)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects?tags=golang", nil, &res)
+	if len(res.Items) != 2 || res.Pagination.Total != 2 {
+		t.Errorf("got %d items of %d, want the 2 projects tagged golang", len(res.Items), res.Pagination.Total)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects?tags=agritech,fintech&orderBy=asc", nil, &res)
+	if len(res.Items) != 2 || res.Items[0].Uuid != first.Uuid {
+		t.Errorf("got %v, want the projects having any of the tags, oldest first", res.Items)
+	}
+
+	res = itemsResponse[handler.Project]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects?tags=unknown", nil, &res)
+	if res.Items == nil || len(res.Items) != 0 || res.Pagination.Total != 0 {
+		t.Errorf("got %v, want an empty list", res.Items)
+	}
+}
+
+func TestPublicProjectsPagination(t *testing.T) {
+	api := newTestAPI(t)
+	user := api.user("alice@example.com")
+	for i := range 25 {
+		api.project(user, fmt.Sprintf("Project number %02d", i))
+	}
+
+	c := api.anonymous()
+	for _, tc := range []struct {
+		query       string
+		currentPage int
+		count       int
+	}{
+		{"", 1, 20},
+		{"?page=0", 1, 20},
+		{"?page=-1", 1, 20},
+		{"?page=abc", 1, 20},
+		{"?limit=5", 1, 5},
+		{"?limit=0", 1, 20},
+		{"?limit=100", 1, 20},
+		{"?page=3&limit=10", 3, 5},
+		{"?page=4&limit=10", 4, 0},
+		{"?page=1000", 1000, 0},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			var res itemsResponse[handler.Project]
+			c.with(t).expect(http.StatusOK, http.MethodGet, "/public/projects"+tc.query, nil, &res)
+
+			if res.Pagination.CurrentPage != tc.currentPage {
+				t.Errorf("got current page %d, want %d", res.Pagination.CurrentPage, tc.currentPage)
+			}
+			if len(res.Items) != tc.count || res.Pagination.Count != tc.count {
+				t.Errorf("got %d items, want %d", len(res.Items), tc.count)
+			}
+			if res.Pagination.Total != 25 || res.Pagination.TotalPages != 3 {
+				t.Errorf("got %d items on %d pages, want 25 items on 3 pages", res.Pagination.Total, res.Pagination.TotalPages)
+			}
+		})
+	}
+}
+
+func TestPublicProject(t *testing.T) {
+	api := newTestAPI(t)
+	project := api.project(api.user("alice@example.com"), "Kedai Pay Project", "fintech")
+	c := api.anonymous()
+
+	var res itemResponse[handler.Project]
+	c.expect(http.StatusOK, http.MethodGet, "/public/projects/"+project.Uuid.String(), nil, &res)
+	if res.Item.Uuid != project.Uuid || res.Item.Name != project.Name || res.Item.Repository != project.Repository {
+		t.Errorf("got %+v, want %s", res.Item, project.Name)
+	}
+
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/projects/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/projects/not-a-uuid", nil, nil)
+}
+
+func TestPublicProjectRepositoryStats(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	enriched := api.project(alice, "Kedai Pay Project", "fintech")
+	api.project(alice, "Pasar Hub Project", "agritech")
+
+	if _, err := api.store.UpsertProjectRepositoryStats(context.Background(), database.UpsertProjectRepositoryStatsParams{
+		ProjectID:  enriched.ProjectID,
+		Repository: enriched.Repository.String,
+		Found:      true,
+		Stars:      42,
+		Forks:      7,
+		Topics:     []string{"fintech"},
+		FetchedAt:  time.Now(),
+	}); err != nil {
+		t.Fatalf("could not upsert project repository stats: %v", err)
+	}
+
+	var res itemsResponse[handler.Project]
+	api.anonymous().expect(http.StatusOK, http.MethodGet, "/public/projects?orderBy=asc", nil, &res)
+	if len(res.Items) != 2 {
+		t.Fatalf("got %d projects, want 2", len(res.Items))
+	}
+	if stats := res.Items[0].RepositoryStats; stats == nil || stats.Stars != 42 || stats.Forks != 7 {
+		t.Errorf("got %+v, want the stats of %s", stats, enriched.Name)
+	}
+	if res.Items[1].RepositoryStats != nil {
+		t.Errorf("got %+v, want no stats for a project never enriched", res.Items[1].RepositoryStats)
+	}
+}
+
+func TestPublicEvents(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	bob := api.user("bob@example.com")
+
+	first := api.event(alice, "Kuala Lumpur Golang Meetup", "golang")
+	api.event(bob, "Penang Fintech Summit", "fintech")
+	last := api.event(alice, "Cyberjaya Cloud Native Workshop", "golang", "cloud")
+
+	c := api.anonymous()
+
+	var res itemsResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodGet, "/public/events", nil, &res)
+	if len(res.Items) != 3 || res.Pagination.Total != 3 {
+		t.Fatalf("got %d items of %d, want the events of every user", len(res.Items), res.Pagination.Total)
+	}
+	if res.Items[0].Uuid != last.Uuid {
+		t.Errorf("got %s first, want the latest event first", res.Items[0].Name)
+	}
+
+	res = itemsResponse[handler.Event]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/events?orderBy=asc&tags=golang", nil, &res)
+	if len(res.Items) != 2 || res.Pagination.Total != 2 || res.Items[0].Uuid != first.Uuid {
+		t.Errorf("got %v, want the 2 events tagged golang, oldest first", res.Items)
+	}
+
+	res = itemsResponse[handler.Event]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/events?page=2&limit=10", nil, &res)
+	if len(res.Items) != 0 || res.Pagination.CurrentPage != 2 || res.Pagination.Total != 3 {
+		t.Errorf("got %d items on page %d, want none past the last page", len(res.Items), res.Pagination.CurrentPage)
+	}
+}
+
+func TestPublicEvent(t *testing.T) {
+	api := newTestAPI(t)
+	event := api.event(api.user("alice@example.com"), "Kuala Lumpur Golang Meetup", "golang")
+	c := api.anonymous()
+
+	var res itemResponse[handler.Event]
+	c.expect(http.StatusOK, http.MethodGet, "/public/events/"+event.Uuid.String(), nil, &res)
+	if res.Item.Uuid != event.Uuid || !res.Item.StartsAt.Equal(event.StartsAt) || !res.Item.EndsAt.Equal(event.EndsAt) {
+		t.Errorf("got %+v, want %s", res.Item, event.Name)
+	}
+
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/events/"+uuid.Must(uuid.NewV4()).String(), nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/events/not-a-uuid", nil, nil)
+}
+
+// TestPublicInMemory runs the public routes against the in-memory store, so it runs without
+// a database.
+func TestPublicInMemory(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	user, err := st.InsertUser(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatalf("could not insert user: %v", err)
+	}
+	for i := range 3 {
+		if _, err := st.InsertProject(ctx, database.InsertProjectParams{
+			Name:        fmt.Sprintf("Project number %02d", i),
+			Description: "A project made in Malaysia.",
+			Tags:        []string{"golang"},
+			UserID:      user.UserID,
+		}); err != nil {
+			t.Fatalf("could not insert project: %v", err)
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(testLogWriter{t}, nil))
+	server := httptest.NewServer(handler.NewPublic(logger, awesomemy.Config{}, st))
+	t.Cleanup(server.Close)
+
+	res, err := http.Get(server.URL + "/projects?tags=golang&limit=2")
+	if err != nil {
+		t.Fatalf("could not list projects: %v", err)
+	}
+	defer func(body io.Closer) {
+		_ = body.Close()
+	}(res.Body)
+
+	var projects itemsResponse[handler.Project]
+	if err := json.NewDecoder(res.Body).Decode(&projects); err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+	if len(projects.Items) != 2 || projects.Pagination.Total != 3 || projects.Items[0].Name != "Project number 02" {
+		t.Errorf("got %v of %d, want the 2 latest projects of 3", projects.Items, projects.Pagination.Total)
+	}
+
+	res, err = http.Get(server.URL + "/projects/" + uuid.Must(uuid.NewV4()).String())
+	if err != nil {
+		t.Fatalf("could not show project: %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d, want %d", res.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestPublicOrganisations(t *testing.T) {
+	api := newTestAPI(t)
+	alice := api.user("alice@example.com")
+	first := api.organisation(alice, "Kedai Pay", "fintech", "selangor")
+	api.organisation(alice, "Pasar Hub", "agritech", "johor")
+	last := api.organisation(alice, "Lepak Labs", "fintech", "pulau-pinang")
+	c := api.anonymous()
+
+	var res itemsResponse[handler.Organisation]
+	c.expect(http.StatusOK, http.MethodGet, "/public/organisations", nil, &res)
+	if len(res.Items) != 3 || res.Pagination.Total != 3 || res.Items[0].Uuid != last.Uuid {
+		t.Fatalf("got %v of %d, want every organisation, latest first", res.Items, res.Pagination.Total)
+	}
+
+	res = itemsResponse[handler.Organisation]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/organisations?industry=fintech&orderBy=asc", nil, &res)
+	if len(res.Items) != 2 || res.Pagination.Total != 2 || res.Items[0].Uuid != first.Uuid {
+		t.Errorf("got %v, want the 2 fintech organisations, oldest first", res.Items)
+	}
+
+	res = itemsResponse[handler.Organisation]{}
+	c.expect(http.StatusOK, http.MethodGet, "/public/organisations?industry=fintech&hq_state=pulau-pinang", nil, &res)
+	if len(res.Items) != 1 || res.Items[0].Uuid != last.Uuid {
+		t.Errorf("got %v, want the fintech organisation in pulau-pinang", res.Items)
+	}
+
+	var organisation itemResponse[handler.Organisation]
+	c.expect(http.StatusOK, http.MethodGet, "/public/organisations/kedai-pay", nil, &organisation)
+	if organisation.Item.Uuid != first.Uuid {
+		t.Errorf("got %+v, want %s", organisation.Item, first.Name)
+	}
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/organisations/unknown", nil, nil)
+	c.expect(http.StatusNotFound, http.MethodGet, "/public/organisations/unknown/projects", nil, nil)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/awesome-my/backend"
+	"github.com/go-chi/httprate"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+const (
+	defaultRateLimitRequests = 50
+	defaultRateLimitWindow   = time.Minute
+)
+
+// RateLimiter creates the rate limit middlewares of the route groups, counting requests in
+// memory or in redis so that instances share their budgets.
+type RateLimiter struct {
+	logger         *slog.Logger
+	config         awesomemy.RateLimitConfig
+	pool           *redis.Pool
+	sessionManager *scs.SessionManager
+	metrics        *Metrics
+}
+
+// NewRateLimiter creates a RateLimiter, the redis pool is only used by the redis store and
+// may be nil otherwise.
+func NewRateLimiter(logger *slog.Logger, cfg awesomemy.RateLimitConfig, pool *redis.Pool, sm *scs.SessionManager, metrics *Metrics) (*RateLimiter, error) {
+	switch cfg.Store {
+	case "", RateLimitStoreMemory:
+	case RateLimitStoreRedis:
+		if pool == nil {
+			return nil, errors.New("redis rate limit store requires a redis pool")
+		}
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+
+	return &RateLimiter{
+		logger:         logger,
+		config:         cfg,
+		pool:           pool,
+		sessionManager: sm,
+		metrics:        metrics,
+	}, nil
+}
+
+// Limit limits the requests of a route group according to its policy. Anonymous requests are
+// keyed by IP address, requests of a signed-in user by the user UUID. The middleware must run
+// after the session is loaded.
+func (rl *RateLimiter) Limit(group string, policy awesomemy.RateLimitPolicy) func(next http.Handler) http.Handler {
+	requests := policy.Requests
+	if requests <= 0 {
+		requests = defaultRateLimitRequests
+	}
+	authenticatedRequests := policy.AuthenticatedRequests
+	if authenticatedRequests <= 0 {
+		authenticatedRequests = requests
+	}
+	window := policy.Window.Duration
+	if window <= 0 {
+		window = defaultRateLimitWindow
+	}
+
+	anonymous := rl.newTier(group+":ip", requests, window)
+	authenticated := rl.newTier(group+":user", authenticatedRequests, window)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tier := anonymous
+			key, _ := httprate.KeyByIP(r)
+			if userUuid := rl.sessionManager.GetString(r.Context(), "user:uuid"); userUuid != "" {
+				tier = authenticated
+				key = userUuid
+			}
+
+			allowed, remaining, reset, err := tier.take(r.Context(), key)
+			if err != nil {
+				// A failing counter store should not take the API down with it.
+				requestLogger(r, rl.logger).Error("could not count request for rate limit", slog.String("group", group), slog.Any("err", err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", tier.limit, int(tier.window.Seconds())))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(tier.limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(reset))
+
+			if !allowed {
+				rl.metrics.RateLimited()
+				w.Header().Set("Retry-After", strconv.Itoa(reset))
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(map[string]string{
+					"message": "You have hit the rate limit, try again later.",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (rl *RateLimiter) newTier(name string, limit int, window time.Duration) *rateLimitTier {
+	var counter rateLimitCounter
+	if rl.config.Store == RateLimitStoreRedis {
+		counter = &redisLimitCounter{
+			pool:   rl.pool,
+			prefix: rl.config.Prefix + ":ratelimit:" + name + ":",
+			limit:  limit,
+			window: window,
+		}
+	} else {
+		counter = &memoryLimitCounter{
+			limit:   limit,
+			window:  window,
+			limiter: httprate.NewRateLimiter(limit, window),
+		}
+	}
+
+	return &rateLimitTier{
+		limit:   limit,
+		window:  window,
+		counter: counter,
+	}
+}
+
+// rateLimitTier counts requests in a sliding window, which weighs the count of the previous
+// window by how much of it the sliding window still covers.
+type rateLimitTier struct {
+	limit   int
+	window  time.Duration
+	counter rateLimitCounter
+}
+
+// take counts a request against key, returning whether it is allowed, the remaining requests
+// and the seconds until the current window resets.
+func (t *rateLimitTier) take(ctx context.Context, key string) (bool, int, int, error) {
+	now := time.Now().UTC()
+	currentWindow := now.Truncate(t.window)
+	reset := int(math.Ceil(currentWindow.Add(t.window).Sub(now).Seconds()))
+
+	allowed, used, err := t.counter.take(ctx, key, now)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if !allowed {
+		return false, 0, reset, nil
+	}
+
+	return true, t.limit - used - 1, reset, nil
+}
+
+// rateLimitCounter checks the limit and counts a request in a single step, so that concurrent
+// requests, of this instance or of others sharing the counts, cannot overshoot the limit.
+type rateLimitCounter interface {
+	// take counts a request against key at now unless the sliding window already holds the
+	// limit, it returns whether the request was counted and the requests held before it.
+	take(ctx context.Context, key string, now time.Time) (bool, int, error)
+}
+
+// memoryLimitCounter counts requests in memory with the sliding window of httprate, it only
+// uses the limiter for its counter and status so the response headers remain ours to write.
+type memoryLimitCounter struct {
+	limit   int
+	window  time.Duration
+	limiter interface {
+		Counter() httprate.LimitCounter
+		Status(key string) (bool, float64, error)
+	}
+	mu sync.Mutex
+}
+
+func (c *memoryLimitCounter) take(ctx context.Context, key string, now time.Time) (bool, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, rate, err := c.limiter.Status(key)
+	if err != nil {
+		return false, 0, err
+	}
+
+	used := int(math.Round(rate))
+	if used >= c.limit {
+		return false, used, nil
+	}
+
+	if err := c.limiter.Counter().IncrementBy(key, now.Truncate(c.window), 1); err != nil {
+		return false, 0, err
+	}
+
+	return true, used, nil
+}
+
+// rateLimitScript counts a request in the window of KEYS[1] unless the sliding window over it
+// and the previous window of KEYS[2] holds the limit. ARGV holds the weight of the previous
+// window, the limit and the seconds the counts are kept for.
+var rateLimitScript = redis.NewScript(2, `
+local curr = tonumber(redis.call("GET", KEYS[1]) or 0)
+local prev = tonumber(redis.call("GET", KEYS[2]) or 0)
+local used = math.floor(prev * tonumber(ARGV[1]) + curr + 0.5)
+if used >= tonumber(ARGV[2]) then
+	return {0, used}
+end
+
+redis.call("INCR", KEYS[1])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return {1, used}
+`)
+
+// redisLimitCounter stores the request counts of each window in redis, expiring them once
+// they can no longer be part of the sliding window.
+type redisLimitCounter struct {
+	pool   *redis.Pool
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+func (c *redisLimitCounter) take(ctx context.Context, key string, now time.Time) (bool, int, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func(conn redis.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	currentWindow := now.Truncate(c.window)
+	previousWindow := currentWindow.Add(-c.window)
+	weight := float64(c.window-now.Sub(currentWindow)) / float64(c.window)
+
+	values, err := redis.Ints(rateLimitScript.DoContext(ctx, conn,
+		c.key(key, currentWindow),
+		c.key(key, previousWindow),
+		strconv.FormatFloat(weight, 'f', -1, 64),
+		c.limit,
+		int(math.Ceil((c.window * 3).Seconds())),
+	))
+	if err != nil {
+		return false, 0, err
+	}
+
+	return values[0] == 1, values[1], nil
+}
+
+func (c *redisLimitCounter) key(key string, window time.Time) string {
+	return c.prefix + key + ":" + strconv.FormatInt(window.Unix(), 10)
+}
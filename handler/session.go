@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/gofrs/uuid"
+)
+
+// sessionTouchInterval throttles how often the last seen time of a session is written.
+const sessionTouchInterval = time.Minute
+
+// trackSession records metadata for the session loaded into the request context, along with
+// its token so it can be revoked, and links it to the session through the session:uuid key.
+func trackSession(r *http.Request, st store.UserSessionStore, sm *scs.SessionManager, user database.User) error {
+	now := time.Now()
+
+	session, err := st.InsertUserSession(r.Context(), database.InsertUserSessionParams{
+		Token:      sm.Token(r.Context()),
+		IpAddress:  requestIP(r),
+		UserAgent:  r.UserAgent(),
+		LastSeenAt: now,
+		ExpiresAt:  sm.Deadline(r.Context()),
+		UserID:     user.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.Put(r.Context(), "session:uuid", session.Uuid.String())
+	sm.Put(r.Context(), "session:seen_at", now.Unix())
+
+	return nil
+}
+
+// touchSession updates the last seen time, IP address and user agent of the session loaded
+// into the request context, at most once every sessionTouchInterval.
+func touchSession(r *http.Request, st store.UserSessionStore, sm *scs.SessionManager) error {
+	sessionUuid, err := uuid.FromString(sm.GetString(r.Context(), "session:uuid"))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if now.Sub(time.Unix(sm.GetInt64(r.Context(), "session:seen_at"), 0)) < sessionTouchInterval {
+		return nil
+	}
+
+	if err := st.TouchUserSession(r.Context(), database.TouchUserSessionParams{
+		Token:      sm.Token(r.Context()),
+		IpAddress:  requestIP(r),
+		UserAgent:  r.UserAgent(),
+		LastSeenAt: now,
+		Uuid:       sessionUuid,
+	}); err != nil {
+		return err
+	}
+
+	sm.Put(r.Context(), "session:seen_at", now.Unix())
+
+	return nil
+}
+
+// destroySessionTokens deletes the sessions of the tokens from the store. The session loaded
+// into ctx, if any, is left untouched and must be destroyed by the caller.
+func destroySessionTokens(ctx context.Context, sm *scs.SessionManager, tokens ...string) error {
+	for _, token := range tokens {
+		if token == sm.Token(ctx) {
+			continue
+		}
+
+		var err error
+		if cs, ok := sm.Store.(scs.CtxStore); ok {
+			err = cs.DeleteCtx(ctx, token)
+		} else {
+			err = sm.Store.Delete(token)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// destroyUserSessions deletes the tracked sessions of the given user from the store.
+func destroyUserSessions(ctx context.Context, st store.UserSessionStore, sm *scs.SessionManager, userID int32) error {
+	sessions, err := st.UserSessions(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	tokens := make([]string, len(sessions))
+	for i, s := range sessions {
+		tokens[i] = s.Token
+	}
+
+	return destroySessionTokens(ctx, sm, tokens...)
+}
+
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alexedwards/scs/redisstore"
+	"github.com/alexedwards/scs/v2"
+	"github.com/alexedwards/scs/v2/memstore"
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	SessionStoreRedis    = "redis"
+	SessionStorePostgres = "postgres"
+	SessionStoreMemory   = "memory"
+)
+
+// defaultSessionCleanupInterval is used by the postgres and memory stores when no cleanup
+// interval is configured.
+const defaultSessionCleanupInterval = 5 * time.Minute
+
+// NewSessionStore creates the session store selected by the authentication configuration,
+// defaulting to redis. The redis pool is only used by the redis store and may be nil otherwise.
+func NewSessionStore(logger *slog.Logger, cfg awesomemy.Config, db *sql.DB, pool *redis.Pool) (scs.Store, error) {
+	cleanupInterval := cfg.Authentication.Session.CleanupInterval.Duration
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultSessionCleanupInterval
+	}
+
+	switch cfg.Authentication.Session.Store {
+	case "", SessionStoreRedis:
+		if pool == nil {
+			return nil, errors.New("redis session store requires a redis pool")
+		}
+		return redisstore.NewWithPrefix(pool, cfg.Authentication.Session.Prefix), nil
+	case SessionStorePostgres:
+		return NewPostgresStore(logger, db, cleanupInterval), nil
+	case SessionStoreMemory:
+		return memstore.NewWithCleanupInterval(cleanupInterval), nil
+	}
+
+	return nil, fmt.Errorf("unknown session store %q", cfg.Authentication.Session.Store)
+}
+
+var (
+	_ scs.CtxStore         = (*PostgresStore)(nil)
+	_ scs.IterableCtxStore = (*PostgresStore)(nil)
+	_ scs.IterableStore    = (*PostgresStore)(nil)
+)
+
+// PostgresStore is a session store backed by the sessions table.
+type PostgresStore struct {
+	logger      *slog.Logger
+	database    *sql.DB
+	queries     *database.Queries
+	stopCleanup chan struct{}
+}
+
+// NewPostgresStore creates a PostgresStore with a background goroutine that purges expired
+// sessions every cleanupInterval. A cleanupInterval of 0 disables the cleanup goroutine.
+func NewPostgresStore(logger *slog.Logger, db *sql.DB, cleanupInterval time.Duration) *PostgresStore {
+	p := &PostgresStore{
+		logger:   logger,
+		database: db,
+		queries:  database.New(),
+	}
+
+	if cleanupInterval > 0 {
+		p.stopCleanup = make(chan struct{})
+		go p.startCleanup(cleanupInterval)
+	}
+
+	return p
+}
+
+func (p *PostgresStore) Find(token string) ([]byte, bool, error) {
+	return p.FindCtx(context.Background(), token)
+}
+
+func (p *PostgresStore) FindCtx(ctx context.Context, token string) ([]byte, bool, error) {
+	b, err := p.queries.SessionDataByToken(ctx, p.database, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return b, true, nil
+}
+
+func (p *PostgresStore) Commit(token string, b []byte, expiry time.Time) error {
+	return p.CommitCtx(context.Background(), token, b, expiry)
+}
+
+func (p *PostgresStore) CommitCtx(ctx context.Context, token string, b []byte, expiry time.Time) error {
+	return p.queries.UpsertSession(ctx, p.database, database.UpsertSessionParams{
+		Token:  token,
+		Data:   b,
+		Expiry: expiry,
+	})
+}
+
+func (p *PostgresStore) Delete(token string) error {
+	return p.DeleteCtx(context.Background(), token)
+}
+
+func (p *PostgresStore) DeleteCtx(ctx context.Context, token string) error {
+	return p.queries.DeleteSession(ctx, p.database, token)
+}
+
+func (p *PostgresStore) All() (map[string][]byte, error) {
+	return p.AllCtx(context.Background())
+}
+
+func (p *PostgresStore) AllCtx(ctx context.Context) (map[string][]byte, error) {
+	sessions, err := p.queries.ActiveSessions(ctx, p.database)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string][]byte, len(sessions))
+	for _, s := range sessions {
+		all[s.Token] = s.Data
+	}
+
+	return all, nil
+}
+
+// StopCleanup stops the background cleanup goroutine, if it is running.
+func (p *PostgresStore) StopCleanup() {
+	if p.stopCleanup != nil {
+		p.stopCleanup <- struct{}{}
+	}
+}
+
+func (p *PostgresStore) startCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.queries.DeleteExpiredSessions(context.Background(), p.database); err != nil {
+				p.logger.Error("could not delete expired sessions", slog.Any("err", err))
+			}
+		case <-p.stopCleanup:
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+const tracerName = "github.com/awesome-my/backend/handler"
+
+var tracer = otel.Tracer(tracerName)
+
+// untracedPaths are probed too often to be worth a span on every request.
+var untracedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Trace starts a span for every request, continuing any trace propagated by the caller.
+func Trace(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(
+		traceRoute(next),
+		"http.request",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !untracedPaths[r.URL.Path]
+		}),
+	)
+}
+
+// traceRoute names the request span after the chi route pattern once the request is routed.
+func traceRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		span := trace.SpanFromContext(r.Context())
+		if !span.IsRecording() {
+			return
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+			span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+		}
+	})
+}
+
+// oauth2Context makes the OAuth2 token exchange and the clients derived from the returned
+// context trace their outbound HTTP calls.
+func oauth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	})
+}
+
+// endSpan records err on the span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+var (
+	_ scs.CtxStore         = (*TracedStore)(nil)
+	_ scs.IterableCtxStore = (*TracedStore)(nil)
+)
+
+// TracedStore wraps a session store to record a span for every session operation.
+type TracedStore struct {
+	store scs.Store
+	attrs []attribute.KeyValue
+}
+
+// NewTracedStore wraps store, recording the name of its backend on every span.
+func NewTracedStore(store scs.Store, backend string) *TracedStore {
+	if backend == "" {
+		backend = SessionStoreRedis
+	}
+
+	return &TracedStore{
+		store: store,
+		attrs: []attribute.KeyValue{attribute.String("session.store", backend)},
+	}
+}
+
+func (t *TracedStore) Find(token string) ([]byte, bool, error) {
+	return t.FindCtx(context.Background(), token)
+}
+
+func (t *TracedStore) FindCtx(ctx context.Context, token string) (b []byte, found bool, err error) {
+	ctx, span := tracer.Start(ctx, "session.find", trace.WithAttributes(t.attrs...))
+	defer func() { endSpan(span, err) }()
+
+	if s, ok := t.store.(scs.CtxStore); ok {
+		return s.FindCtx(ctx, token)
+	}
+
+	return t.store.Find(token)
+}
+
+func (t *TracedStore) Commit(token string, b []byte, expiry time.Time) error {
+	return t.CommitCtx(context.Background(), token, b, expiry)
+}
+
+func (t *TracedStore) CommitCtx(ctx context.Context, token string, b []byte, expiry time.Time) (err error) {
+	ctx, span := tracer.Start(ctx, "session.commit", trace.WithAttributes(t.attrs...))
+	defer func() { endSpan(span, err) }()
+
+	if s, ok := t.store.(scs.CtxStore); ok {
+		return s.CommitCtx(ctx, token, b, expiry)
+	}
+
+	return t.store.Commit(token, b, expiry)
+}
+
+func (t *TracedStore) Delete(token string) error {
+	return t.DeleteCtx(context.Background(), token)
+}
+
+func (t *TracedStore) DeleteCtx(ctx context.Context, token string) (err error) {
+	ctx, span := tracer.Start(ctx, "session.delete", trace.WithAttributes(t.attrs...))
+	defer func() { endSpan(span, err) }()
+
+	if s, ok := t.store.(scs.CtxStore); ok {
+		return s.DeleteCtx(ctx, token)
+	}
+
+	return t.store.Delete(token)
+}
+
+func (t *TracedStore) AllCtx(ctx context.Context) (all map[string][]byte, err error) {
+	ctx, span := tracer.Start(ctx, "session.all", trace.WithAttributes(t.attrs...))
+	defer func() { endSpan(span, err) }()
+
+	switch s := t.store.(type) {
+	case scs.IterableCtxStore:
+		return s.AllCtx(ctx)
+	case scs.IterableStore:
+		return s.All()
+	}
+
+	return nil, errors.New("session store does not support iteration")
+}
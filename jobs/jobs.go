@@ -0,0 +1,325 @@
+// Package jobs runs background jobs queued in Postgres. Workers claim due jobs with
+// FOR UPDATE SKIP LOCKED so any number of them can share the queue, retry failed jobs with
+// exponential backoff and move the jobs that exhausted their attempts to the dead letters.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+)
+
+const (
+	// DefaultConcurrency is how many jobs a worker runs at once when the configuration leaves
+	// it unset.
+	DefaultConcurrency = 4
+	// DefaultPollInterval is how often an idle worker looks for due jobs when the
+	// configuration leaves it unset.
+	DefaultPollInterval = time.Second
+	// DefaultLockTimeout is how long a job may run before it is claimed again when the
+	// configuration leaves it unset.
+	DefaultLockTimeout = 5 * time.Minute
+	// DefaultMaxAttempts is how many times a job is attempted before it is dead when neither
+	// the job nor the configuration set it.
+	DefaultMaxAttempts = 5
+	// DefaultRetention is how long completed jobs are kept when the configuration leaves it
+	// unset.
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// backoffBase is the delay before the first retry, it doubles after every attempt up to
+	// backoffMax.
+	backoffBase = 10 * time.Second
+	backoffMax  = time.Hour
+)
+
+// Kind names a kind of job whose payload is a T, which is stored as JSON. Jobs are enqueued
+// and handled through their Kind so both sides agree on the payload.
+type Kind[T any] string
+
+// PruneJobs deletes the jobs completed before the retention period, every worker schedules it.
+const PruneJobs Kind[struct{}] = "jobs.prune"
+
+// EnqueueOptions tunes a job, the zero value runs it as soon as possible with the attempts
+// configured for the worker.
+type EnqueueOptions struct {
+	RunAt       time.Time
+	MaxAttempts int
+	// UniqueKey keeps a job from being enqueued again while a job with the same key is kept,
+	// Enqueue then returns store.ErrJobExists.
+	UniqueKey string
+}
+
+// Enqueue adds a job of kind to the queue, within the transaction of st if any so the job
+// only runs once the changes it depends on are committed.
+func Enqueue[T any](ctx context.Context, st store.JobStore, kind Kind[T], payload T, opts EnqueueOptions) (database.Job, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return database.Job{}, err
+	}
+
+	runAt := opts.RunAt
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+
+	var uniqueKey nulls.String
+	if opts.UniqueKey != "" {
+		uniqueKey = nulls.NewString(opts.UniqueKey)
+	}
+
+	return st.EnqueueJob(ctx, database.EnqueueJobParams{
+		Kind:        string(kind),
+		Payload:     b,
+		MaxAttempts: int32(opts.MaxAttempts),
+		UniqueKey:   uniqueKey,
+		RunAt:       runAt,
+	})
+}
+
+// permanentError marks an error that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error returned by a handler so the job is dead straight away instead of
+// being retried, e.g. when its payload refers to a record that no longer exists.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+type handler func(ctx context.Context, payload json.RawMessage) error
+
+// Worker runs the jobs of the kinds it handles and enqueues the scheduled ones.
+type Worker struct {
+	logger       *slog.Logger
+	store        store.JobStore
+	handlers     map[string]handler
+	schedules    []*scheduled
+	concurrency  int
+	pollInterval time.Duration
+	lockTimeout  time.Duration
+	maxAttempts  int
+	retention    time.Duration
+}
+
+// NewWorker creates a Worker from the jobs configuration, unset settings take their default.
+// The worker handles and schedules PruneJobs daily.
+func NewWorker(logger *slog.Logger, cfg awesomemy.JobsConfig, st store.JobStore) *Worker {
+	w := &Worker{
+		logger:       logger,
+		store:        st,
+		handlers:     make(map[string]handler),
+		concurrency:  cfg.Concurrency,
+		pollInterval: cfg.PollInterval.Duration,
+		lockTimeout:  cfg.LockTimeout.Duration,
+		maxAttempts:  cfg.MaxAttempts,
+		retention:    cfg.Retention.Duration,
+	}
+	if w.concurrency == 0 {
+		w.concurrency = DefaultConcurrency
+	}
+	if w.pollInterval == 0 {
+		w.pollInterval = DefaultPollInterval
+	}
+	if w.lockTimeout == 0 {
+		w.lockTimeout = DefaultLockTimeout
+	}
+	if w.maxAttempts == 0 {
+		w.maxAttempts = DefaultMaxAttempts
+	}
+	if w.retention == 0 {
+		w.retention = DefaultRetention
+	}
+
+	Handle(w, PruneJobs, func(ctx context.Context, _ struct{}) error {
+		n, err := w.store.DeleteCompletedJobs(ctx, time.Now().Add(-w.retention))
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			w.logger.Info("deleted completed jobs", slog.Int64("count", n))
+		}
+
+		return nil
+	})
+	if err := Schedule(w, "@daily", PruneJobs, struct{}{}); err != nil {
+		panic(err)
+	}
+
+	return w
+}
+
+// Handle registers fn to run the jobs of kind, replacing any previous handler. A payload that
+// cannot be decoded into a T kills the job.
+func Handle[T any](w *Worker, kind Kind[T], fn func(ctx context.Context, payload T) error) {
+	w.handlers[string(kind)] = func(ctx context.Context, payload json.RawMessage) error {
+		var p T
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return Permanent(fmt.Errorf("could not decode payload: %w", err))
+		}
+
+		return fn(ctx, p)
+	}
+}
+
+// Run enqueues the scheduled jobs and runs the due jobs until ctx is done, then waits for the
+// running jobs to finish. Handlers are not cancelled with ctx, they are bounded by the lock
+// timeout instead.
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("running job worker", slog.Int("concurrency", w.concurrency))
+
+	var wg sync.WaitGroup
+	wg.Add(w.concurrency + 1)
+
+	go func() {
+		defer wg.Done()
+		w.every(ctx, func(ctx context.Context) bool {
+			w.EnqueueScheduled(ctx, time.Now())
+			return false
+		})
+	}()
+
+	for range w.concurrency {
+		go func() {
+			defer wg.Done()
+			w.every(ctx, func(ctx context.Context) bool {
+				n, err := w.Work(ctx, time.Now(), 1)
+				if err != nil && ctx.Err() == nil {
+					w.logger.Error("could not claim jobs", slog.Any("err", err))
+				}
+
+				return n > 0
+			})
+		}()
+	}
+
+	wg.Wait()
+	w.logger.Info("job worker stopped")
+}
+
+// every calls fn until ctx is done, waiting for the poll interval whenever fn returns false.
+func (w *Worker) every(ctx context.Context, fn func(ctx context.Context) bool) {
+	for ctx.Err() == nil {
+		if fn(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Work claims up to limit jobs due at now and runs them concurrently, returning how many were
+// claimed once they are done.
+func (w *Worker) Work(ctx context.Context, now time.Time, limit int) (int, error) {
+	claimed, err := w.store.ClaimJobs(ctx, now, now.Add(-w.lockTimeout), int32(w.maxAttempts), int32(limit))
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range claimed {
+		wg.Add(1)
+		go func(job database.Job) {
+			defer wg.Done()
+			w.run(ctx, job, now)
+		}(job)
+	}
+	wg.Wait()
+
+	return len(claimed), nil
+}
+
+// run runs a claimed job and records its outcome, even when ctx is done in the meantime.
+func (w *Worker) run(ctx context.Context, job database.Job, now time.Time) {
+	ctx = context.WithoutCancel(ctx)
+	logger := w.logger.With(slog.Int("job_id", int(job.JobID)), slog.String("kind", job.Kind), slog.Int("attempt", int(job.Attempts)))
+
+	started := time.Now()
+	err := w.handle(ctx, job)
+	if err == nil {
+		logger.Debug("job completed", slog.Duration("duration", time.Since(started)))
+		if err := w.store.CompleteJob(ctx, job.JobID, job.LockedAt.Time, time.Now()); err != nil {
+			logOutcomeError(logger, "could not complete job", err)
+		}
+		return
+	}
+
+	maxAttempts := int(job.MaxAttempts)
+	if maxAttempts == 0 {
+		maxAttempts = w.maxAttempts
+	}
+
+	var pe permanentError
+	if errors.As(err, &pe) || int(job.Attempts) >= maxAttempts {
+		logger.Error("job is dead", slog.Any("err", err))
+		if err := w.store.KillJob(ctx, job.JobID, job.LockedAt.Time, time.Now(), err.Error()); err != nil {
+			logOutcomeError(logger, "could not kill job", err)
+		}
+		return
+	}
+
+	runAt := now.Add(backoff(int(job.Attempts)))
+	logger.Warn("job failed, retrying", slog.Time("run_at", runAt), slog.Any("err", err))
+	if err := w.store.RetryJob(ctx, job.JobID, job.LockedAt.Time, runAt, err.Error()); err != nil {
+		logOutcomeError(logger, "could not retry job", err)
+	}
+}
+
+// logOutcomeError logs why the outcome of a job could not be recorded. A job whose lock
+// expired while it ran belongs to whoever claimed or killed it since, so its outcome is
+// dropped.
+func logOutcomeError(logger *slog.Logger, msg string, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		logger.Warn("job lock expired before its outcome was recorded")
+		return
+	}
+
+	logger.Error(msg, slog.Any("err", err))
+}
+
+// handle calls the handler of job within the lock timeout, turning a panic into an error.
+func (w *Worker) handle(ctx context.Context, job database.Job) (err error) {
+	h, ok := w.handlers[job.Kind]
+	if !ok {
+		// A newer worker may handle it, e.g. while a deployment rolls out.
+		return fmt.Errorf("no handler for job kind %q", job.Kind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.lockTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return h(ctx, job.Payload)
+}
+
+// backoff is the delay before retrying a job that failed its attempt.
+func backoff(attempt int) time.Duration {
+	d := backoffBase
+	for i := 1; i < attempt && d < backoffMax; i++ {
+		d *= 2
+	}
+
+	return min(d, backoffMax)
+}
@@ -0,0 +1,262 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/store"
+)
+
+func TestParseCron(t *testing.T) {
+	from := time.Date(2026, 10, 19, 13, 7, 30, 0, time.UTC) // a Monday
+	for _, tc := range []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 19, 13, 8, 0, 0, time.UTC)},
+		{"*/10 * * * *", time.Date(2026, 10, 19, 13, 10, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Restricting both days runs on either.
+		{"0 0 1 * 3", time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)},
+		{"@every 15m", time.Date(2026, 10, 19, 13, 15, 0, 0, time.UTC)},
+	} {
+		cron, err := jobs.ParseCron(tc.spec)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned %v", tc.spec, err)
+			continue
+		}
+
+		if next := cron.Next(from); !next.Equal(tc.next) {
+			t.Errorf("ParseCron(%q).Next = %v, want %v", tc.spec, next, tc.next)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "@every 1ms", "@yearly"} {
+		if _, err := jobs.ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) returned no error", spec)
+		}
+	}
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+const greetJob jobs.Kind[greeting] = "test.greet"
+
+func newWorker(t *testing.T, st store.JobStore) *jobs.Worker {
+	t.Helper()
+
+	return jobs.NewWorker(slog.New(slog.NewTextHandler(io.Discard, nil)), awesomemy.JobsConfig{MaxAttempts: 3}, st)
+}
+
+func TestWorkerRetriesThenKills(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	w := newWorker(t, st)
+
+	var mu sync.Mutex
+	var greeted []string
+	jobs.Handle(w, greetJob, func(ctx context.Context, g greeting) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		greeted = append(greeted, g.Name)
+		if g.Name == "bob" {
+			return errors.New("bob is away")
+		}
+		return nil
+	})
+
+	now := time.Now()
+	if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "alice"}, jobs.EnqueueOptions{RunAt: now}); err != nil {
+		t.Fatalf("could not enqueue job: %v", err)
+	}
+	bob, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "bob"}, jobs.EnqueueOptions{RunAt: now})
+	if err != nil {
+		t.Fatalf("could not enqueue job: %v", err)
+	}
+
+	if n, err := w.Work(ctx, now, 10); err != nil || n != 2 {
+		t.Fatalf("got %d, %v, want 2 jobs claimed", n, err)
+	}
+
+	// The failed job is retried after 10s, then 20s, and is dead after its third attempt.
+	for _, tc := range []struct {
+		after time.Duration
+		n     int
+	}{
+		{9 * time.Second, 0},
+		{10 * time.Second, 1},
+		{29 * time.Second, 0},
+		{30 * time.Second, 1},
+		{time.Hour, 0},
+	} {
+		if n, err := w.Work(ctx, now.Add(tc.after), 10); err != nil || n != tc.n {
+			t.Fatalf("after %v got %d, %v, want %d jobs claimed", tc.after, n, err, tc.n)
+		}
+	}
+	if len(greeted) != 4 {
+		t.Errorf("got %v, want alice greeted once and bob thrice", greeted)
+	}
+
+	dead, err := st.DeadJobs(ctx, 10)
+	if err != nil {
+		t.Fatalf("could not list dead jobs: %v", err)
+	}
+	if len(dead) != 1 || dead[0].JobID != bob.JobID || dead[0].Attempts != 3 || dead[0].LastError.String != "bob is away" {
+		t.Fatalf("got %+v, want bob dead after 3 attempts", dead)
+	}
+
+	// Requeued dead jobs get a fresh set of attempts.
+	if _, err := st.RequeueDeadJob(ctx, bob.JobID, now); err != nil {
+		t.Fatalf("could not requeue job: %v", err)
+	}
+	if n, err := w.Work(ctx, now, 10); err != nil || n != 1 {
+		t.Fatalf("got %d, %v, want the requeued job claimed", n, err)
+	}
+	if dead, _ := st.DeadJobs(ctx, 10); len(dead) != 0 {
+		t.Errorf("got %+v, want no dead job before the attempts are exhausted again", dead)
+	}
+}
+
+func TestWorkerKillsPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	w := newWorker(t, st)
+
+	jobs.Handle(w, greetJob, func(ctx context.Context, g greeting) error {
+		if g.Name == "" {
+			return jobs.Permanent(errors.New("nobody to greet"))
+		}
+		panic("greeting failed")
+	})
+
+	now := time.Now()
+	for _, name := range []string{"", "alice"} {
+		if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: name}, jobs.EnqueueOptions{RunAt: now, MaxAttempts: 1}); err != nil {
+			t.Fatalf("could not enqueue job: %v", err)
+		}
+	}
+	if n, err := w.Work(ctx, now, 10); err != nil || n != 2 {
+		t.Fatalf("got %d, %v, want 2 jobs claimed", n, err)
+	}
+
+	if dead, _ := st.DeadJobs(ctx, 10); len(dead) != 2 {
+		t.Errorf("got %+v, want both jobs dead", dead)
+	}
+}
+
+func TestWorkerExpiredLocks(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	w := newWorker(t, st)
+
+	var greeted []string
+	jobs.Handle(w, greetJob, func(ctx context.Context, g greeting) error {
+		greeted = append(greeted, g.Name)
+		return nil
+	})
+
+	// Both jobs are claimed by a worker that dies before recording their outcome.
+	now := time.Now()
+	if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "alice"}, jobs.EnqueueOptions{RunAt: now}); err != nil {
+		t.Fatalf("could not enqueue job: %v", err)
+	}
+	bob, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "bob"}, jobs.EnqueueOptions{RunAt: now, MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("could not enqueue job: %v", err)
+	}
+	claimed, err := st.ClaimJobs(ctx, now, now.Add(-jobs.DefaultLockTimeout), 3, 10)
+	if err != nil || len(claimed) != 2 {
+		t.Fatalf("got %v, %v, want 2 jobs claimed", claimed, err)
+	}
+
+	// Once the lock expired, the job with attempts left is claimed again while the other is
+	// dead.
+	if n, err := w.Work(ctx, now.Add(jobs.DefaultLockTimeout+time.Second), 10); err != nil || n != 1 {
+		t.Fatalf("got %d, %v, want 1 job claimed", n, err)
+	}
+	if len(greeted) != 1 || greeted[0] != "alice" {
+		t.Errorf("got %v, want only alice greeted", greeted)
+	}
+	dead, err := st.DeadJobs(ctx, 10)
+	if err != nil {
+		t.Fatalf("could not list dead jobs: %v", err)
+	}
+	if len(dead) != 1 || dead[0].JobID != bob.JobID || dead[0].Attempts != 1 {
+		t.Fatalf("got %+v, want bob dead after 1 attempt", dead)
+	}
+
+	// The outcome of the first claim is dropped once the job was claimed again.
+	for _, job := range claimed {
+		if err := st.RetryJob(ctx, job.JobID, job.LockedAt.Time, now, "late"); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("got %v retrying job %d, want ErrNotFound", err, job.JobID)
+		}
+	}
+}
+
+func TestWorkerEnqueueScheduled(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+
+	// Two workers sharing the queue enqueue each run once.
+	var greeted atomic.Int32
+	workers := []*jobs.Worker{newWorker(t, st), newWorker(t, st)}
+	for _, w := range workers {
+		jobs.Handle(w, greetJob, func(ctx context.Context, g greeting) error {
+			greeted.Add(1)
+			return nil
+		})
+		if err := jobs.Schedule(w, "@every 1m", greetJob, greeting{Name: "alice"}); err != nil {
+			t.Fatalf("could not schedule job: %v", err)
+		}
+	}
+
+	start := time.Date(2026, 10, 19, 13, 0, 30, 0, time.UTC)
+	for minute := range 3 {
+		now := start.Add(time.Duration(minute) * time.Minute)
+		for _, w := range workers {
+			w.EnqueueScheduled(ctx, now)
+		}
+		if _, err := workers[minute%2].Work(ctx, now, 10); err != nil {
+			t.Fatalf("could not work: %v", err)
+		}
+	}
+
+	// The first call only works out when the job is due.
+	if n := greeted.Load(); n != 2 {
+		t.Errorf("got %d greetings, want 2", n)
+	}
+
+	// Completed jobs keep their unique key until they are pruned.
+	opts := jobs.EnqueueOptions{UniqueKey: "greet:alice"}
+	if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "alice"}, opts); err != nil {
+		t.Fatalf("could not enqueue job: %v", err)
+	}
+	if _, err := workers[0].Work(ctx, time.Now(), 10); err != nil {
+		t.Fatalf("could not work: %v", err)
+	}
+	if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "alice"}, opts); !errors.Is(err, store.ErrJobExists) {
+		t.Errorf("got %v, want %v", err, store.ErrJobExists)
+	}
+	if n, err := st.DeleteCompletedJobs(ctx, time.Now().Add(time.Second)); err != nil || n != 3 {
+		t.Fatalf("got %d, %v, want 3 completed jobs deleted", n, err)
+	}
+	if _, err := jobs.Enqueue(ctx, st, greetJob, greeting{Name: "alice"}, opts); err != nil {
+		t.Errorf("could not enqueue job once pruned: %v", err)
+	}
+}
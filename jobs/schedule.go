@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend/store"
+)
+
+// Cron tells when a scheduled job runs next.
+type Cron interface {
+	// Next returns the first time strictly after t the job runs at.
+	Next(t time.Time) time.Time
+}
+
+// ParseCron parses a cron expression of five fields, minute hour day-of-month month
+// day-of-week, evaluated in UTC. A field is *, a value, a range such as 1-5 or a list of
+// those separated by commas, each optionally stepped such as */15. The @hourly, @daily,
+// @weekly and @monthly shorthands are accepted, as is @every followed by a duration such as
+// @every 15m, which runs at the multiples of the duration.
+func ParseCron(spec string) (Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("invalid schedule %q: the interval must be at least a second", spec)
+		}
+
+		return everySchedule(interval), nil
+	}
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var s cronSchedule
+	for i, f := range []struct {
+		bits        *uint64
+		first, last int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.day, 1, 31},
+		{&s.month, 1, 12},
+		{&s.weekday, 0, 7},
+	} {
+		bits, err := parseField(fields[i], f.first, f.last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		*f.bits = bits
+	}
+
+	// 7 is another name for Sunday.
+	if s.weekday&(1<<7) != 0 {
+		s.weekday |= 1
+	}
+	s.anyDay = fields[2] == "*"
+	s.anyWeekday = fields[4] == "*"
+
+	return s, nil
+}
+
+// parseField returns the values of a cron field as a bitset.
+func parseField(field string, first, last int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		lo, hi := first, last
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+
+			var err error
+			lo, err = strconv.Atoi(loStr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", loStr)
+			}
+			hi = lo
+			if isRange {
+				hi, err = strconv.Atoi(hiStr)
+				if err != nil {
+					return 0, fmt.Errorf("invalid value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = last
+			}
+		}
+		if lo < first || hi > last || lo > hi {
+			return 0, fmt.Errorf("%q is out of the range %d-%d", part, first, last)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+type cronSchedule struct {
+	minute, hour, day, month, weekday uint64
+	// When both the day of month and the day of week are restricted, a day matching either
+	// is run, like cron does.
+	anyDay, anyWeekday bool
+}
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+
+	// Every schedule matches within a few years, the bound only guards against a day of
+	// month that never comes, such as 31 in February.
+	for limit := t.AddDate(5, 0, 0); t.Before(limit); {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s cronSchedule) matchDay(t time.Time) bool {
+	day := s.day&(1<<t.Day()) != 0
+	weekday := s.weekday&(1<<int(t.Weekday())) != 0
+
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+
+	return day || weekday
+}
+
+// everySchedule runs at the multiples of its interval since the zero time, so every worker
+// agrees on when it runs.
+type everySchedule time.Duration
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(time.Duration(s)).Add(time.Duration(s))
+}
+
+type scheduled struct {
+	kind    string
+	enqueue func(ctx context.Context, st store.JobStore, opts EnqueueOptions) error
+	cron    Cron
+	next    time.Time
+}
+
+// Schedule enqueues a job of kind with payload whenever spec, parsed by ParseCron, comes
+// due while the worker runs. Every worker may schedule the same job, a run is only enqueued
+// once since its unique key is the kind and the time it is due.
+func Schedule[T any](w *Worker, spec string, kind Kind[T], payload T) error {
+	cron, err := ParseCron(spec)
+	if err != nil {
+		return err
+	}
+
+	w.schedules = append(w.schedules, &scheduled{
+		kind: string(kind),
+		enqueue: func(ctx context.Context, st store.JobStore, opts EnqueueOptions) error {
+			_, err := Enqueue(ctx, st, kind, payload, opts)
+			return err
+		},
+		cron: cron,
+	})
+
+	return nil
+}
+
+// EnqueueScheduled enqueues the scheduled jobs that came due at now. The first call only
+// works out when each job is due, runs missed while no worker was running are skipped.
+func (w *Worker) EnqueueScheduled(ctx context.Context, now time.Time) {
+	for _, s := range w.schedules {
+		if s.next.IsZero() {
+			s.next = s.cron.Next(now)
+			continue
+		}
+		if now.Before(s.next) {
+			continue
+		}
+
+		err := s.enqueue(ctx, w.store, EnqueueOptions{
+			RunAt:     s.next,
+			UniqueKey: "schedule:" + s.kind + ":" + strconv.FormatInt(s.next.Unix(), 10),
+		})
+		if err != nil && !errors.Is(err, store.ErrJobExists) {
+			w.logger.Error("could not enqueue scheduled job", slog.String("kind", s.kind), slog.Any("err", err))
+			continue
+		}
+
+		s.next = s.cron.Next(now)
+	}
+}
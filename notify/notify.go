@@ -0,0 +1,264 @@
+// Package notify emails users about their account and listings. Notifications are enqueued as
+// jobs, within the transaction of the change they are about, and rendered from the templates
+// of the package then sent through a Transport by the job workers. Users turn each category of
+// notification off with their preferences, or in one click from the emails.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/store"
+	"github.com/gofrs/uuid"
+)
+
+// Notification is a kind of email sent to users.
+type Notification string
+
+const (
+	// Welcome is sent once a user signs up, it is always sent.
+	Welcome Notification = "welcome"
+	// ListingCreated confirms a project or event was created.
+	ListingCreated Notification = "listing_created"
+	// ListingUpdated tells the owner of a project or event it was edited by someone else.
+	ListingUpdated Notification = "listing_updated"
+	// ListingDeleted tells the owner of a project or event it was deleted by someone else.
+	ListingDeleted Notification = "listing_deleted"
+)
+
+var notifications = []Notification{Welcome, ListingCreated, ListingUpdated, ListingDeleted}
+
+// The categories users turn notifications off by, as accepted by the unsubscribe endpoint.
+const (
+	CategoryListingCreated = "listing_created"
+	CategoryListingChanges = "listing_changes"
+)
+
+// category returns the category of a notification, which is empty for the notifications that
+// cannot be turned off.
+func (n Notification) category() string {
+	switch n {
+	case ListingCreated:
+		return CategoryListingCreated
+	case ListingUpdated, ListingDeleted:
+		return CategoryListingChanges
+	}
+
+	return ""
+}
+
+// Enabled reports whether prefs let notification n be sent.
+func Enabled(prefs database.NotificationPreference, n Notification) bool {
+	switch n.category() {
+	case CategoryListingCreated:
+		return prefs.ListingCreated
+	case CategoryListingChanges:
+		return prefs.ListingChanges
+	}
+
+	return true
+}
+
+// Listing is the project or event a notification is about, copied into the notification so
+// it can still be told about once deleted.
+type Listing struct {
+	// Type is project or event.
+	Type string    `json:"type"`
+	Uuid uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+	// Actor is the GitHub email of the user who made the change.
+	Actor string `json:"actor,omitempty"`
+}
+
+// ProjectListing returns the listing of a project changed by actor.
+func ProjectListing(p database.Project, actor database.User) *Listing {
+	return &Listing{Type: "project", Uuid: p.Uuid, Name: p.Name, Actor: actor.GithubEmail}
+}
+
+// EventListing returns the listing of an event changed by actor.
+func EventListing(e database.Event, actor database.User) *Listing {
+	return &Listing{Type: "event", Uuid: e.Uuid, Name: e.Name, Actor: actor.GithubEmail}
+}
+
+type SendPayload struct {
+	Notification Notification `json:"notification"`
+	UserID       int32        `json:"user_id"`
+	Listing      *Listing     `json:"listing,omitempty"`
+}
+
+// SendJob renders and sends a notification to a user.
+const SendJob jobs.Kind[SendPayload] = "notify.send"
+
+// Enqueue enqueues notification n to the user, listing is nil for the notifications that are
+// not about a listing. The preferences of the user are checked once the job runs.
+func Enqueue(ctx context.Context, st store.JobStore, userID int32, n Notification, listing *Listing) error {
+	_, err := jobs.Enqueue(ctx, st, SendJob, SendPayload{
+		Notification: n,
+		UserID:       userID,
+		Listing:      listing,
+	}, jobs.EnqueueOptions{})
+	return err
+}
+
+//go:embed templates
+var templatesFS embed.FS
+
+type notificationTemplates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// templateData is what the templates are executed with.
+type templateData struct {
+	Email   string
+	Listing *Listing
+	// Actor is the user who changed the listing, as seen by the recipient.
+	Actor          string
+	SiteURL        string
+	UnsubscribeURL string
+}
+
+// Notifier renders notifications and sends them to the users who did not turn them off.
+type Notifier struct {
+	logger          *slog.Logger
+	store           store.Store
+	transport       Transport
+	frontendBaseURL string
+	apiBaseURL      string
+	templates       map[Notification]notificationTemplates
+}
+
+// NewNotifier creates a Notifier sending emails through transport, the links of the emails
+// point at the frontend and API base URLs of the configuration.
+func NewNotifier(logger *slog.Logger, cfg awesomemy.Config, st store.Store, transport Transport) (*Notifier, error) {
+	n := &Notifier{
+		logger:          logger,
+		store:           st,
+		transport:       transport,
+		frontendBaseURL: strings.TrimSuffix(cfg.FrontendBaseURL, "/"),
+		apiBaseURL:      strings.TrimSuffix(cfg.Mail.APIBaseURL, "/"),
+		templates:       make(map[Notification]notificationTemplates, len(notifications)),
+	}
+
+	for _, notification := range notifications {
+		text, err := texttemplate.ParseFS(templatesFS, "templates/layout.txt.tmpl", "templates/"+string(notification)+".txt.tmpl")
+		if err != nil {
+			return nil, err
+		}
+
+		html, err := htmltemplate.ParseFS(templatesFS, "templates/layout.html.tmpl", "templates/"+string(notification)+".html.tmpl")
+		if err != nil {
+			return nil, err
+		}
+
+		n.templates[notification] = notificationTemplates{text: text, html: html}
+	}
+
+	return n, nil
+}
+
+// Send renders notification p and sends it, unless the user turned it off or no longer
+// exists.
+func (n *Notifier) Send(ctx context.Context, p SendPayload) error {
+	user, err := n.store.UserByID(ctx, p.UserID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	prefs, err := n.store.NotificationPreferences(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	if !Enabled(prefs, p.Notification) {
+		n.logger.Debug("notification turned off", slog.String("notification", string(p.Notification)), slog.Int("user_id", int(user.UserID)))
+		return nil
+	}
+
+	m, err := n.Render(user, prefs, p)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+
+	return n.transport.Send(ctx, m)
+}
+
+// Render renders notification p to user.
+func (n *Notifier) Render(user database.User, prefs database.NotificationPreference, p SendPayload) (Message, error) {
+	t, ok := n.templates[p.Notification]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown notification %q", p.Notification)
+	}
+	if p.Listing == nil && p.Notification != Welcome {
+		return Message{}, fmt.Errorf("notification %q is missing its listing", p.Notification)
+	}
+
+	// The unsubscribe links turn off the category of the notification, or every category for
+	// the notifications that cannot be turned off.
+	query := url.Values{}
+	if category := p.Notification.category(); category != "" {
+		query.Set("category", category)
+	}
+	listUnsubscribeURL := n.apiBaseURL + "/public/notifications/unsubscribe/" + prefs.UnsubscribeToken.String()
+	if len(query) > 0 {
+		listUnsubscribeURL += "?" + query.Encode()
+	}
+	query.Set("token", prefs.UnsubscribeToken.String())
+
+	data := templateData{
+		Email:          user.GithubEmail,
+		Listing:        p.Listing,
+		SiteURL:        n.frontendBaseURL,
+		UnsubscribeURL: n.frontendBaseURL + "/notifications/unsubscribe?" + query.Encode(),
+	}
+	if p.Listing != nil {
+		data.Actor = p.Listing.Actor
+		if strings.EqualFold(p.Listing.Actor, user.GithubEmail) {
+			data.Actor = "you"
+		}
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return Message{}, err
+	}
+
+	m := Message{
+		To:      user.GithubEmail,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}
+	if n.apiBaseURL != "" {
+		// Mail clients unsubscribe in one click by posting to the URL, see RFC 8058.
+		m.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + listUnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+
+	return m, nil
+}
+
+// Register has the worker send the notifications.
+func Register(w *jobs.Worker, n *Notifier) {
+	jobs.Handle(w, SendJob, n.Send)
+}
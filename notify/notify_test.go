@@ -0,0 +1,185 @@
+package notify_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/notify"
+	"github.com/awesome-my/backend/store"
+)
+
+// recorder is a transport keeping the messages it is asked to send.
+type recorder struct {
+	mu       sync.Mutex
+	messages []notify.Message
+}
+
+func (r *recorder) Send(ctx context.Context, m notify.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, m)
+	return nil
+}
+
+// sent returns the messages sent since the last call.
+func (r *recorder) sent() []notify.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := r.messages
+	r.messages = nil
+	return messages
+}
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestNotifierSendsEnabledNotifications(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice, _ := st.InsertUser(ctx, "alice@example.com")
+	bob, _ := st.InsertUser(ctx, "bob@example.com")
+
+	var cfg awesomemy.Config
+	cfg.FrontendBaseURL = "https://awesome.my/"
+	cfg.Mail.APIBaseURL = "https://api.awesome.my"
+	transport := &recorder{}
+	notifier, err := notify.NewNotifier(logger, cfg, st, transport)
+	if err != nil {
+		t.Fatalf("could not create notifier: %v", err)
+	}
+	w := jobs.NewWorker(logger, awesomemy.JobsConfig{}, st)
+	notify.Register(w, notifier)
+
+	work := func() []notify.Message {
+		t.Helper()
+
+		if _, err := w.Work(ctx, time.Now(), 10); err != nil {
+			t.Fatalf("could not work: %v", err)
+		}
+		return transport.sent()
+	}
+
+	project := database.Project{Name: "<Kedai> & Co"}
+	if err := notify.Enqueue(ctx, st, alice.UserID, notify.ListingUpdated, notify.ProjectListing(project, bob)); err != nil {
+		t.Fatalf("could not enqueue notification: %v", err)
+	}
+	if err := notify.Enqueue(ctx, st, alice.UserID, notify.ListingDeleted, notify.ProjectListing(project, alice)); err != nil {
+		t.Fatalf("could not enqueue notification: %v", err)
+	}
+
+	sent := work()
+	if len(sent) != 2 {
+		t.Fatalf("got %d messages, want 2", len(sent))
+	}
+	// The jobs run concurrently, in any order.
+	updated, deleted := sent[0], sent[1]
+	if strings.Contains(updated.Subject, "deleted") {
+		updated, deleted = deleted, updated
+	}
+	if updated.Subject != "Your project <Kedai> & Co was edited" || updated.To != "alice@example.com" {
+		t.Errorf("got %q to %q, want the edit notice to alice", updated.Subject, updated.To)
+	}
+	if !strings.Contains(updated.Text, "edited by bob@example.com") || !strings.Contains(deleted.Text, "deleted by you") {
+		t.Errorf("got %q and %q, want the editors named", updated.Text, deleted.Text)
+	}
+	if !strings.Contains(updated.HTML, "&lt;Kedai&gt; &amp; Co") {
+		t.Errorf("got %q, want the name escaped", updated.HTML)
+	}
+
+	prefs, _ := st.NotificationPreferences(ctx, alice.UserID)
+	token := prefs.UnsubscribeToken.String()
+	if want := "<https://api.awesome.my/public/notifications/unsubscribe/" + token + "?category=listing_changes>"; updated.Headers["List-Unsubscribe"] != want {
+		t.Errorf("got List-Unsubscribe %q, want %q", updated.Headers["List-Unsubscribe"], want)
+	}
+	if !strings.Contains(updated.Text, "https://awesome.my/notifications/unsubscribe?category=listing_changes&token="+token) {
+		t.Errorf("got %q, want the unsubscribe link of the frontend", updated.Text)
+	}
+
+	// Turned off notifications are dropped, while the welcome is always sent.
+	if _, err := st.UpdateNotificationPreferences(ctx, database.UpdateNotificationPreferencesParams{UserID: alice.UserID}); err != nil {
+		t.Fatalf("could not update preferences: %v", err)
+	}
+	for _, n := range []notify.Notification{notify.ListingCreated, notify.ListingUpdated, notify.Welcome} {
+		if err := notify.Enqueue(ctx, st, alice.UserID, n, notify.ProjectListing(project, alice)); err != nil {
+			t.Fatalf("could not enqueue notification: %v", err)
+		}
+	}
+	if sent := work(); len(sent) != 1 || sent[0].Subject != "Welcome to Awesome MY" {
+		t.Errorf("got %+v, want only the welcome sent", sent)
+	}
+
+	if dead, _ := st.DeadJobs(ctx, 10); len(dead) != 0 {
+		t.Errorf("got %+v, want no failed notification", dead)
+	}
+}
+
+func TestFileTransportWritesMIMEMessages(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	transport, err := notify.NewTransport(logger, awesomemy.MailConfig{
+		Transport: "file",
+		From:      "Awesome MY <no-reply@awesome.my>",
+		Directory: dir,
+	})
+	if err != nil {
+		t.Fatalf("could not create transport: %v", err)
+	}
+
+	err = transport.Send(context.Background(), notify.Message{
+		To:      "alice@example.com",
+		Subject: "Selamat datang ke Awesome MY",
+		Text:    "Terima kasih!",
+		HTML:    "<p>Terima kasih!</p>",
+		Headers: map[string]string{"List-Unsubscribe-Post": "List-Unsubscribe=One-Click"},
+	})
+	if err != nil {
+		t.Fatalf("could not send: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("got %v, want a single .eml file", files)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatalf("could not open message: %v", err)
+	}
+	defer f.Close()
+
+	msg, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatalf("could not parse message: %v", err)
+	}
+	if msg.Header.Get("To") != "alice@example.com" || msg.Header.Get("List-Unsubscribe-Post") != "List-Unsubscribe=One-Click" {
+		t.Errorf("got headers %v, want the recipient and extra headers", msg.Header)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("got %q, %v, want a multipart/alternative message", mediaType, err)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []string{"Terima kasih!", "<p>Terima kasih!</p>"} {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("could not read part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		if string(body) != want {
+			t.Errorf("got part %q, want %q", body, want)
+		}
+	}
+}
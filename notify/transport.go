@@ -0,0 +1,237 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/gofrs/uuid"
+)
+
+// Message is an email with a plain text and an HTML alternative.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers are added to the standard headers of the email.
+	Headers map[string]string
+}
+
+// Bytes encodes the message from from as a multipart/alternative MIME email.
+func (m Message) Bytes(from string) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	headers := map[string]string{
+		"From":         from,
+		"To":           m.To,
+		"Subject":      mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"Message-ID":   "<" + uuid.Must(uuid.NewV4()).String() + "@" + messageIDDomain(from) + ">",
+		"MIME-Version": "1.0",
+		"Content-Type": "multipart/alternative; boundary=" + mw.Boundary(),
+	}
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+
+	var header bytes.Buffer
+	for _, k := range sortedKeys(headers) {
+		if strings.ContainsAny(headers[k], "\r\n") {
+			return nil, fmt.Errorf("invalid %s header", k)
+		}
+		fmt.Fprintf(&header, "%s: %s\r\n", k, headers[k])
+	}
+	header.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return append(header.Bytes(), buf.Bytes()...), nil
+}
+
+func messageIDDomain(from string) string {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if _, domain, ok := strings.Cut(addr.Address, "@"); ok {
+			return domain
+		}
+	}
+
+	return "localhost"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Transport sends emails.
+type Transport interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// NewTransport creates the transport of the mail configuration, emails are logged when it
+// is unset.
+func NewTransport(logger *slog.Logger, cfg awesomemy.MailConfig) (Transport, error) {
+	switch cfg.Transport {
+	case "smtp":
+		return &SMTPTransport{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.From,
+		}, nil
+	case "file":
+		return &FileTransport{Directory: cfg.Directory, From: cfg.From}, nil
+	case "", "log":
+		return &LogTransport{Logger: logger}, nil
+	}
+
+	return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+}
+
+// SMTPTransport sends emails through an SMTP server, upgrading the connection with STARTTLS
+// when the server supports it. It authenticates when a username is set.
+type SMTPTransport struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (t *SMTPTransport) Send(ctx context.Context, m Message) error {
+	from, err := mail.ParseAddress(t.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	msg, err := m.Bytes(t.From)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.Host, strconv.Itoa(t.Port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: t.Host}); err != nil {
+			return err
+		}
+	}
+	if t.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", t.Username, t.Password, t.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// FileTransport writes every email to a .eml file of Directory, which is created when
+// missing, for local development.
+type FileTransport struct {
+	Directory string
+	From      string
+}
+
+func (t *FileTransport) Send(ctx context.Context, m Message) error {
+	msg, err := m.Bytes(t.From)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(t.Directory, 0o755); err != nil {
+		return err
+	}
+
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + uuid.Must(uuid.NewV4()).String() + ".eml"
+	return os.WriteFile(filepath.Join(t.Directory, name), msg, 0o644)
+}
+
+// LogTransport logs emails instead of sending them, for local development.
+type LogTransport struct {
+	Logger *slog.Logger
+}
+
+func (t *LogTransport) Send(ctx context.Context, m Message) error {
+	t.Logger.Info("email", slog.String("to", m.To), slog.String("subject", m.Subject), slog.String("text", m.Text))
+	return nil
+}
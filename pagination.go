@@ -38,3 +38,12 @@ func NewPaginationMeta(currentPage, count, total int) PaginationMeta {
 		Total:       total,
 	}
 }
+
+// CursorPaginationMeta describes a page of a list paginated with cursors, which keeps its
+// place while records are added to the list.
+type CursorPaginationMeta struct {
+	// NextCursor is passed as the cursor query parameter to fetch the next page, it is empty
+	// on the last page.
+	NextCursor string `json:"next_cursor"`
+	Count      int    `json:"count"`
+}
@@ -0,0 +1,208 @@
+// Package repostats enriches projects with the stats of their GitHub repository, such as
+// stars, forks and the date of the last push.
+package repostats
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+	"github.com/google/go-github/v55/github"
+)
+
+const (
+	// DefaultRefreshAfter is how long fetched stats are kept before being fetched again when
+	// the configuration leaves it unset.
+	DefaultRefreshAfter = 6 * time.Hour
+	// DefaultBatchSize is how many projects are enriched per run when the configuration
+	// leaves it unset.
+	DefaultBatchSize = 50
+)
+
+// Enricher fetches the stats of the GitHub repositories of projects, sending the ETag of
+// the previous response so unchanged repositories are answered with 304 Not Modified,
+// which does not count against the rate limit.
+type Enricher struct {
+	logger       *slog.Logger
+	store        store.RepositoryStatsStore
+	client       *github.Client
+	refreshAfter time.Duration
+	batchSize    int32
+}
+
+// NewEnricher creates an Enricher from the GitHub configuration, unset enrichment settings
+// take their default.
+func NewEnricher(logger *slog.Logger, cfg awesomemy.GitHubConfig, st store.RepositoryStatsStore) (*Enricher, error) {
+	client := github.NewClient(&http.Client{Timeout: 30 * time.Second}).WithAuthToken(cfg.Token)
+	if cfg.APIURL != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(cfg.APIURL, "/") + "/")
+		if err != nil {
+			return nil, err
+		}
+		client.BaseURL = baseURL
+	}
+
+	refreshAfter := cfg.Enrichment.RefreshAfter.Duration
+	if refreshAfter == 0 {
+		refreshAfter = DefaultRefreshAfter
+	}
+	batchSize := cfg.Enrichment.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Enricher{
+		logger:       logger,
+		store:        st,
+		client:       client,
+		refreshAfter: refreshAfter,
+		batchSize:    int32(batchSize),
+	}, nil
+}
+
+// Enrich fetches the stats of a batch of projects whose stats are missing or older than the
+// refresh period, returning how many were fetched. It stops early without an error when
+// GitHub rate limits the requests, the remaining projects are picked up by a later run.
+func (e *Enricher) Enrich(ctx context.Context) (int, error) {
+	now := time.Now()
+	projects, err := e.store.ProjectsDueForRepositoryStats(ctx, now.Add(-e.refreshAfter), e.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var enriched int
+	for _, p := range projects {
+		if err := e.enrich(ctx, p, now); err != nil {
+			var rateLimitErr *github.RateLimitError
+			var abuseRateLimitErr *github.AbuseRateLimitError
+			if errors.As(err, &rateLimitErr) || errors.As(err, &abuseRateLimitErr) {
+				e.logger.Warn("github rate limited the repository enrichment", slog.Any("err", err))
+				return enriched, nil
+			}
+			if ctx.Err() != nil {
+				return enriched, ctx.Err()
+			}
+
+			e.logger.Error("could not enrich project repository", slog.Int("project_id", int(p.ProjectID)), slog.Any("err", err))
+
+			// The failure is recorded so the project is tried again once the refresh period
+			// has passed, rather than staying at the head of every batch.
+			if err := e.store.TouchProjectRepositoryStats(ctx, p.ProjectID, p.Repository.String, now); err != nil {
+				e.logger.Error("could not record project repository failure", slog.Int("project_id", int(p.ProjectID)), slog.Any("err", err))
+			}
+			continue
+		}
+
+		enriched++
+	}
+
+	return enriched, nil
+}
+
+func (e *Enricher) enrich(ctx context.Context, p database.ProjectsDueForRepositoryStatsRow, now time.Time) error {
+	owner, name, ok := ParseRepository(p.Repository.String)
+	if !ok {
+		// Repositories hosted elsewhere are recorded as not found, so they are only looked
+		// at again once the refresh period has passed.
+		return e.notFound(ctx, p, now)
+	}
+
+	req, err := e.client.NewRequest(http.MethodGet, "repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+	if p.Etag.Valid {
+		req.Header.Set("If-None-Match", p.Etag.String)
+	}
+
+	var repo github.Repository
+	resp, err := e.client.Do(ctx, req, &repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotModified {
+			return e.store.TouchProjectRepositoryStats(ctx, p.ProjectID, p.Repository.String, now)
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return e.notFound(ctx, p, now)
+		}
+
+		return err
+	}
+
+	var pushedAt nulls.Time
+	if !repo.GetPushedAt().IsZero() {
+		pushedAt = nulls.NewTime(repo.GetPushedAt().Time)
+	}
+
+	// GitHub reports licenses it cannot identify as NOASSERTION.
+	var license nulls.String
+	if spdxID := repo.GetLicense().GetSPDXID(); spdxID != "" && spdxID != "NOASSERTION" {
+		license = nulls.NewString(spdxID)
+	}
+
+	var language nulls.String
+	if repo.GetLanguage() != "" {
+		language = nulls.NewString(repo.GetLanguage())
+	}
+
+	var etag nulls.String
+	if resp.Header.Get("ETag") != "" {
+		etag = nulls.NewString(resp.Header.Get("ETag"))
+	}
+
+	_, err = e.store.UpsertProjectRepositoryStats(ctx, database.UpsertProjectRepositoryStatsParams{
+		ProjectID:  p.ProjectID,
+		Repository: p.Repository.String,
+		Found:      true,
+		Stars:      int32(repo.GetStargazersCount()),
+		Forks:      int32(repo.GetForksCount()),
+		Language:   language,
+		Topics:     repo.Topics,
+		License:    license,
+		PushedAt:   pushedAt,
+		Etag:       etag,
+		FetchedAt:  now,
+	})
+	return err
+}
+
+func (e *Enricher) notFound(ctx context.Context, p database.ProjectsDueForRepositoryStatsRow, now time.Time) error {
+	_, err := e.store.UpsertProjectRepositoryStats(ctx, database.UpsertProjectRepositoryStatsParams{
+		ProjectID:  p.ProjectID,
+		Repository: p.Repository.String,
+		Found:      false,
+		FetchedAt:  now,
+	})
+	return err
+}
+
+// ParseRepository returns the owner and name of a github.com repository URL, such as
+// https://github.com/awesome-my/backend, ignoring a .git suffix and any further path.
+func ParseRepository(repository string) (owner, name string, ok bool) {
+	u, err := url.Parse(repository)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", "", false
+	}
+	if host := strings.ToLower(u.Hostname()); host != "github.com" && host != "www.github.com" {
+		return "", "", false
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return "", "", false
+	}
+
+	owner, name = segments[0], strings.TrimSuffix(segments[1], ".git")
+	if owner == "" || name == "" {
+		return "", "", false
+	}
+
+	return owner, name, true
+}
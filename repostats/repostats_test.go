@@ -0,0 +1,172 @@
+package repostats_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/githubtest"
+	"github.com/awesome-my/backend/repostats"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+)
+
+func TestParseRepository(t *testing.T) {
+	for _, tc := range []struct {
+		repository  string
+		owner, name string
+		ok          bool
+	}{
+		{"https://github.com/awesome-my/backend", "awesome-my", "backend", true},
+		{"https://www.github.com/awesome-my/backend.git", "awesome-my", "backend", true},
+		{"https://github.com/awesome-my/backend/tree/main/", "awesome-my", "backend", true},
+		{"https://github.com/awesome-my", "", "", false},
+		{"https://gitlab.com/awesome-my/backend", "", "", false},
+		{"git@github.com:awesome-my/backend.git", "", "", false},
+	} {
+		owner, name, ok := repostats.ParseRepository(tc.repository)
+		if owner != tc.owner || name != tc.name || ok != tc.ok {
+			t.Errorf("ParseRepository(%q) = %q, %q, %t, want %q, %q, %t", tc.repository, owner, name, ok, tc.owner, tc.name, tc.ok)
+		}
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	user, err := st.InsertUser(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatalf("could not insert user: %v", err)
+	}
+
+	insertProject := func(name, repository string) database.Project {
+		t.Helper()
+
+		project, err := st.InsertProject(ctx, database.InsertProjectParams{
+			Name:        name,
+			Description: "A project made in Malaysia.",
+			Repository:  nulls.NewString(repository),
+			UserID:      user.UserID,
+		})
+		if err != nil {
+			t.Fatalf("could not insert project: %v", err)
+		}
+
+		return project
+	}
+	backend := insertProject("Awesome MY Backend", "https://github.com/awesome-my/backend")
+	missing := insertProject("Missing Project", "https://github.com/awesome-my/missing")
+	elsewhere := insertProject("Elsewhere Project", "https://gitlab.com/awesome-my/elsewhere")
+
+	gh := githubtest.NewServer("alice@example.com")
+	t.Cleanup(gh.Close)
+	gh.SetRepository("awesome-my", "backend", map[string]any{
+		"stargazers_count": 42,
+		"forks_count":      7,
+		"language":         "Go",
+		"topics":           []string{"malaysia", "startups"},
+		"license":          map[string]any{"spdx_id": "MIT"},
+		"pushed_at":        "2026-10-01T08:00:00Z",
+	})
+
+	var cfg awesomemy.Config
+	gh.Configure(&cfg)
+	cfg.GitHub.Enrichment.RefreshAfter = awesomemy.Duration{Duration: -time.Second}
+	enricher, err := repostats.NewEnricher(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg.GitHub, st)
+	if err != nil {
+		t.Fatalf("could not create enricher: %v", err)
+	}
+
+	if n, err := enricher.Enrich(ctx); err != nil || n != 3 {
+		t.Fatalf("got %d, %v, want 3 projects enriched", n, err)
+	}
+
+	stats, err := st.ProjectRepositoryStats(ctx, []int32{backend.ProjectID, missing.ProjectID, elsewhere.ProjectID})
+	if err != nil {
+		t.Fatalf("could not fetch project repository stats: %v", err)
+	}
+	if len(stats) != 1 || stats[0].ProjectID != backend.ProjectID {
+		t.Fatalf("got %v, want only the stats of the repository found", stats)
+	}
+	got := stats[0]
+	if got.Stars != 42 || got.Forks != 7 || got.Language.String != "Go" || got.License.String != "MIT" || !slices.Equal(got.Topics, []string{"malaysia", "startups"}) {
+		t.Errorf("got %+v, want the stats of awesome-my/backend", got)
+	}
+	if want := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC); !got.PushedAt.Time.Equal(want) {
+		t.Errorf("got pushed at %v, want %v", got.PushedAt.Time, want)
+	}
+
+	// Every project is due again since nothing is kept, the unchanged repository is
+	// answered with 304 Not Modified.
+	if n, err := enricher.Enrich(ctx); err != nil || n != 3 {
+		t.Fatalf("got %d, %v, want 3 projects enriched", n, err)
+	}
+	if total, notModified := gh.RepositoryRequests(); total != 4 || notModified != 1 {
+		t.Errorf("got %d requests with %d not modified, want 4 with 1", total, notModified)
+	}
+
+	// Stats no longer apply once the project points at another repository.
+	if _, err := st.UpdateProject(ctx, database.UpdateProjectParams{
+		Name:        backend.Name,
+		Description: backend.Description,
+		Repository:  nulls.NewString("https://github.com/awesome-my/frontend"),
+		ProjectID:   backend.ProjectID,
+	}); err != nil {
+		t.Fatalf("could not update project: %v", err)
+	}
+	if stats, _ := st.ProjectRepositoryStats(ctx, []int32{backend.ProjectID}); len(stats) != 0 {
+		t.Errorf("got %v, want no stats for the new repository", stats)
+	}
+}
+
+func TestEnrichFailure(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	user, err := st.InsertUser(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatalf("could not insert user: %v", err)
+	}
+
+	project, err := st.InsertProject(ctx, database.InsertProjectParams{
+		Name:        "Awesome MY Backend",
+		Description: "A project made in Malaysia.",
+		Repository:  nulls.NewString("https://github.com/awesome-my/backend"),
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		t.Fatalf("could not insert project: %v", err)
+	}
+
+	gh := githubtest.NewServer("alice@example.com")
+	t.Cleanup(gh.Close)
+	gh.FailRepository("awesome-my", "backend", http.StatusInternalServerError)
+
+	var cfg awesomemy.Config
+	gh.Configure(&cfg)
+	enricher, err := repostats.NewEnricher(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg.GitHub, st)
+	if err != nil {
+		t.Fatalf("could not create enricher: %v", err)
+	}
+
+	if n, err := enricher.Enrich(ctx); err != nil || n != 0 {
+		t.Fatalf("got %d, %v, want no project enriched", n, err)
+	}
+
+	// The failure is recorded, so the project waits for the refresh period like the others.
+	due, err := st.ProjectsDueForRepositoryStats(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("could not fetch projects due for repository stats: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("got %v, want project %d no longer due", due, project.ProjectID)
+	}
+	if total, _ := gh.RepositoryRequests(); total != 1 {
+		t.Errorf("got %d requests, want 1", total)
+	}
+}
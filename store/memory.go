@@ -0,0 +1,1995 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// Memory implements Store in memory for tests, mirroring the behaviour of the Postgres
+// queries. A transaction works on a copy of the records that replaces them when it commits,
+// so writes made outside of a running transaction are lost when it commits.
+type Memory struct {
+	mu    sync.Mutex
+	txMu  sync.Mutex
+	inTx  bool
+	state *memoryState
+}
+
+var _ Store = (*Memory)(nil)
+
+// errDuplicate mirrors the unique constraint violations of Postgres.
+var errDuplicate = errors.New("duplicate key value violates unique constraint")
+
+type memoryState struct {
+	users        []database.User
+	userSessions []database.UserSession
+	projects     []database.Project
+	members      []database.ProjectMember
+	invitations  []database.ProjectInvitation
+	events       []database.Event
+
+	organisations       []database.Organisation
+	organisationMembers []database.OrganisationMember
+	repositoryStats     []database.ProjectRepositoryStat
+	jobs                []database.Job
+	webhooks            []database.Webhook
+	webhookDeliveries   []database.WebhookDelivery
+	notificationPrefs   []database.NotificationPreference
+	follows             []database.Follow
+	bookmarks           []database.Bookmark
+	collections         []database.Collection
+	collectionItems     []database.CollectionItem
+
+	lastUserID              int32
+	lastUserSessionID       int32
+	lastProjectID           int32
+	lastProjectMemberID     int32
+	lastProjectInvitationID int32
+	lastEventID             int32
+
+	lastOrganisationID       int32
+	lastOrganisationMemberID int32
+	lastJobID                int32
+	lastWebhookID            int32
+	lastWebhookDeliveryID    int32
+	lastFollowID             int32
+	lastBookmarkID           int32
+	lastCollectionID         int32
+	lastCollectionItemID     int32
+}
+
+func (s *memoryState) clone() *memoryState {
+	c := *s
+	c.users = slices.Clone(s.users)
+	c.userSessions = slices.Clone(s.userSessions)
+	c.projects = slices.Clone(s.projects)
+	c.members = slices.Clone(s.members)
+	c.invitations = slices.Clone(s.invitations)
+	c.events = slices.Clone(s.events)
+	c.organisations = slices.Clone(s.organisations)
+	c.organisationMembers = slices.Clone(s.organisationMembers)
+	c.repositoryStats = slices.Clone(s.repositoryStats)
+	c.jobs = slices.Clone(s.jobs)
+	c.webhooks = slices.Clone(s.webhooks)
+	c.webhookDeliveries = slices.Clone(s.webhookDeliveries)
+	c.notificationPrefs = slices.Clone(s.notificationPrefs)
+	c.follows = slices.Clone(s.follows)
+	c.bookmarks = slices.Clone(s.bookmarks)
+	c.collections = slices.Clone(s.collections)
+	c.collectionItems = slices.Clone(s.collectionItems)
+
+	return &c
+}
+
+// deleteProjects deletes the projects matching match, mirroring the ON DELETE CASCADE
+// relations of the projects table.
+func (s *memoryState) deleteProjects(match func(database.Project) bool) {
+	var projectIDs []int32
+	s.projects = slices.DeleteFunc(s.projects, func(p database.Project) bool {
+		if match(p) {
+			projectIDs = append(projectIDs, p.ProjectID)
+			return true
+		}
+		return false
+	})
+
+	s.members = slices.DeleteFunc(s.members, func(pm database.ProjectMember) bool {
+		return slices.Contains(projectIDs, pm.ProjectID)
+	})
+	s.invitations = slices.DeleteFunc(s.invitations, func(pi database.ProjectInvitation) bool {
+		return slices.Contains(projectIDs, pi.ProjectID)
+	})
+	s.repositoryStats = slices.DeleteFunc(s.repositoryStats, func(rs database.ProjectRepositoryStat) bool {
+		return slices.Contains(projectIDs, rs.ProjectID)
+	})
+	s.bookmarks = slices.DeleteFunc(s.bookmarks, func(b database.Bookmark) bool {
+		return b.ProjectID.Valid && slices.Contains(projectIDs, b.ProjectID.Int32)
+	})
+	s.collectionItems = slices.DeleteFunc(s.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.ProjectID.Valid && slices.Contains(projectIDs, ci.ProjectID.Int32)
+	})
+}
+
+// deleteEvents deletes the events matching match, mirroring the ON DELETE CASCADE relations
+// of the events table.
+func (s *memoryState) deleteEvents(match func(database.Event) bool) {
+	var eventIDs []int32
+	s.events = slices.DeleteFunc(s.events, func(e database.Event) bool {
+		if match(e) {
+			eventIDs = append(eventIDs, e.EventID)
+			return true
+		}
+		return false
+	})
+
+	s.bookmarks = slices.DeleteFunc(s.bookmarks, func(b database.Bookmark) bool {
+		return b.EventID.Valid && slices.Contains(eventIDs, b.EventID.Int32)
+	})
+	s.collectionItems = slices.DeleteFunc(s.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.EventID.Valid && slices.Contains(eventIDs, ci.EventID.Int32)
+	})
+}
+
+func NewMemory() *Memory {
+	return &Memory{state: &memoryState{}}
+}
+
+func (m *Memory) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *Memory) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if m.inTx {
+		return fn(m)
+	}
+
+	m.txMu.Lock()
+	defer m.txMu.Unlock()
+
+	m.mu.Lock()
+	tx := &Memory{inTx: true, state: m.state.clone()}
+	m.mu.Unlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.state = tx.state
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Memory) UserByUUID(ctx context.Context, userUuid uuid.UUID) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.users, func(u database.User) bool { return u.Uuid == userUuid })
+}
+
+func (m *Memory) UserByID(ctx context.Context, userID int32) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.users, func(u database.User) bool { return u.UserID == userID })
+}
+
+func (m *Memory) UserByGithubEmail(ctx context.Context, githubEmail string) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.users, func(u database.User) bool { return strings.EqualFold(u.GithubEmail, githubEmail) })
+}
+
+func (m *Memory) InsertUser(ctx context.Context, githubEmail string) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastUserID++
+	user := database.User{
+		UserID:      m.state.lastUserID,
+		Uuid:        uuid.Must(uuid.NewV4()),
+		GithubEmail: githubEmail,
+		CreatedAt:   time.Now(),
+	}
+	m.state.users = append(m.state.users, user)
+
+	return user, nil
+}
+
+func (m *Memory) ScheduleUserDeletion(ctx context.Context, arg database.ScheduleUserDeletionParams) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.users, func(u database.User) bool { return u.UserID == arg.UserID }, func(u *database.User) {
+		u.DeletesAt = arg.DeletesAt
+	})
+}
+
+func (m *Memory) CancelUserDeletion(ctx context.Context, userID int32) (database.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.users, func(u database.User) bool { return u.UserID == userID }, func(u *database.User) {
+		u.DeletesAt.Valid = false
+	})
+}
+
+func (m *Memory) DeleteDueUsers(ctx context.Context, now time.Time) ([]int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var userIDs []int32
+	m.state.users = slices.DeleteFunc(m.state.users, func(u database.User) bool {
+		if u.DeletesAt.Valid && !u.DeletesAt.Time.After(now) {
+			userIDs = append(userIDs, u.UserID)
+			return true
+		}
+		return false
+	})
+
+	// Mirror the ON DELETE CASCADE relations of the users table.
+	m.state.userSessions = slices.DeleteFunc(m.state.userSessions, func(s database.UserSession) bool {
+		return slices.Contains(userIDs, s.UserID)
+	})
+	m.state.deleteProjects(func(p database.Project) bool {
+		return slices.Contains(userIDs, p.UserID)
+	})
+	m.state.members = slices.DeleteFunc(m.state.members, func(pm database.ProjectMember) bool {
+		return slices.Contains(userIDs, pm.UserID)
+	})
+	m.state.invitations = slices.DeleteFunc(m.state.invitations, func(pi database.ProjectInvitation) bool {
+		return slices.Contains(userIDs, pi.InvitedBy)
+	})
+	m.state.deleteEvents(func(e database.Event) bool {
+		return slices.Contains(userIDs, e.UserID)
+	})
+	m.state.organisationMembers = slices.DeleteFunc(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return slices.Contains(userIDs, om.UserID)
+	})
+	m.state.deleteWebhooks(func(wh database.Webhook) bool {
+		return slices.Contains(userIDs, wh.UserID)
+	})
+	m.state.notificationPrefs = slices.DeleteFunc(m.state.notificationPrefs, func(np database.NotificationPreference) bool {
+		return slices.Contains(userIDs, np.UserID)
+	})
+	m.state.follows = slices.DeleteFunc(m.state.follows, func(f database.Follow) bool {
+		return slices.Contains(userIDs, f.FollowerID) || f.FollowedUserID.Valid && slices.Contains(userIDs, f.FollowedUserID.Int32)
+	})
+	m.state.bookmarks = slices.DeleteFunc(m.state.bookmarks, func(b database.Bookmark) bool {
+		return slices.Contains(userIDs, b.UserID)
+	})
+	m.state.deleteCollections(func(c database.Collection) bool {
+		return slices.Contains(userIDs, c.UserID)
+	})
+
+	return userIDs, nil
+}
+
+func (m *Memory) CountUsers(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(m.state.users)), nil
+}
+
+func (m *Memory) InsertUserSession(ctx context.Context, arg database.InsertUserSessionParams) (database.UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastUserSessionID++
+	session := database.UserSession{
+		UserSessionID: m.state.lastUserSessionID,
+		Uuid:          uuid.Must(uuid.NewV4()),
+		IpAddress:     arg.IpAddress,
+		UserAgent:     arg.UserAgent,
+		CreatedAt:     time.Now(),
+		LastSeenAt:    arg.LastSeenAt,
+		ExpiresAt:     arg.ExpiresAt,
+		UserID:        arg.UserID,
+		Token:         arg.Token,
+	}
+	m.state.userSessions = append(m.state.userSessions, session)
+
+	return session, nil
+}
+
+func (m *Memory) UserSessionByUUID(ctx context.Context, sessionUuid uuid.UUID) (database.UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.userSessions, func(s database.UserSession) bool { return s.Uuid == sessionUuid })
+}
+
+func (m *Memory) UserSessions(ctx context.Context, userID int32, now time.Time) ([]database.UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := filter(m.state.userSessions, func(s database.UserSession) bool {
+		return s.UserID == userID && s.ExpiresAt.After(now)
+	})
+	slices.SortStableFunc(sessions, func(a, b database.UserSession) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+func (m *Memory) TouchUserSession(ctx context.Context, arg database.TouchUserSessionParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := update(m.state.userSessions, func(s database.UserSession) bool { return s.Uuid == arg.Uuid }, func(s *database.UserSession) {
+		s.Token = arg.Token
+		s.IpAddress = arg.IpAddress
+		s.UserAgent = arg.UserAgent
+		s.LastSeenAt = arg.LastSeenAt
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (m *Memory) DeleteUserSession(ctx context.Context, sessionUuid uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.userSessions = slices.DeleteFunc(m.state.userSessions, func(s database.UserSession) bool {
+		return s.Uuid == sessionUuid
+	})
+
+	return nil
+}
+
+func (m *Memory) DeleteUserSessions(ctx context.Context, userID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.userSessions = slices.DeleteFunc(m.state.userSessions, func(s database.UserSession) bool {
+		return s.UserID == userID
+	})
+
+	return nil
+}
+
+func (m *Memory) DeleteExpiredUserSessions(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.userSessions = slices.DeleteFunc(m.state.userSessions, func(s database.UserSession) bool {
+		return !s.ExpiresAt.After(now)
+	})
+
+	return nil
+}
+
+func (m *Memory) Projects(ctx context.Context, tags []string, page Page) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.projects, func(p database.Project) bool {
+		return len(tags) == 0 || overlaps(p.Tags, tags)
+	}), page), nil
+}
+
+func (m *Memory) CountProjects(ctx context.Context, tags []string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.projects, func(p database.Project) bool {
+		return len(tags) == 0 || overlaps(p.Tags, tags)
+	}))), nil
+}
+
+func (m *Memory) UserProjects(ctx context.Context, userID int32, page Page) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.projects, func(p database.Project) bool { return p.UserID == userID }), page), nil
+}
+
+func (m *Memory) AllUserProjects(ctx context.Context, userID int32) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.projects, func(p database.Project) bool { return p.UserID == userID }), nil
+}
+
+func (m *Memory) CountUserProjects(ctx context.Context, userID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.projects, func(p database.Project) bool { return p.UserID == userID }))), nil
+}
+
+func (m *Memory) ProjectByUUID(ctx context.Context, projectUuid uuid.UUID) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.projects, func(p database.Project) bool { return p.Uuid == projectUuid })
+}
+
+func (m *Memory) ProjectsByIDs(ctx context.Context, projectIDs []int32) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.projects, func(p database.Project) bool {
+		return slices.Contains(projectIDs, p.ProjectID)
+	}), nil
+}
+
+func (m *Memory) UserProjectByName(ctx context.Context, userID int32, name string) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.projects, func(p database.Project) bool { return p.UserID == userID && p.Name == name })
+}
+
+func (m *Memory) InsertProject(ctx context.Context, arg database.InsertProjectParams) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastProjectID++
+	project := database.Project{
+		ProjectID:   m.state.lastProjectID,
+		Uuid:        uuid.Must(uuid.NewV4()),
+		Name:        arg.Name,
+		Description: arg.Description,
+		Tags:        slices.Clone(arg.Tags),
+		UserID:      arg.UserID,
+		CreatedAt:   time.Now(),
+		Repository:  arg.Repository,
+		Website:     arg.Website,
+	}
+	m.state.projects = append(m.state.projects, project)
+
+	return project, nil
+}
+
+func (m *Memory) UpdateProject(ctx context.Context, arg database.UpdateProjectParams) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.projects, func(p database.Project) bool { return p.ProjectID == arg.ProjectID }, func(p *database.Project) {
+		p.Name = arg.Name
+		p.Description = arg.Description
+		p.Tags = slices.Clone(arg.Tags)
+		p.Repository = arg.Repository
+		p.Website = arg.Website
+	})
+}
+
+func (m *Memory) DeleteProject(ctx context.Context, projectID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.deleteProjects(func(p database.Project) bool {
+		return p.ProjectID == projectID
+	})
+
+	return nil
+}
+
+func (m *Memory) OrganisationProjects(ctx context.Context, organisationID int32, page Page) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.projects, func(p database.Project) bool {
+		return p.OrganisationID == nulls.NewInt32(organisationID)
+	}), page), nil
+}
+
+func (m *Memory) CountOrganisationProjects(ctx context.Context, organisationID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.projects, func(p database.Project) bool {
+		return p.OrganisationID == nulls.NewInt32(organisationID)
+	}))), nil
+}
+
+func (m *Memory) SetProjectOrganisation(ctx context.Context, projectID int32, organisationID nulls.Int32) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.projects, func(p database.Project) bool { return p.ProjectID == projectID }, func(p *database.Project) {
+		p.OrganisationID = organisationID
+	})
+}
+
+func (m *Memory) ProjectMembers(ctx context.Context, projectID int32) ([]database.ProjectMembersRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rows []database.ProjectMembersRow
+	for _, pm := range m.state.members {
+		if pm.ProjectID != projectID {
+			continue
+		}
+
+		user, err := first(m.state.users, func(u database.User) bool { return u.UserID == pm.UserID })
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, database.ProjectMembersRow{
+			ProjectMemberID: pm.ProjectMemberID,
+			Role:            pm.Role,
+			CreatedAt:       pm.CreatedAt,
+			ProjectID:       pm.ProjectID,
+			UserID:          pm.UserID,
+			UserUuid:        user.Uuid,
+			GithubEmail:     user.GithubEmail,
+		})
+	}
+
+	return rows, nil
+}
+
+func (m *Memory) ProjectMember(ctx context.Context, projectID, userID int32) (database.ProjectMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.members, func(pm database.ProjectMember) bool {
+		return pm.ProjectID == projectID && pm.UserID == userID
+	})
+}
+
+func (m *Memory) InsertProjectMember(ctx context.Context, arg database.InsertProjectMemberParams) (database.ProjectMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.members, func(pm database.ProjectMember) bool {
+		return pm.ProjectID == arg.ProjectID && pm.UserID == arg.UserID
+	}) {
+		return database.ProjectMember{}, errDuplicate
+	}
+
+	m.state.lastProjectMemberID++
+	member := database.ProjectMember{
+		ProjectMemberID: m.state.lastProjectMemberID,
+		Role:            arg.Role,
+		CreatedAt:       time.Now(),
+		ProjectID:       arg.ProjectID,
+		UserID:          arg.UserID,
+	}
+	m.state.members = append(m.state.members, member)
+
+	return member, nil
+}
+
+func (m *Memory) UpdateProjectMemberRole(ctx context.Context, arg database.UpdateProjectMemberRoleParams) (database.ProjectMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.members, func(pm database.ProjectMember) bool {
+		return pm.ProjectID == arg.ProjectID && pm.UserID == arg.UserID
+	}, func(pm *database.ProjectMember) {
+		pm.Role = arg.Role
+	})
+}
+
+func (m *Memory) DeleteProjectMember(ctx context.Context, projectID, userID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.members = slices.DeleteFunc(m.state.members, func(pm database.ProjectMember) bool {
+		return pm.ProjectID == projectID && pm.UserID == userID
+	})
+
+	return nil
+}
+
+func (m *Memory) MemberProjects(ctx context.Context, userID int32) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	projects := filter(m.state.projects, func(p database.Project) bool {
+		return slices.ContainsFunc(m.state.members, func(pm database.ProjectMember) bool {
+			return pm.ProjectID == p.ProjectID && pm.UserID == userID
+		})
+	})
+	slices.Reverse(projects)
+
+	return projects, nil
+}
+
+func (m *Memory) TransferProject(ctx context.Context, projectID, userID int32) (database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.projects, func(p database.Project) bool { return p.ProjectID == projectID }, func(p *database.Project) {
+		p.UserID = userID
+	})
+}
+
+func (m *Memory) ProjectInvitations(ctx context.Context, projectID int32) ([]database.ProjectInvitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.invitations, func(pi database.ProjectInvitation) bool { return pi.ProjectID == projectID }), nil
+}
+
+func (m *Memory) ProjectInvitationByUUID(ctx context.Context, invitationUuid uuid.UUID) (database.ProjectInvitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.invitations, func(pi database.ProjectInvitation) bool { return pi.Uuid == invitationUuid })
+}
+
+func (m *Memory) ProjectInvitationByEmail(ctx context.Context, projectID int32, email string) (database.ProjectInvitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.invitations, func(pi database.ProjectInvitation) bool {
+		return pi.ProjectID == projectID && pi.Email == email
+	})
+}
+
+func (m *Memory) UserProjectInvitations(ctx context.Context, email string) ([]database.UserProjectInvitationsRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rows []database.UserProjectInvitationsRow
+	for _, pi := range m.state.invitations {
+		if pi.Email != email {
+			continue
+		}
+
+		project, err := first(m.state.projects, func(p database.Project) bool { return p.ProjectID == pi.ProjectID })
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, database.UserProjectInvitationsRow{
+			ProjectInvitationID: pi.ProjectInvitationID,
+			Uuid:                pi.Uuid,
+			Email:               pi.Email,
+			Role:                pi.Role,
+			CreatedAt:           pi.CreatedAt,
+			ProjectID:           pi.ProjectID,
+			InvitedBy:           pi.InvitedBy,
+			ProjectUuid:         project.Uuid,
+			ProjectName:         project.Name,
+		})
+	}
+
+	return rows, nil
+}
+
+func (m *Memory) InsertProjectInvitation(ctx context.Context, arg database.InsertProjectInvitationParams) (database.ProjectInvitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.invitations, func(pi database.ProjectInvitation) bool {
+		return pi.ProjectID == arg.ProjectID && pi.Email == arg.Email
+	}) {
+		return database.ProjectInvitation{}, errDuplicate
+	}
+
+	m.state.lastProjectInvitationID++
+	invitation := database.ProjectInvitation{
+		ProjectInvitationID: m.state.lastProjectInvitationID,
+		Uuid:                uuid.Must(uuid.NewV4()),
+		Email:               arg.Email,
+		Role:                arg.Role,
+		CreatedAt:           time.Now(),
+		ProjectID:           arg.ProjectID,
+		InvitedBy:           arg.InvitedBy,
+	}
+	m.state.invitations = append(m.state.invitations, invitation)
+
+	return invitation, nil
+}
+
+func (m *Memory) DeleteProjectInvitation(ctx context.Context, projectInvitationID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.invitations = slices.DeleteFunc(m.state.invitations, func(pi database.ProjectInvitation) bool {
+		return pi.ProjectInvitationID == projectInvitationID
+	})
+
+	return nil
+}
+
+func (m *Memory) Events(ctx context.Context, tags []string, page Page) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.events, func(e database.Event) bool {
+		return len(tags) == 0 || overlaps(e.Tags, tags)
+	}), page), nil
+}
+
+func (m *Memory) CountEvents(ctx context.Context, tags []string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.events, func(e database.Event) bool {
+		return len(tags) == 0 || overlaps(e.Tags, tags)
+	}))), nil
+}
+
+func (m *Memory) UserEvents(ctx context.Context, userID int32, page Page) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.events, func(e database.Event) bool { return e.UserID == userID }), page), nil
+}
+
+func (m *Memory) AllUserEvents(ctx context.Context, userID int32) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.events, func(e database.Event) bool { return e.UserID == userID }), nil
+}
+
+func (m *Memory) CountUserEvents(ctx context.Context, userID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.events, func(e database.Event) bool { return e.UserID == userID }))), nil
+}
+
+func (m *Memory) EventByUUID(ctx context.Context, eventUuid uuid.UUID) (database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.events, func(e database.Event) bool { return e.Uuid == eventUuid })
+}
+
+func (m *Memory) EventsByIDs(ctx context.Context, eventIDs []int32) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.events, func(e database.Event) bool {
+		return slices.Contains(eventIDs, e.EventID)
+	}), nil
+}
+
+func (m *Memory) UserEventByName(ctx context.Context, userID int32, name string) (database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.events, func(e database.Event) bool { return e.UserID == userID && e.Name == name })
+}
+
+func (m *Memory) InsertEvent(ctx context.Context, arg database.InsertEventParams) (database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastEventID++
+	event := database.Event{
+		EventID:     m.state.lastEventID,
+		Uuid:        uuid.Must(uuid.NewV4()),
+		Name:        arg.Name,
+		Description: arg.Description,
+		Tags:        slices.Clone(arg.Tags),
+		StartsAt:    arg.StartsAt,
+		EndsAt:      arg.EndsAt,
+		CreatedAt:   time.Now(),
+		Website:     arg.Website,
+		UserID:      arg.UserID,
+	}
+	m.state.events = append(m.state.events, event)
+
+	return event, nil
+}
+
+func (m *Memory) UpdateEvent(ctx context.Context, arg database.UpdateEventParams) (database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.events, func(e database.Event) bool { return e.EventID == arg.EventID }, func(e *database.Event) {
+		e.Name = arg.Name
+		e.Description = arg.Description
+		e.Tags = slices.Clone(arg.Tags)
+		e.Website = arg.Website
+		e.StartsAt = arg.StartsAt
+		e.EndsAt = arg.EndsAt
+	})
+}
+
+func (m *Memory) DeleteEvent(ctx context.Context, eventID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.deleteEvents(func(e database.Event) bool {
+		return e.EventID == eventID
+	})
+
+	return nil
+}
+
+func (m *Memory) UpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time, page Page) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := filter(m.state.events, func(e database.Event) bool {
+		return e.OrganisationID == nulls.NewInt32(organisationID) && e.EndsAt.After(now)
+	})
+	slices.SortStableFunc(events, func(a, b database.Event) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	page.Ascending = true
+	return paginate(events, page), nil
+}
+
+func (m *Memory) CountUpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.events, func(e database.Event) bool {
+		return e.OrganisationID == nulls.NewInt32(organisationID) && e.EndsAt.After(now)
+	}))), nil
+}
+
+func (m *Memory) SetEventOrganisation(ctx context.Context, eventID int32, organisationID nulls.Int32) (database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.events, func(e database.Event) bool { return e.EventID == eventID }, func(e *database.Event) {
+		e.OrganisationID = organisationID
+	})
+}
+
+func (m *Memory) Organisations(ctx context.Context, f OrganisationFilter, page Page) ([]database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return paginate(filter(m.state.organisations, f.match), page), nil
+}
+
+func (m *Memory) CountOrganisations(ctx context.Context, f OrganisationFilter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.organisations, f.match))), nil
+}
+
+func (f OrganisationFilter) match(o database.Organisation) bool {
+	return (f.Industry == "" || o.Industry == f.Industry) && (f.HqState == "" || o.HqState == f.HqState)
+}
+
+func (m *Memory) OrganisationByUUID(ctx context.Context, organisationUuid uuid.UUID) (database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.organisations, func(o database.Organisation) bool { return o.Uuid == organisationUuid })
+}
+
+func (m *Memory) OrganisationBySlug(ctx context.Context, slug string) (database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.organisations, func(o database.Organisation) bool { return o.Slug == slug })
+}
+
+func (m *Memory) InsertOrganisation(ctx context.Context, arg database.InsertOrganisationParams) (database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.organisations, func(o database.Organisation) bool { return o.Slug == arg.Slug }) {
+		return database.Organisation{}, errDuplicate
+	}
+
+	m.state.lastOrganisationID++
+	organisation := database.Organisation{
+		OrganisationID: m.state.lastOrganisationID,
+		Uuid:           uuid.Must(uuid.NewV4()),
+		Name:           arg.Name,
+		Slug:           arg.Slug,
+		Logo:           arg.Logo,
+		Description:    arg.Description,
+		Website:        arg.Website,
+		Industry:       arg.Industry,
+		HqState:        arg.HqState,
+		FoundedYear:    arg.FoundedYear,
+		TeamSize:       arg.TeamSize,
+		CreatedAt:      time.Now(),
+	}
+	m.state.organisations = append(m.state.organisations, organisation)
+
+	return organisation, nil
+}
+
+func (m *Memory) UpdateOrganisation(ctx context.Context, arg database.UpdateOrganisationParams) (database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.organisations, func(o database.Organisation) bool {
+		return o.Slug == arg.Slug && o.OrganisationID != arg.OrganisationID
+	}) {
+		return database.Organisation{}, errDuplicate
+	}
+
+	return update(m.state.organisations, func(o database.Organisation) bool { return o.OrganisationID == arg.OrganisationID }, func(o *database.Organisation) {
+		o.Name = arg.Name
+		o.Slug = arg.Slug
+		o.Logo = arg.Logo
+		o.Description = arg.Description
+		o.Website = arg.Website
+		o.Industry = arg.Industry
+		o.HqState = arg.HqState
+		o.FoundedYear = arg.FoundedYear
+		o.TeamSize = arg.TeamSize
+	})
+}
+
+func (m *Memory) DeleteOrganisation(ctx context.Context, organisationID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.organisations = slices.DeleteFunc(m.state.organisations, func(o database.Organisation) bool {
+		return o.OrganisationID == organisationID
+	})
+
+	// Mirror the ON DELETE relations of the organisations table.
+	m.state.organisationMembers = slices.DeleteFunc(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == organisationID
+	})
+	m.state.follows = slices.DeleteFunc(m.state.follows, func(f database.Follow) bool {
+		return f.FollowedOrganisationID == nulls.NewInt32(organisationID)
+	})
+	for i := range m.state.projects {
+		if m.state.projects[i].OrganisationID == nulls.NewInt32(organisationID) {
+			m.state.projects[i].OrganisationID = nulls.Int32{}
+		}
+	}
+	for i := range m.state.events {
+		if m.state.events[i].OrganisationID == nulls.NewInt32(organisationID) {
+			m.state.events[i].OrganisationID = nulls.Int32{}
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) UserOrganisations(ctx context.Context, userID int32) ([]database.Organisation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.organisations, func(o database.Organisation) bool {
+		return slices.ContainsFunc(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+			return om.OrganisationID == o.OrganisationID && om.UserID == userID
+		})
+	}), nil
+}
+
+func (m *Memory) OrganisationMembers(ctx context.Context, organisationID int32) ([]database.OrganisationMembersRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rows []database.OrganisationMembersRow
+	for _, om := range m.state.organisationMembers {
+		if om.OrganisationID != organisationID {
+			continue
+		}
+
+		user, err := first(m.state.users, func(u database.User) bool { return u.UserID == om.UserID })
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, database.OrganisationMembersRow{
+			OrganisationMemberID: om.OrganisationMemberID,
+			Role:                 om.Role,
+			CreatedAt:            om.CreatedAt,
+			OrganisationID:       om.OrganisationID,
+			UserID:               om.UserID,
+			UserUuid:             user.Uuid,
+			GithubEmail:          user.GithubEmail,
+		})
+	}
+
+	return rows, nil
+}
+
+func (m *Memory) OrganisationMember(ctx context.Context, organisationID, userID int32) (database.OrganisationMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == organisationID && om.UserID == userID
+	})
+}
+
+func (m *Memory) InsertOrganisationMember(ctx context.Context, arg database.InsertOrganisationMemberParams) (database.OrganisationMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == arg.OrganisationID && om.UserID == arg.UserID
+	}) {
+		return database.OrganisationMember{}, errDuplicate
+	}
+
+	m.state.lastOrganisationMemberID++
+	member := database.OrganisationMember{
+		OrganisationMemberID: m.state.lastOrganisationMemberID,
+		Role:                 arg.Role,
+		CreatedAt:            time.Now(),
+		OrganisationID:       arg.OrganisationID,
+		UserID:               arg.UserID,
+	}
+	m.state.organisationMembers = append(m.state.organisationMembers, member)
+
+	return member, nil
+}
+
+func (m *Memory) UpdateOrganisationMemberRole(ctx context.Context, arg database.UpdateOrganisationMemberRoleParams) (database.OrganisationMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == arg.OrganisationID && om.UserID == arg.UserID
+	}, func(om *database.OrganisationMember) {
+		om.Role = arg.Role
+	})
+}
+
+func (m *Memory) DeleteOrganisationMember(ctx context.Context, organisationID, userID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.organisationMembers = slices.DeleteFunc(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == organisationID && om.UserID == userID
+	})
+
+	return nil
+}
+
+func (m *Memory) CountOrganisationOwners(ctx context.Context, organisationID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.organisationMembers, func(om database.OrganisationMember) bool {
+		return om.OrganisationID == organisationID && om.Role == "owner"
+	}))), nil
+}
+
+func (m *Memory) ProjectsDueForRepositoryStats(ctx context.Context, staleBefore time.Time, limit int32) ([]database.ProjectsDueForRepositoryStatsRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var never, stale []database.ProjectsDueForRepositoryStatsRow
+	fetchedAt := make(map[int32]time.Time)
+	for _, p := range m.state.projects {
+		if !p.Repository.Valid {
+			continue
+		}
+
+		row := database.ProjectsDueForRepositoryStatsRow{
+			ProjectID:  p.ProjectID,
+			Repository: p.Repository,
+		}
+		rs, err := m.state.repositoryStatsOf(p)
+		if err != nil {
+			never = append(never, row)
+			continue
+		}
+		if !rs.FetchedAt.Before(staleBefore) {
+			continue
+		}
+
+		row.Etag = rs.Etag
+		fetchedAt[p.ProjectID] = rs.FetchedAt
+		stale = append(stale, row)
+	}
+
+	slices.SortStableFunc(stale, func(a, b database.ProjectsDueForRepositoryStatsRow) int {
+		return fetchedAt[a.ProjectID].Compare(fetchedAt[b.ProjectID])
+	})
+	due := append(never, stale...)
+
+	return due[:min(len(due), int(limit))], nil
+}
+
+// repositoryStatsOf returns the stats fetched for the current repository of p.
+func (s *memoryState) repositoryStatsOf(p database.Project) (database.ProjectRepositoryStat, error) {
+	return first(s.repositoryStats, func(rs database.ProjectRepositoryStat) bool {
+		return rs.ProjectID == p.ProjectID && p.Repository.Valid && rs.Repository == p.Repository.String
+	})
+}
+
+func (m *Memory) ProjectRepositoryStats(ctx context.Context, projectIDs []int32) ([]database.ProjectRepositoryStat, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stats []database.ProjectRepositoryStat
+	for _, p := range m.state.projects {
+		if !slices.Contains(projectIDs, p.ProjectID) {
+			continue
+		}
+
+		if rs, err := m.state.repositoryStatsOf(p); err == nil && rs.Found {
+			stats = append(stats, rs)
+		}
+	}
+
+	return stats, nil
+}
+
+func (m *Memory) UpsertProjectRepositoryStats(ctx context.Context, arg database.UpsertProjectRepositoryStatsParams) (database.ProjectRepositoryStat, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rs := database.ProjectRepositoryStat{
+		ProjectID:  arg.ProjectID,
+		Repository: arg.Repository,
+		Found:      arg.Found,
+		Stars:      arg.Stars,
+		Forks:      arg.Forks,
+		Language:   arg.Language,
+		Topics:     slices.Clone(arg.Topics),
+		License:    arg.License,
+		PushedAt:   arg.PushedAt,
+		Etag:       arg.Etag,
+		FetchedAt:  arg.FetchedAt,
+	}
+
+	i := slices.IndexFunc(m.state.repositoryStats, func(rs database.ProjectRepositoryStat) bool {
+		return rs.ProjectID == arg.ProjectID
+	})
+	if i < 0 {
+		m.state.repositoryStats = append(m.state.repositoryStats, rs)
+	} else {
+		m.state.repositoryStats[i] = rs
+	}
+
+	return rs, nil
+}
+
+func (m *Memory) TouchProjectRepositoryStats(ctx context.Context, projectID int32, repository string, fetchedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := update(m.state.repositoryStats, func(rs database.ProjectRepositoryStat) bool {
+		return rs.ProjectID == projectID
+	}, func(rs *database.ProjectRepositoryStat) {
+		if rs.Repository != repository {
+			rs.Found = false
+			rs.Etag = nulls.String{}
+		}
+		rs.Repository = repository
+		rs.FetchedAt = fetchedAt
+	})
+	if errors.Is(err, ErrNotFound) {
+		m.state.repositoryStats = append(m.state.repositoryStats, database.ProjectRepositoryStat{
+			ProjectID:  projectID,
+			Repository: repository,
+			FetchedAt:  fetchedAt,
+		})
+		return nil
+	}
+
+	return err
+}
+
+func (m *Memory) EnqueueJob(ctx context.Context, arg database.EnqueueJobParams) (database.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if arg.UniqueKey.Valid && slices.ContainsFunc(m.state.jobs, func(j database.Job) bool {
+		return j.UniqueKey == arg.UniqueKey
+	}) {
+		return database.Job{}, ErrJobExists
+	}
+
+	m.state.lastJobID++
+	job := database.Job{
+		JobID:       m.state.lastJobID,
+		Kind:        arg.Kind,
+		Payload:     slices.Clone(arg.Payload),
+		Status:      "pending",
+		MaxAttempts: arg.MaxAttempts,
+		UniqueKey:   arg.UniqueKey,
+		RunAt:       arg.RunAt,
+		CreatedAt:   time.Now(),
+	}
+	m.state.jobs = append(m.state.jobs, job)
+
+	return job, nil
+}
+
+func (m *Memory) ClaimJobs(ctx context.Context, now, lockExpiredBefore time.Time, maxAttempts, limit int32) ([]database.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []int
+	for i, j := range m.state.jobs {
+		if j.Status == "pending" && !j.RunAt.After(now) {
+			due = append(due, i)
+			continue
+		}
+		if j.Status != "running" || !j.LockedAt.Time.Before(lockExpiredBefore) {
+			continue
+		}
+
+		if j.Attempts < cmp.Or(j.MaxAttempts, maxAttempts) {
+			due = append(due, i)
+			continue
+		}
+
+		j := &m.state.jobs[i]
+		j.Status = "dead"
+		j.LockedAt = nulls.Time{}
+		j.LastError = nulls.NewString(errJobLockExpired)
+		j.FinishedAt = nulls.NewTime(now)
+	}
+	slices.SortStableFunc(due, func(a, b int) int {
+		return m.state.jobs[a].RunAt.Compare(m.state.jobs[b].RunAt)
+	})
+
+	var claimed []database.Job
+	for _, i := range due[:min(len(due), int(limit))] {
+		j := &m.state.jobs[i]
+		j.Status = "running"
+		j.Attempts++
+		j.LockedAt = nulls.NewTime(now)
+		claimed = append(claimed, *j)
+	}
+
+	return claimed, nil
+}
+
+func (m *Memory) CompleteJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time) error {
+	return m.updateJob(jobID, lockedAt, func(j *database.Job) {
+		j.Status = "completed"
+		j.LockedAt = nulls.Time{}
+		j.FinishedAt = nulls.NewTime(finishedAt)
+	})
+}
+
+func (m *Memory) RetryJob(ctx context.Context, jobID int32, lockedAt, runAt time.Time, lastError string) error {
+	return m.updateJob(jobID, lockedAt, func(j *database.Job) {
+		j.Status = "pending"
+		j.LockedAt = nulls.Time{}
+		j.LastError = nulls.NewString(lastError)
+		j.RunAt = runAt
+	})
+}
+
+func (m *Memory) KillJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time, lastError string) error {
+	return m.updateJob(jobID, lockedAt, func(j *database.Job) {
+		j.Status = "dead"
+		j.LockedAt = nulls.Time{}
+		j.LastError = nulls.NewString(lastError)
+		j.FinishedAt = nulls.NewTime(finishedAt)
+	})
+}
+
+// updateJob modifies a job still locked at lockedAt in place.
+func (m *Memory) updateJob(jobID int32, lockedAt time.Time, modify func(*database.Job)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := update(m.state.jobs, func(j database.Job) bool {
+		return j.JobID == jobID && j.LockedAt.Valid && j.LockedAt.Time.Equal(lockedAt)
+	}, modify)
+
+	return err
+}
+
+func (m *Memory) DeadJobs(ctx context.Context, limit int32) ([]database.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dead := filter(m.state.jobs, func(j database.Job) bool {
+		return j.Status == "dead"
+	})
+	slices.SortStableFunc(dead, func(a, b database.Job) int {
+		if c := b.FinishedAt.Time.Compare(a.FinishedAt.Time); c != 0 {
+			return c
+		}
+		return int(b.JobID - a.JobID)
+	})
+
+	return dead[:min(len(dead), int(limit))], nil
+}
+
+func (m *Memory) RequeueDeadJob(ctx context.Context, jobID int32, runAt time.Time) (database.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.jobs, func(j database.Job) bool {
+		return j.JobID == jobID && j.Status == "dead"
+	}, func(j *database.Job) {
+		j.Status = "pending"
+		j.Attempts = 0
+		j.FinishedAt = nulls.Time{}
+		j.RunAt = runAt
+	})
+}
+
+func (m *Memory) DeleteCompletedJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := len(m.state.jobs)
+	m.state.jobs = slices.DeleteFunc(m.state.jobs, func(j database.Job) bool {
+		return j.Status == "completed" && j.FinishedAt.Time.Before(finishedBefore)
+	})
+
+	return int64(n - len(m.state.jobs)), nil
+}
+
+// deleteWebhooks deletes the webhooks matching match, mirroring the ON DELETE CASCADE
+// relation of the webhook_deliveries table.
+func (s *memoryState) deleteWebhooks(match func(database.Webhook) bool) {
+	var webhookIDs []int32
+	s.webhooks = slices.DeleteFunc(s.webhooks, func(wh database.Webhook) bool {
+		if match(wh) {
+			webhookIDs = append(webhookIDs, wh.WebhookID)
+			return true
+		}
+		return false
+	})
+
+	s.webhookDeliveries = slices.DeleteFunc(s.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return slices.Contains(webhookIDs, wd.WebhookID)
+	})
+}
+
+func (m *Memory) UserWebhooks(ctx context.Context, userID int32) ([]database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.webhooks, func(wh database.Webhook) bool {
+		return wh.UserID == userID
+	}), nil
+}
+
+func (m *Memory) CountUserWebhooks(ctx context.Context, userID int32) (int64, error) {
+	webhooks, err := m.UserWebhooks(ctx, userID)
+	return int64(len(webhooks)), err
+}
+
+func (m *Memory) WebhookByUUID(ctx context.Context, webhookUuid uuid.UUID) (database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.webhooks, func(wh database.Webhook) bool {
+		return wh.Uuid == webhookUuid
+	})
+}
+
+func (m *Memory) WebhookByID(ctx context.Context, webhookID int32) (database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.webhooks, func(wh database.Webhook) bool {
+		return wh.WebhookID == webhookID
+	})
+}
+
+func (m *Memory) InsertWebhook(ctx context.Context, arg database.InsertWebhookParams) (database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastWebhookID++
+	webhook := database.Webhook{
+		WebhookID:  m.state.lastWebhookID,
+		Uuid:       uuid.Must(uuid.NewV4()),
+		Url:        arg.Url,
+		Secret:     arg.Secret,
+		EventTypes: slices.Clone(arg.EventTypes),
+		Site:       arg.Site,
+		Active:     arg.Active,
+		CreatedAt:  time.Now(),
+		UserID:     arg.UserID,
+	}
+	m.state.webhooks = append(m.state.webhooks, webhook)
+
+	return webhook, nil
+}
+
+func (m *Memory) UpdateWebhook(ctx context.Context, arg database.UpdateWebhookParams) (database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.webhooks, func(wh database.Webhook) bool {
+		return wh.WebhookID == arg.WebhookID
+	}, func(wh *database.Webhook) {
+		wh.Url = arg.Url
+		wh.EventTypes = slices.Clone(arg.EventTypes)
+		wh.Site = arg.Site
+		wh.Active = arg.Active
+	})
+}
+
+func (m *Memory) DeleteWebhook(ctx context.Context, webhookID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.deleteWebhooks(func(wh database.Webhook) bool {
+		return wh.WebhookID == webhookID
+	})
+
+	return nil
+}
+
+func (m *Memory) SubscribedWebhooks(ctx context.Context, eventType string, userID int32) ([]database.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.webhooks, func(wh database.Webhook) bool {
+		return wh.Active && slices.Contains(wh.EventTypes, eventType) && (wh.Site || wh.UserID == userID)
+	}), nil
+}
+
+func (m *Memory) InsertWebhookDelivery(ctx context.Context, arg database.InsertWebhookDeliveryParams) (database.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastWebhookDeliveryID++
+	delivery := database.WebhookDelivery{
+		WebhookDeliveryID: m.state.lastWebhookDeliveryID,
+		Uuid:              arg.Uuid,
+		EventType:         arg.EventType,
+		Payload:           slices.Clone(arg.Payload),
+		Status:            "pending",
+		CreatedAt:         time.Now(),
+		WebhookID:         arg.WebhookID,
+	}
+	m.state.webhookDeliveries = append(m.state.webhookDeliveries, delivery)
+
+	return delivery, nil
+}
+
+func (m *Memory) WebhookDeliveryByUUID(ctx context.Context, deliveryUuid uuid.UUID) (database.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.Uuid == deliveryUuid
+	})
+}
+
+func (m *Memory) WebhookDeliveryByID(ctx context.Context, webhookDeliveryID int32) (database.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.WebhookDeliveryID == webhookDeliveryID
+	})
+}
+
+func (m *Memory) WebhookDeliveries(ctx context.Context, webhookID int32, page Page) ([]database.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	page.Ascending = false
+	return paginate(filter(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.WebhookID == webhookID
+	}), page), nil
+}
+
+func (m *Memory) CountWebhookDeliveries(ctx context.Context, webhookID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.WebhookID == webhookID
+	}))), nil
+}
+
+func (m *Memory) RecordWebhookDeliveryAttempt(ctx context.Context, arg database.RecordWebhookDeliveryAttemptParams) (database.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.WebhookDeliveryID == arg.WebhookDeliveryID
+	}, func(wd *database.WebhookDelivery) {
+		wd.Status = arg.Status
+		wd.Attempts++
+		wd.ResponseStatus = arg.ResponseStatus
+		wd.ResponseBody = arg.ResponseBody
+		wd.Error = arg.Error
+		wd.DurationMs = arg.DurationMs
+		wd.DeliveredAt = arg.DeliveredAt
+	})
+}
+
+func (m *Memory) DeleteWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := len(m.state.webhookDeliveries)
+	m.state.webhookDeliveries = slices.DeleteFunc(m.state.webhookDeliveries, func(wd database.WebhookDelivery) bool {
+		return wd.CreatedAt.Before(createdBefore)
+	})
+
+	return int64(n - len(m.state.webhookDeliveries)), nil
+}
+
+// notificationPreferences returns the preferences of a user, creating them when missing.
+func (s *memoryState) notificationPreferences(userID int32) *database.NotificationPreference {
+	i := slices.IndexFunc(s.notificationPrefs, func(np database.NotificationPreference) bool {
+		return np.UserID == userID
+	})
+	if i < 0 {
+		s.notificationPrefs = append(s.notificationPrefs, database.NotificationPreference{
+			UserID:           userID,
+			ListingCreated:   true,
+			ListingChanges:   true,
+			UnsubscribeToken: uuid.Must(uuid.NewV4()),
+			UpdatedAt:        time.Now(),
+		})
+		i = len(s.notificationPrefs) - 1
+	}
+
+	return &s.notificationPrefs[i]
+}
+
+func (m *Memory) NotificationPreferences(ctx context.Context, userID int32) (database.NotificationPreference, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return *m.state.notificationPreferences(userID), nil
+}
+
+func (m *Memory) NotificationPreferencesByUnsubscribeToken(ctx context.Context, token uuid.UUID) (database.NotificationPreference, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.notificationPrefs, func(np database.NotificationPreference) bool {
+		return np.UnsubscribeToken == token
+	})
+}
+
+func (m *Memory) UpdateNotificationPreferences(ctx context.Context, arg database.UpdateNotificationPreferencesParams) (database.NotificationPreference, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	np := m.state.notificationPreferences(arg.UserID)
+	np.ListingCreated = arg.ListingCreated
+	np.ListingChanges = arg.ListingChanges
+	np.UpdatedAt = time.Now()
+
+	return *np, nil
+}
+
+func (m *Memory) Follows(ctx context.Context, followerID int32) ([]database.FollowsRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rows []database.FollowsRow
+	for _, f := range m.state.follows {
+		if f.FollowerID != followerID {
+			continue
+		}
+
+		row := database.FollowsRow{
+			FollowID:               f.FollowID,
+			FollowerID:             f.FollowerID,
+			FollowedUserID:         f.FollowedUserID,
+			FollowedOrganisationID: f.FollowedOrganisationID,
+			FollowedTag:            f.FollowedTag,
+			CreatedAt:              f.CreatedAt,
+		}
+		if u, err := first(m.state.users, func(u database.User) bool {
+			return f.FollowedUserID == nulls.NewInt32(u.UserID)
+		}); err == nil {
+			row.UserUuid = nulls.NewUUID(u.Uuid)
+			row.UserGithubEmail = nulls.NewString(u.GithubEmail)
+		}
+		if o, err := first(m.state.organisations, func(o database.Organisation) bool {
+			return f.FollowedOrganisationID == nulls.NewInt32(o.OrganisationID)
+		}); err == nil {
+			row.OrganisationUuid = nulls.NewUUID(o.Uuid)
+			row.OrganisationSlug = nulls.NewString(o.Slug)
+			row.OrganisationName = nulls.NewString(o.Name)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (m *Memory) CountFollows(ctx context.Context, followerID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.follows, func(f database.Follow) bool {
+		return f.FollowerID == followerID
+	}))), nil
+}
+
+// followMatches reports whether f is the follow of a user, organisation or tag by follower.
+func followMatches(f database.Follow, followerID int32, userID, organisationID nulls.Int32, tag nulls.String) bool {
+	return f.FollowerID == followerID && f.FollowedUserID == userID && f.FollowedOrganisationID == organisationID && f.FollowedTag == tag
+}
+
+func (m *Memory) InsertFollow(ctx context.Context, arg database.InsertFollowParams) (database.Follow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.follows, func(f database.Follow) bool {
+		return followMatches(f, arg.FollowerID, arg.FollowedUserID, arg.FollowedOrganisationID, arg.FollowedTag)
+	}) {
+		return database.Follow{}, ErrFollowExists
+	}
+
+	m.state.lastFollowID++
+	follow := database.Follow{
+		FollowID:               m.state.lastFollowID,
+		FollowerID:             arg.FollowerID,
+		FollowedUserID:         arg.FollowedUserID,
+		FollowedOrganisationID: arg.FollowedOrganisationID,
+		FollowedTag:            arg.FollowedTag,
+		CreatedAt:              time.Now(),
+	}
+	m.state.follows = append(m.state.follows, follow)
+
+	return follow, nil
+}
+
+func (m *Memory) DeleteFollow(ctx context.Context, arg database.DeleteFollowParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := len(m.state.follows)
+	m.state.follows = slices.DeleteFunc(m.state.follows, func(f database.Follow) bool {
+		return followMatches(f, arg.FollowerID, arg.FollowedUserID, arg.FollowedOrganisationID, arg.FollowedTag)
+	})
+	if len(m.state.follows) == n {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// followed returns what a user follows, mirroring the subqueries of the feed queries.
+func (s *memoryState) followed(followerID int32) (userIDs, organisationIDs []int32, tags []string) {
+	for _, f := range s.follows {
+		if f.FollowerID != followerID {
+			continue
+		}
+
+		switch {
+		case f.FollowedUserID.Valid:
+			userIDs = append(userIDs, f.FollowedUserID.Int32)
+		case f.FollowedOrganisationID.Valid:
+			organisationIDs = append(organisationIDs, f.FollowedOrganisationID.Int32)
+		case f.FollowedTag.Valid:
+			tags = append(tags, f.FollowedTag.String)
+		}
+	}
+
+	return userIDs, organisationIDs, tags
+}
+
+// feedPage pages the records of a feed from before, newest first.
+func feedPage[T any](records []T, before Cursor, limit int32, position func(T) (time.Time, int32)) []T {
+	records = filter(records, func(r T) bool {
+		createdAt, id := position(r)
+		return before.CreatedAt.IsZero() || createdAt.Before(before.CreatedAt) || createdAt.Equal(before.CreatedAt) && id < before.ID
+	})
+	slices.SortFunc(records, func(a, b T) int {
+		aCreatedAt, aID := position(a)
+		bCreatedAt, bID := position(b)
+		if c := bCreatedAt.Compare(aCreatedAt); c != 0 {
+			return c
+		}
+		return int(bID - aID)
+	})
+
+	return records[:min(int(limit), len(records))]
+}
+
+func (m *Memory) FeedProjects(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userIDs, organisationIDs, tags := m.state.followed(followerID)
+	return feedPage(filter(m.state.projects, func(p database.Project) bool {
+		return p.UserID != followerID && (slices.Contains(userIDs, p.UserID) ||
+			p.OrganisationID.Valid && slices.Contains(organisationIDs, p.OrganisationID.Int32) ||
+			overlaps(p.Tags, tags))
+	}), before, limit, func(p database.Project) (time.Time, int32) {
+		return p.CreatedAt, p.ProjectID
+	}), nil
+}
+
+func (m *Memory) FeedEvents(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userIDs, organisationIDs, tags := m.state.followed(followerID)
+	return feedPage(filter(m.state.events, func(e database.Event) bool {
+		return e.UserID != followerID && (slices.Contains(userIDs, e.UserID) ||
+			e.OrganisationID.Valid && slices.Contains(organisationIDs, e.OrganisationID.Int32) ||
+			overlaps(e.Tags, tags))
+	}), before, limit, func(e database.Event) (time.Time, int32) {
+		return e.CreatedAt, e.EventID
+	}), nil
+}
+
+func (m *Memory) UserBookmarks(ctx context.Context, userID int32, page Page) ([]database.Bookmark, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	page.Ascending = false
+	return paginate(filter(m.state.bookmarks, func(b database.Bookmark) bool {
+		return b.UserID == userID
+	}), page), nil
+}
+
+func (m *Memory) CountUserBookmarks(ctx context.Context, userID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.bookmarks, func(b database.Bookmark) bool {
+		return b.UserID == userID
+	}))), nil
+}
+
+func (m *Memory) InsertBookmark(ctx context.Context, arg database.InsertBookmarkParams) (database.Bookmark, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.state.bookmarks, func(b database.Bookmark) bool {
+		return b.UserID == arg.UserID && b.ProjectID == arg.ProjectID && b.EventID == arg.EventID
+	}) {
+		return database.Bookmark{}, ErrBookmarkExists
+	}
+
+	m.state.lastBookmarkID++
+	bookmark := database.Bookmark{
+		BookmarkID: m.state.lastBookmarkID,
+		UserID:     arg.UserID,
+		ProjectID:  arg.ProjectID,
+		EventID:    arg.EventID,
+		CreatedAt:  time.Now(),
+	}
+	m.state.bookmarks = append(m.state.bookmarks, bookmark)
+
+	return bookmark, nil
+}
+
+func (m *Memory) DeleteBookmark(ctx context.Context, arg database.DeleteBookmarkParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := len(m.state.bookmarks)
+	m.state.bookmarks = slices.DeleteFunc(m.state.bookmarks, func(b database.Bookmark) bool {
+		return b.UserID == arg.UserID && b.ProjectID == arg.ProjectID && b.EventID == arg.EventID
+	})
+	if len(m.state.bookmarks) == n {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// deleteCollections deletes the collections matching match, mirroring the ON DELETE CASCADE
+// relation of the collection_items table.
+func (s *memoryState) deleteCollections(match func(database.Collection) bool) {
+	var collectionIDs []int32
+	s.collections = slices.DeleteFunc(s.collections, func(c database.Collection) bool {
+		if match(c) {
+			collectionIDs = append(collectionIDs, c.CollectionID)
+			return true
+		}
+		return false
+	})
+
+	s.collectionItems = slices.DeleteFunc(s.collectionItems, func(ci database.CollectionItem) bool {
+		return slices.Contains(collectionIDs, ci.CollectionID)
+	})
+}
+
+func (m *Memory) UserCollections(ctx context.Context, userID int32) ([]database.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter(m.state.collections, func(c database.Collection) bool {
+		return c.UserID == userID
+	}), nil
+}
+
+func (m *Memory) CountUserCollections(ctx context.Context, userID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.collections, func(c database.Collection) bool {
+		return c.UserID == userID
+	}))), nil
+}
+
+func (m *Memory) CollectionByUUID(ctx context.Context, collectionUuid uuid.UUID) (database.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return first(m.state.collections, func(c database.Collection) bool { return c.Uuid == collectionUuid })
+}
+
+func (m *Memory) InsertCollection(ctx context.Context, arg database.InsertCollectionParams) (database.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.lastCollectionID++
+	collection := database.Collection{
+		CollectionID: m.state.lastCollectionID,
+		Uuid:         uuid.Must(uuid.NewV4()),
+		Name:         arg.Name,
+		Description:  arg.Description,
+		Public:       arg.Public,
+		CreatedAt:    time.Now(),
+		UserID:       arg.UserID,
+	}
+	m.state.collections = append(m.state.collections, collection)
+
+	return collection, nil
+}
+
+func (m *Memory) UpdateCollection(ctx context.Context, arg database.UpdateCollectionParams) (database.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return update(m.state.collections, func(c database.Collection) bool {
+		return c.CollectionID == arg.CollectionID
+	}, func(c *database.Collection) {
+		c.Name = arg.Name
+		c.Description = arg.Description
+		c.Public = arg.Public
+	})
+}
+
+func (m *Memory) DeleteCollection(ctx context.Context, collectionID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.deleteCollections(func(c database.Collection) bool {
+		return c.CollectionID == collectionID
+	})
+
+	return nil
+}
+
+func (m *Memory) CollectionItems(ctx context.Context, collectionID int32) ([]database.CollectionItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := filter(m.state.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.CollectionID == collectionID
+	})
+	slices.SortStableFunc(items, func(a, b database.CollectionItem) int {
+		return int(a.Position - b.Position)
+	})
+
+	return items, nil
+}
+
+func (m *Memory) CountCollectionItems(ctx context.Context, collectionID int32) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return int64(len(filter(m.state.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.CollectionID == collectionID
+	}))), nil
+}
+
+func (m *Memory) InsertCollectionItem(ctx context.Context, arg database.InsertCollectionItemParams) (database.CollectionItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var position int32
+	for _, ci := range m.state.collectionItems {
+		if ci.CollectionID != arg.CollectionID {
+			continue
+		}
+		if ci.ProjectID == arg.ProjectID && ci.EventID == arg.EventID {
+			return database.CollectionItem{}, ErrCollectionItemExists
+		}
+		position = max(position, ci.Position)
+	}
+
+	m.state.lastCollectionItemID++
+	item := database.CollectionItem{
+		CollectionItemID: m.state.lastCollectionItemID,
+		Uuid:             uuid.Must(uuid.NewV4()),
+		Position:         position + 1,
+		CreatedAt:        time.Now(),
+		CollectionID:     arg.CollectionID,
+		ProjectID:        arg.ProjectID,
+		EventID:          arg.EventID,
+	}
+	m.state.collectionItems = append(m.state.collectionItems, item)
+
+	return item, nil
+}
+
+func (m *Memory) UpdateCollectionItemPosition(ctx context.Context, collectionItemID, position int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := update(m.state.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.CollectionItemID == collectionItemID
+	}, func(ci *database.CollectionItem) {
+		ci.Position = position
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (m *Memory) DeleteCollectionItem(ctx context.Context, collectionItemID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.collectionItems = slices.DeleteFunc(m.state.collectionItems, func(ci database.CollectionItem) bool {
+		return ci.CollectionItemID == collectionItemID
+	})
+
+	return nil
+}
+
+// first returns the first record matching match, or ErrNotFound.
+func first[T any](records []T, match func(T) bool) (T, error) {
+	i := slices.IndexFunc(records, match)
+	if i < 0 {
+		var zero T
+		return zero, ErrNotFound
+	}
+
+	return records[i], nil
+}
+
+// update modifies the first record matching match in place and returns it, or ErrNotFound.
+func update[T any](records []T, match func(T) bool, modify func(*T)) (T, error) {
+	i := slices.IndexFunc(records, match)
+	if i < 0 {
+		var zero T
+		return zero, ErrNotFound
+	}
+
+	modify(&records[i])
+	return records[i], nil
+}
+
+func filter[T any](records []T, match func(T) bool) []T {
+	var matches []T
+	for _, r := range records {
+		if match(r) {
+			matches = append(matches, r)
+		}
+	}
+
+	return matches
+}
+
+// paginate pages records, which are stored oldest first like the serial IDs they mirror.
+func paginate[T any](records []T, page Page) []T {
+	if !page.Ascending {
+		records = slices.Clone(records)
+		slices.Reverse(records)
+	}
+
+	start := min(int(page.Offset), len(records))
+	end := min(start+int(page.Limit), len(records))
+
+	return records[start:end]
+}
+
+// overlaps mirrors the && operator of Postgres arrays.
+func overlaps(a, b []string) bool {
+	return slices.ContainsFunc(a, func(s string) bool { return slices.Contains(b, s) })
+}
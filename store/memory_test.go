@@ -0,0 +1,266 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+func TestMemoryProjects(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice := mustInsertUser(t, st, "alice@example.com")
+	bob := mustInsertUser(t, st, "bob@example.com")
+
+	first := mustInsertProject(t, st, alice, "Kedai Pay Project", "fintech", "golang")
+	middle := mustInsertProject(t, st, bob, "Pasar Hub Project", "agritech")
+	last := mustInsertProject(t, st, alice, "Lepak Labs Project", "golang")
+
+	for _, tc := range []struct {
+		name  string
+		tags  []string
+		page  store.Page
+		uuids []uuid.UUID
+	}{
+		{"newest first", nil, store.Page{Limit: 20}, []uuid.UUID{last.Uuid, middle.Uuid, first.Uuid}},
+		{"oldest first", nil, store.Page{Ascending: true, Limit: 1}, []uuid.UUID{first.Uuid}},
+		{"offset", nil, store.Page{Offset: 2, Limit: 20}, []uuid.UUID{first.Uuid}},
+		{"past the end", nil, store.Page{Offset: 5, Limit: 20}, nil},
+		{"any of tags", []string{"golang", "unknown"}, store.Page{Limit: 20}, []uuid.UUID{last.Uuid, first.Uuid}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			projects, err := st.Projects(ctx, tc.tags, tc.page)
+			if err != nil {
+				t.Fatalf("could not list projects: %v", err)
+			}
+			if len(projects) != len(tc.uuids) {
+				t.Fatalf("got %d projects, want %d", len(projects), len(tc.uuids))
+			}
+			for i, p := range projects {
+				if p.Uuid != tc.uuids[i] {
+					t.Errorf("got %s at %d, want %s", p.Name, i, tc.uuids[i])
+				}
+			}
+		})
+	}
+
+	if count, _ := st.CountProjects(ctx, []string{"golang"}); count != 2 {
+		t.Errorf("got %d projects tagged golang, want 2", count)
+	}
+	if count, _ := st.CountUserProjects(ctx, bob.UserID); count != 1 {
+		t.Errorf("got %d projects of bob, want 1", count)
+	}
+
+	if _, err := st.UserProjectByName(ctx, bob.UserID, first.Name); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("got %v for the project of another user, want ErrNotFound", err)
+	}
+	if err := st.DeleteProject(ctx, first.ProjectID); err != nil {
+		t.Fatalf("could not delete project: %v", err)
+	}
+	if _, err := st.ProjectByUUID(ctx, first.Uuid); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("got %v for a deleted project, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryWithTx(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice := mustInsertUser(t, st, "alice@example.com")
+
+	errRollback := errors.New("rollback")
+	err := st.WithTx(ctx, func(tx store.Store) error {
+		mustInsertProject(t, tx, alice, "Kedai Pay Project")
+
+		// Nested transactions run in the outer one.
+		return tx.WithTx(ctx, func(tx store.Store) error {
+			mustInsertProject(t, tx, alice, "Pasar Hub Project")
+			return errRollback
+		})
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("got %v, want the error of fn", err)
+	}
+	if count, _ := st.CountUserProjects(ctx, alice.UserID); count != 0 {
+		t.Fatalf("got %d projects after a rollback, want none", count)
+	}
+
+	if err := st.WithTx(ctx, func(tx store.Store) error {
+		mustInsertProject(t, tx, alice, "Kedai Pay Project")
+		if count, _ := st.CountUserProjects(ctx, alice.UserID); count != 0 {
+			t.Errorf("got %d projects outside of the transaction before commit, want none", count)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("could not commit: %v", err)
+	}
+	if count, _ := st.CountUserProjects(ctx, alice.UserID); count != 1 {
+		t.Fatalf("got %d projects after a commit, want 1", count)
+	}
+}
+
+func TestMemoryDeleteDueUsers(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice := mustInsertUser(t, st, "alice@example.com")
+	bob := mustInsertUser(t, st, "bob@example.com")
+	mustInsertProject(t, st, alice, "Kedai Pay Project")
+	mustInsertProject(t, st, bob, "Pasar Hub Project")
+
+	now := time.Now()
+	if _, err := st.ScheduleUserDeletion(ctx, database.ScheduleUserDeletionParams{
+		DeletesAt: nulls.NewTime(now.Add(-time.Minute)),
+		UserID:    alice.UserID,
+	}); err != nil {
+		t.Fatalf("could not schedule user deletion: %v", err)
+	}
+	if _, err := st.ScheduleUserDeletion(ctx, database.ScheduleUserDeletionParams{
+		DeletesAt: nulls.NewTime(now.Add(time.Hour)),
+		UserID:    bob.UserID,
+	}); err != nil {
+		t.Fatalf("could not schedule user deletion: %v", err)
+	}
+
+	userIDs, err := st.DeleteDueUsers(ctx, now)
+	if err != nil {
+		t.Fatalf("could not delete due users: %v", err)
+	}
+	if len(userIDs) != 1 || userIDs[0] != alice.UserID {
+		t.Fatalf("got deleted users %v, want only alice", userIDs)
+	}
+
+	if _, err := st.UserByUUID(ctx, alice.Uuid); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("got %v for a deleted user, want ErrNotFound", err)
+	}
+	if count, _ := st.CountProjects(ctx, nil); count != 1 {
+		t.Errorf("got %d projects, want the projects of the deleted user removed", count)
+	}
+}
+
+func TestMemoryDeleteOrganisation(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice := mustInsertUser(t, st, "alice@example.com")
+	project := mustInsertProject(t, st, alice, "Kedai Pay Project")
+
+	organisation, err := st.InsertOrganisation(ctx, database.InsertOrganisationParams{
+		Name:        "Kedai Pay",
+		Slug:        "kedai-pay",
+		Description: "A startup made in Malaysia.",
+		Industry:    "fintech",
+		HqState:     "selangor",
+		FoundedYear: 2019,
+		TeamSize:    "11-50",
+	})
+	if err != nil {
+		t.Fatalf("could not insert organisation: %v", err)
+	}
+	if _, err := st.InsertOrganisation(ctx, database.InsertOrganisationParams{Slug: "kedai-pay"}); err == nil {
+		t.Errorf("got no error for a duplicate slug")
+	}
+	if _, err := st.InsertOrganisationMember(ctx, database.InsertOrganisationMemberParams{
+		Role:           "owner",
+		OrganisationID: organisation.OrganisationID,
+		UserID:         alice.UserID,
+	}); err != nil {
+		t.Fatalf("could not insert organisation member: %v", err)
+	}
+	if _, err := st.SetProjectOrganisation(ctx, project.ProjectID, nulls.NewInt32(organisation.OrganisationID)); err != nil {
+		t.Fatalf("could not set project organisation: %v", err)
+	}
+	if count, _ := st.CountOrganisationProjects(ctx, organisation.OrganisationID); count != 1 {
+		t.Fatalf("got %d projects of the organisation, want 1", count)
+	}
+
+	if err := st.DeleteOrganisation(ctx, organisation.OrganisationID); err != nil {
+		t.Fatalf("could not delete organisation: %v", err)
+	}
+
+	project, err = st.ProjectByUUID(ctx, project.Uuid)
+	if err != nil {
+		t.Fatalf("got %v for the project of a deleted organisation, want it kept", err)
+	}
+	if project.OrganisationID.Valid {
+		t.Errorf("got organisation %d, want none", project.OrganisationID.Int32)
+	}
+	if organisations, _ := st.UserOrganisations(ctx, alice.UserID); len(organisations) != 0 {
+		t.Errorf("got %d organisations of alice, want none", len(organisations))
+	}
+}
+
+func TestMemoryFeed(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	alice := mustInsertUser(t, st, "alice@example.com")
+	bob := mustInsertUser(t, st, "bob@example.com")
+	carol := mustInsertUser(t, st, "carol@example.com")
+
+	ofBob := mustInsertProject(t, st, bob, "Kedai Pay Project")
+	tagged := mustInsertProject(t, st, carol, "Pasar Hub Project", "golang")
+	mustInsertProject(t, st, carol, "Lepak Labs Project", "agritech")
+	mustInsertProject(t, st, alice, "Teh Tarik Project", "golang")
+
+	for _, arg := range []database.InsertFollowParams{
+		{FollowerID: alice.UserID, FollowedUserID: nulls.NewInt32(bob.UserID)},
+		{FollowerID: alice.UserID, FollowedTag: nulls.NewString("golang")},
+	} {
+		if _, err := st.InsertFollow(ctx, arg); err != nil {
+			t.Fatalf("could not insert follow: %v", err)
+		}
+	}
+	if _, err := st.InsertFollow(ctx, database.InsertFollowParams{FollowerID: alice.UserID, FollowedTag: nulls.NewString("golang")}); !errors.Is(err, store.ErrFollowExists) {
+		t.Errorf("got %v for a duplicate follow, want ErrFollowExists", err)
+	}
+
+	// The projects of alice are left out of her own feed.
+	projects, err := st.FeedProjects(ctx, alice.UserID, store.Cursor{}, 1)
+	if err != nil || len(projects) != 1 || projects[0].Uuid != tagged.Uuid {
+		t.Fatalf("got %v, %v, want the tagged project first", projects, err)
+	}
+	projects, err = st.FeedProjects(ctx, alice.UserID, store.Cursor{CreatedAt: projects[0].CreatedAt, ID: projects[0].ProjectID}, 10)
+	if err != nil || len(projects) != 1 || projects[0].Uuid != ofBob.Uuid {
+		t.Fatalf("got %v, %v, want the project of bob after the cursor", projects, err)
+	}
+
+	if err := st.DeleteFollow(ctx, database.DeleteFollowParams{FollowerID: alice.UserID, FollowedTag: nulls.NewString("golang")}); err != nil {
+		t.Fatalf("could not delete follow: %v", err)
+	}
+	if err := st.DeleteFollow(ctx, database.DeleteFollowParams{FollowerID: alice.UserID, FollowedTag: nulls.NewString("golang")}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("got %v for a deleted follow, want ErrNotFound", err)
+	}
+	if follows, _ := st.Follows(ctx, alice.UserID); len(follows) != 1 || follows[0].UserGithubEmail.String != bob.GithubEmail {
+		t.Errorf("got %+v, want the follow of bob", follows)
+	}
+}
+
+func mustInsertUser(t *testing.T, st store.Store, email string) database.User {
+	t.Helper()
+
+	user, err := st.InsertUser(context.Background(), email)
+	if err != nil {
+		t.Fatalf("could not insert user: %v", err)
+	}
+
+	return user
+}
+
+func mustInsertProject(t *testing.T, st store.Store, user database.User, name string, tags ...string) database.Project {
+	t.Helper()
+
+	project, err := st.InsertProject(context.Background(), database.InsertProjectParams{
+		Name:        name,
+		Description: "A project made in Malaysia.",
+		Tags:        tags,
+		UserID:      user.UserID,
+	})
+	if err != nil {
+		t.Fatalf("could not insert project: %v", err)
+	}
+
+	return project
+}
@@ -0,0 +1,815 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// Postgres implements Store with the queries generated by sqlc.
+type Postgres struct {
+	// database is nil within a transaction, where db is the transaction.
+	database *sql.DB
+	db       database.DBTX
+	queries  *database.Queries
+}
+
+var _ Store = (*Postgres)(nil)
+
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{
+		database: db,
+		db:       db,
+		queries:  database.New(),
+	}
+}
+
+func (p *Postgres) Ping(ctx context.Context) error {
+	if p.database == nil {
+		// The transaction holds a connection already.
+		return nil
+	}
+
+	return p.database.PingContext(ctx)
+}
+
+func (p *Postgres) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if p.database == nil {
+		return fn(p)
+	}
+
+	tx, err := p.database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	if err := fn(&Postgres{db: tx, queries: p.queries}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *Postgres) UserByUUID(ctx context.Context, userUuid uuid.UUID) (database.User, error) {
+	return p.queries.UserByUUID(ctx, p.db, userUuid)
+}
+
+func (p *Postgres) UserByID(ctx context.Context, userID int32) (database.User, error) {
+	return p.queries.UserByID(ctx, p.db, userID)
+}
+
+func (p *Postgres) UserByGithubEmail(ctx context.Context, githubEmail string) (database.User, error) {
+	return p.queries.UserByGithubEmail(ctx, p.db, githubEmail)
+}
+
+func (p *Postgres) InsertUser(ctx context.Context, githubEmail string) (database.User, error) {
+	return p.queries.InsertUser(ctx, p.db, githubEmail)
+}
+
+func (p *Postgres) ScheduleUserDeletion(ctx context.Context, arg database.ScheduleUserDeletionParams) (database.User, error) {
+	return p.queries.ScheduleUserDeletion(ctx, p.db, arg)
+}
+
+func (p *Postgres) CancelUserDeletion(ctx context.Context, userID int32) (database.User, error) {
+	return p.queries.CancelUserDeletion(ctx, p.db, userID)
+}
+
+func (p *Postgres) DeleteDueUsers(ctx context.Context, now time.Time) ([]int32, error) {
+	return p.queries.DeleteDueUsers(ctx, p.db, nulls.NewTime(now))
+}
+
+func (p *Postgres) CountUsers(ctx context.Context) (int64, error) {
+	return p.queries.CountUsers(ctx, p.db)
+}
+
+func (p *Postgres) InsertUserSession(ctx context.Context, arg database.InsertUserSessionParams) (database.UserSession, error) {
+	return p.queries.InsertUserSession(ctx, p.db, arg)
+}
+
+func (p *Postgres) UserSessionByUUID(ctx context.Context, sessionUuid uuid.UUID) (database.UserSession, error) {
+	return p.queries.UserSessionByUUID(ctx, p.db, sessionUuid)
+}
+
+func (p *Postgres) UserSessions(ctx context.Context, userID int32, now time.Time) ([]database.UserSession, error) {
+	return p.queries.UserSessions(ctx, p.db, database.UserSessionsParams{
+		UserID:    userID,
+		ExpiresAt: now,
+	})
+}
+
+func (p *Postgres) TouchUserSession(ctx context.Context, arg database.TouchUserSessionParams) error {
+	return p.queries.TouchUserSession(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteUserSession(ctx context.Context, sessionUuid uuid.UUID) error {
+	return p.queries.DeleteUserSession(ctx, p.db, sessionUuid)
+}
+
+func (p *Postgres) DeleteUserSessions(ctx context.Context, userID int32) error {
+	return p.queries.DeleteUserSessions(ctx, p.db, userID)
+}
+
+func (p *Postgres) DeleteExpiredUserSessions(ctx context.Context, now time.Time) error {
+	return p.queries.DeleteExpiredUserSessions(ctx, p.db, now)
+}
+
+func (p *Postgres) Projects(ctx context.Context, tags []string, page Page) ([]database.Project, error) {
+	switch {
+	case len(tags) > 0 && page.Ascending:
+		return p.queries.ProjectsByTagsAscOffsetLimit(ctx, p.db, database.ProjectsByTagsAscOffsetLimitParams{
+			Tags:   tags,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	case len(tags) > 0:
+		return p.queries.ProjectsByTagsDescOffsetLimit(ctx, p.db, database.ProjectsByTagsDescOffsetLimitParams{
+			Tags:   tags,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	case page.Ascending:
+		return p.queries.ProjectsByAscOffsetLimit(ctx, p.db, database.ProjectsByAscOffsetLimitParams{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	default:
+		return p.queries.ProjectsByDescOffsetLimit(ctx, p.db, database.ProjectsByDescOffsetLimitParams{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	}
+}
+
+func (p *Postgres) CountProjects(ctx context.Context, tags []string) (int64, error) {
+	if len(tags) > 0 {
+		return p.queries.CountProjectsByTags(ctx, p.db, tags)
+	}
+
+	return p.queries.CountProjects(ctx, p.db)
+}
+
+func (p *Postgres) UserProjects(ctx context.Context, userID int32, page Page) ([]database.Project, error) {
+	if page.Ascending {
+		return p.queries.UserProjectsByAscOffsetLimit(ctx, p.db, database.UserProjectsByAscOffsetLimitParams{
+			UserID: userID,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	}
+
+	return p.queries.UserProjectsByDescOffsetLimit(ctx, p.db, database.UserProjectsByDescOffsetLimitParams{
+		UserID: userID,
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+}
+
+func (p *Postgres) AllUserProjects(ctx context.Context, userID int32) ([]database.Project, error) {
+	return p.queries.UserProjects(ctx, p.db, userID)
+}
+
+func (p *Postgres) CountUserProjects(ctx context.Context, userID int32) (int64, error) {
+	return p.queries.CountUserProjects(ctx, p.db, userID)
+}
+
+func (p *Postgres) ProjectByUUID(ctx context.Context, projectUuid uuid.UUID) (database.Project, error) {
+	return p.queries.ProjectByUUID(ctx, p.db, projectUuid)
+}
+
+func (p *Postgres) ProjectsByIDs(ctx context.Context, projectIDs []int32) ([]database.Project, error) {
+	return p.queries.ProjectsByIDs(ctx, p.db, projectIDs)
+}
+
+func (p *Postgres) UserProjectByName(ctx context.Context, userID int32, name string) (database.Project, error) {
+	return p.queries.UserProjectByName(ctx, p.db, database.UserProjectByNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+}
+
+func (p *Postgres) InsertProject(ctx context.Context, arg database.InsertProjectParams) (database.Project, error) {
+	return p.queries.InsertProject(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateProject(ctx context.Context, arg database.UpdateProjectParams) (database.Project, error) {
+	return p.queries.UpdateProject(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteProject(ctx context.Context, projectID int32) error {
+	return p.queries.DeleteProject(ctx, p.db, projectID)
+}
+
+func (p *Postgres) OrganisationProjects(ctx context.Context, organisationID int32, page Page) ([]database.Project, error) {
+	if page.Ascending {
+		return p.queries.OrganisationProjectsByAscOffsetLimit(ctx, p.db, database.OrganisationProjectsByAscOffsetLimitParams{
+			OrganisationID: nulls.NewInt32(organisationID),
+			Offset:         page.Offset,
+			Limit:          page.Limit,
+		})
+	}
+
+	return p.queries.OrganisationProjectsByDescOffsetLimit(ctx, p.db, database.OrganisationProjectsByDescOffsetLimitParams{
+		OrganisationID: nulls.NewInt32(organisationID),
+		Offset:         page.Offset,
+		Limit:          page.Limit,
+	})
+}
+
+func (p *Postgres) CountOrganisationProjects(ctx context.Context, organisationID int32) (int64, error) {
+	return p.queries.CountOrganisationProjects(ctx, p.db, nulls.NewInt32(organisationID))
+}
+
+func (p *Postgres) SetProjectOrganisation(ctx context.Context, projectID int32, organisationID nulls.Int32) (database.Project, error) {
+	return p.queries.SetProjectOrganisation(ctx, p.db, database.SetProjectOrganisationParams{
+		OrganisationID: organisationID,
+		ProjectID:      projectID,
+	})
+}
+
+func (p *Postgres) ProjectMembers(ctx context.Context, projectID int32) ([]database.ProjectMembersRow, error) {
+	return p.queries.ProjectMembers(ctx, p.db, projectID)
+}
+
+func (p *Postgres) ProjectMember(ctx context.Context, projectID, userID int32) (database.ProjectMember, error) {
+	return p.queries.ProjectMember(ctx, p.db, database.ProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    userID,
+	})
+}
+
+func (p *Postgres) InsertProjectMember(ctx context.Context, arg database.InsertProjectMemberParams) (database.ProjectMember, error) {
+	return p.queries.InsertProjectMember(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateProjectMemberRole(ctx context.Context, arg database.UpdateProjectMemberRoleParams) (database.ProjectMember, error) {
+	return p.queries.UpdateProjectMemberRole(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteProjectMember(ctx context.Context, projectID, userID int32) error {
+	return p.queries.DeleteProjectMember(ctx, p.db, database.DeleteProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    userID,
+	})
+}
+
+func (p *Postgres) MemberProjects(ctx context.Context, userID int32) ([]database.Project, error) {
+	return p.queries.MemberProjects(ctx, p.db, userID)
+}
+
+func (p *Postgres) TransferProject(ctx context.Context, projectID, userID int32) (database.Project, error) {
+	return p.queries.TransferProject(ctx, p.db, database.TransferProjectParams{
+		UserID:    userID,
+		ProjectID: projectID,
+	})
+}
+
+func (p *Postgres) ProjectInvitations(ctx context.Context, projectID int32) ([]database.ProjectInvitation, error) {
+	return p.queries.ProjectInvitations(ctx, p.db, projectID)
+}
+
+func (p *Postgres) ProjectInvitationByUUID(ctx context.Context, invitationUuid uuid.UUID) (database.ProjectInvitation, error) {
+	return p.queries.ProjectInvitationByUUID(ctx, p.db, invitationUuid)
+}
+
+func (p *Postgres) ProjectInvitationByEmail(ctx context.Context, projectID int32, email string) (database.ProjectInvitation, error) {
+	return p.queries.ProjectInvitationByEmail(ctx, p.db, database.ProjectInvitationByEmailParams{
+		ProjectID: projectID,
+		Email:     email,
+	})
+}
+
+func (p *Postgres) UserProjectInvitations(ctx context.Context, email string) ([]database.UserProjectInvitationsRow, error) {
+	return p.queries.UserProjectInvitations(ctx, p.db, email)
+}
+
+func (p *Postgres) InsertProjectInvitation(ctx context.Context, arg database.InsertProjectInvitationParams) (database.ProjectInvitation, error) {
+	return p.queries.InsertProjectInvitation(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteProjectInvitation(ctx context.Context, projectInvitationID int32) error {
+	return p.queries.DeleteProjectInvitation(ctx, p.db, projectInvitationID)
+}
+
+func (p *Postgres) Events(ctx context.Context, tags []string, page Page) ([]database.Event, error) {
+	switch {
+	case len(tags) > 0 && page.Ascending:
+		return p.queries.EventsByTagsAscOffsetLimit(ctx, p.db, database.EventsByTagsAscOffsetLimitParams{
+			Tags:   tags,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	case len(tags) > 0:
+		return p.queries.EventsByTagsDescOffsetLimit(ctx, p.db, database.EventsByTagsDescOffsetLimitParams{
+			Tags:   tags,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	case page.Ascending:
+		return p.queries.EventsByAscOffsetLimit(ctx, p.db, database.EventsByAscOffsetLimitParams{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	default:
+		return p.queries.EventsByDescOffsetLimit(ctx, p.db, database.EventsByDescOffsetLimitParams{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	}
+}
+
+func (p *Postgres) CountEvents(ctx context.Context, tags []string) (int64, error) {
+	if len(tags) > 0 {
+		return p.queries.CountEventsByTags(ctx, p.db, tags)
+	}
+
+	return p.queries.CountEvents(ctx, p.db)
+}
+
+func (p *Postgres) UserEvents(ctx context.Context, userID int32, page Page) ([]database.Event, error) {
+	if page.Ascending {
+		return p.queries.UserEventsByAscOffsetLimit(ctx, p.db, database.UserEventsByAscOffsetLimitParams{
+			UserID: userID,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		})
+	}
+
+	return p.queries.UserEventsByDescOffsetLimit(ctx, p.db, database.UserEventsByDescOffsetLimitParams{
+		UserID: userID,
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+}
+
+func (p *Postgres) AllUserEvents(ctx context.Context, userID int32) ([]database.Event, error) {
+	return p.queries.UserEvents(ctx, p.db, userID)
+}
+
+func (p *Postgres) CountUserEvents(ctx context.Context, userID int32) (int64, error) {
+	return p.queries.CountUserEvents(ctx, p.db, userID)
+}
+
+func (p *Postgres) EventByUUID(ctx context.Context, eventUuid uuid.UUID) (database.Event, error) {
+	return p.queries.EventByUUID(ctx, p.db, eventUuid)
+}
+
+func (p *Postgres) EventsByIDs(ctx context.Context, eventIDs []int32) ([]database.Event, error) {
+	return p.queries.EventsByIDs(ctx, p.db, eventIDs)
+}
+
+func (p *Postgres) UserEventByName(ctx context.Context, userID int32, name string) (database.Event, error) {
+	return p.queries.UserEventByName(ctx, p.db, database.UserEventByNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+}
+
+func (p *Postgres) InsertEvent(ctx context.Context, arg database.InsertEventParams) (database.Event, error) {
+	return p.queries.InsertEvent(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateEvent(ctx context.Context, arg database.UpdateEventParams) (database.Event, error) {
+	return p.queries.UpdateEvent(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteEvent(ctx context.Context, eventID int32) error {
+	return p.queries.DeleteEvent(ctx, p.db, eventID)
+}
+
+func (p *Postgres) UpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time, page Page) ([]database.Event, error) {
+	return p.queries.UpcomingOrganisationEventsByOffsetLimit(ctx, p.db, database.UpcomingOrganisationEventsByOffsetLimitParams{
+		OrganisationID: nulls.NewInt32(organisationID),
+		EndsAt:         now,
+		Offset:         page.Offset,
+		Limit:          page.Limit,
+	})
+}
+
+func (p *Postgres) CountUpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time) (int64, error) {
+	return p.queries.CountUpcomingOrganisationEvents(ctx, p.db, database.CountUpcomingOrganisationEventsParams{
+		OrganisationID: nulls.NewInt32(organisationID),
+		EndsAt:         now,
+	})
+}
+
+func (p *Postgres) SetEventOrganisation(ctx context.Context, eventID int32, organisationID nulls.Int32) (database.Event, error) {
+	return p.queries.SetEventOrganisation(ctx, p.db, database.SetEventOrganisationParams{
+		OrganisationID: organisationID,
+		EventID:        eventID,
+	})
+}
+
+func (p *Postgres) Organisations(ctx context.Context, filter OrganisationFilter, page Page) ([]database.Organisation, error) {
+	if page.Ascending {
+		return p.queries.OrganisationsByAscOffsetLimit(ctx, p.db, database.OrganisationsByAscOffsetLimitParams{
+			Industry: filter.Industry,
+			HqState:  filter.HqState,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+		})
+	}
+
+	return p.queries.OrganisationsByDescOffsetLimit(ctx, p.db, database.OrganisationsByDescOffsetLimitParams{
+		Industry: filter.Industry,
+		HqState:  filter.HqState,
+		Offset:   page.Offset,
+		Limit:    page.Limit,
+	})
+}
+
+func (p *Postgres) CountOrganisations(ctx context.Context, filter OrganisationFilter) (int64, error) {
+	return p.queries.CountOrganisations(ctx, p.db, database.CountOrganisationsParams{
+		Industry: filter.Industry,
+		HqState:  filter.HqState,
+	})
+}
+
+func (p *Postgres) OrganisationByUUID(ctx context.Context, organisationUuid uuid.UUID) (database.Organisation, error) {
+	return p.queries.OrganisationByUUID(ctx, p.db, organisationUuid)
+}
+
+func (p *Postgres) OrganisationBySlug(ctx context.Context, slug string) (database.Organisation, error) {
+	return p.queries.OrganisationBySlug(ctx, p.db, slug)
+}
+
+func (p *Postgres) InsertOrganisation(ctx context.Context, arg database.InsertOrganisationParams) (database.Organisation, error) {
+	return p.queries.InsertOrganisation(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateOrganisation(ctx context.Context, arg database.UpdateOrganisationParams) (database.Organisation, error) {
+	return p.queries.UpdateOrganisation(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteOrganisation(ctx context.Context, organisationID int32) error {
+	return p.queries.DeleteOrganisation(ctx, p.db, organisationID)
+}
+
+func (p *Postgres) UserOrganisations(ctx context.Context, userID int32) ([]database.Organisation, error) {
+	return p.queries.UserOrganisations(ctx, p.db, userID)
+}
+
+func (p *Postgres) OrganisationMembers(ctx context.Context, organisationID int32) ([]database.OrganisationMembersRow, error) {
+	return p.queries.OrganisationMembers(ctx, p.db, organisationID)
+}
+
+func (p *Postgres) OrganisationMember(ctx context.Context, organisationID, userID int32) (database.OrganisationMember, error) {
+	return p.queries.OrganisationMember(ctx, p.db, database.OrganisationMemberParams{
+		OrganisationID: organisationID,
+		UserID:         userID,
+	})
+}
+
+func (p *Postgres) InsertOrganisationMember(ctx context.Context, arg database.InsertOrganisationMemberParams) (database.OrganisationMember, error) {
+	return p.queries.InsertOrganisationMember(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateOrganisationMemberRole(ctx context.Context, arg database.UpdateOrganisationMemberRoleParams) (database.OrganisationMember, error) {
+	return p.queries.UpdateOrganisationMemberRole(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteOrganisationMember(ctx context.Context, organisationID, userID int32) error {
+	return p.queries.DeleteOrganisationMember(ctx, p.db, database.DeleteOrganisationMemberParams{
+		OrganisationID: organisationID,
+		UserID:         userID,
+	})
+}
+
+func (p *Postgres) CountOrganisationOwners(ctx context.Context, organisationID int32) (int64, error) {
+	return p.queries.CountOrganisationOwners(ctx, p.db, organisationID)
+}
+
+func (p *Postgres) ProjectsDueForRepositoryStats(ctx context.Context, staleBefore time.Time, limit int32) ([]database.ProjectsDueForRepositoryStatsRow, error) {
+	return p.queries.ProjectsDueForRepositoryStats(ctx, p.db, database.ProjectsDueForRepositoryStatsParams{
+		StaleBefore: staleBefore,
+		Limit:       limit,
+	})
+}
+
+func (p *Postgres) ProjectRepositoryStats(ctx context.Context, projectIDs []int32) ([]database.ProjectRepositoryStat, error) {
+	return p.queries.ProjectRepositoryStats(ctx, p.db, projectIDs)
+}
+
+func (p *Postgres) UpsertProjectRepositoryStats(ctx context.Context, arg database.UpsertProjectRepositoryStatsParams) (database.ProjectRepositoryStat, error) {
+	return p.queries.UpsertProjectRepositoryStats(ctx, p.db, arg)
+}
+
+func (p *Postgres) TouchProjectRepositoryStats(ctx context.Context, projectID int32, repository string, fetchedAt time.Time) error {
+	return p.queries.TouchProjectRepositoryStats(ctx, p.db, database.TouchProjectRepositoryStatsParams{
+		ProjectID:  projectID,
+		Repository: repository,
+		FetchedAt:  fetchedAt,
+	})
+}
+
+func (p *Postgres) EnqueueJob(ctx context.Context, arg database.EnqueueJobParams) (database.Job, error) {
+	job, err := p.queries.EnqueueJob(ctx, p.db, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		// ON CONFLICT DO NOTHING returns no row when the unique key is taken.
+		return job, ErrJobExists
+	}
+
+	return job, err
+}
+
+func (p *Postgres) ClaimJobs(ctx context.Context, now, lockExpiredBefore time.Time, maxAttempts, limit int32) ([]database.Job, error) {
+	if err := p.queries.KillExpiredJobs(ctx, p.db, database.KillExpiredJobsParams{
+		LastError:         nulls.NewString(errJobLockExpired),
+		Now:               nulls.NewTime(now),
+		LockExpiredBefore: lockExpiredBefore,
+		MaxAttempts:       maxAttempts,
+	}); err != nil {
+		return nil, err
+	}
+
+	return p.queries.ClaimJobs(ctx, p.db, database.ClaimJobsParams{
+		Now:               now,
+		LockExpiredBefore: lockExpiredBefore,
+		MaxAttempts:       maxAttempts,
+		Limit:             limit,
+	})
+}
+
+func (p *Postgres) CompleteJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time) error {
+	n, err := p.queries.CompleteJob(ctx, p.db, database.CompleteJobParams{
+		FinishedAt: nulls.NewTime(finishedAt),
+		JobID:      jobID,
+		LockedAt:   nulls.NewTime(lockedAt),
+	})
+	if err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (p *Postgres) RetryJob(ctx context.Context, jobID int32, lockedAt, runAt time.Time, lastError string) error {
+	n, err := p.queries.RetryJob(ctx, p.db, database.RetryJobParams{
+		LastError: nulls.NewString(lastError),
+		RunAt:     runAt,
+		JobID:     jobID,
+		LockedAt:  nulls.NewTime(lockedAt),
+	})
+	if err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (p *Postgres) KillJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time, lastError string) error {
+	n, err := p.queries.KillJob(ctx, p.db, database.KillJobParams{
+		LastError:  nulls.NewString(lastError),
+		FinishedAt: nulls.NewTime(finishedAt),
+		JobID:      jobID,
+		LockedAt:   nulls.NewTime(lockedAt),
+	})
+	if err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (p *Postgres) DeadJobs(ctx context.Context, limit int32) ([]database.Job, error) {
+	return p.queries.DeadJobs(ctx, p.db, limit)
+}
+
+func (p *Postgres) RequeueDeadJob(ctx context.Context, jobID int32, runAt time.Time) (database.Job, error) {
+	return p.queries.RequeueDeadJob(ctx, p.db, database.RequeueDeadJobParams{
+		RunAt: runAt,
+		JobID: jobID,
+	})
+}
+
+func (p *Postgres) DeleteCompletedJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	return p.queries.DeleteCompletedJobs(ctx, p.db, finishedBefore)
+}
+
+func (p *Postgres) UserWebhooks(ctx context.Context, userID int32) ([]database.Webhook, error) {
+	return p.queries.UserWebhooks(ctx, p.db, userID)
+}
+
+func (p *Postgres) CountUserWebhooks(ctx context.Context, userID int32) (int64, error) {
+	return p.queries.CountUserWebhooks(ctx, p.db, userID)
+}
+
+func (p *Postgres) WebhookByUUID(ctx context.Context, webhookUuid uuid.UUID) (database.Webhook, error) {
+	return p.queries.WebhookByUUID(ctx, p.db, webhookUuid)
+}
+
+func (p *Postgres) WebhookByID(ctx context.Context, webhookID int32) (database.Webhook, error) {
+	return p.queries.WebhookByID(ctx, p.db, webhookID)
+}
+
+func (p *Postgres) InsertWebhook(ctx context.Context, arg database.InsertWebhookParams) (database.Webhook, error) {
+	return p.queries.InsertWebhook(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateWebhook(ctx context.Context, arg database.UpdateWebhookParams) (database.Webhook, error) {
+	return p.queries.UpdateWebhook(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteWebhook(ctx context.Context, webhookID int32) error {
+	return p.queries.DeleteWebhook(ctx, p.db, webhookID)
+}
+
+func (p *Postgres) SubscribedWebhooks(ctx context.Context, eventType string, userID int32) ([]database.Webhook, error) {
+	return p.queries.SubscribedWebhooks(ctx, p.db, database.SubscribedWebhooksParams{
+		EventType: eventType,
+		UserID:    userID,
+	})
+}
+
+func (p *Postgres) InsertWebhookDelivery(ctx context.Context, arg database.InsertWebhookDeliveryParams) (database.WebhookDelivery, error) {
+	return p.queries.InsertWebhookDelivery(ctx, p.db, arg)
+}
+
+func (p *Postgres) WebhookDeliveryByUUID(ctx context.Context, deliveryUuid uuid.UUID) (database.WebhookDelivery, error) {
+	return p.queries.WebhookDeliveryByUUID(ctx, p.db, deliveryUuid)
+}
+
+func (p *Postgres) WebhookDeliveryByID(ctx context.Context, webhookDeliveryID int32) (database.WebhookDelivery, error) {
+	return p.queries.WebhookDeliveryByID(ctx, p.db, webhookDeliveryID)
+}
+
+func (p *Postgres) WebhookDeliveries(ctx context.Context, webhookID int32, page Page) ([]database.WebhookDelivery, error) {
+	return p.queries.WebhookDeliveries(ctx, p.db, database.WebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Offset:    page.Offset,
+		Limit:     page.Limit,
+	})
+}
+
+func (p *Postgres) CountWebhookDeliveries(ctx context.Context, webhookID int32) (int64, error) {
+	return p.queries.CountWebhookDeliveries(ctx, p.db, webhookID)
+}
+
+func (p *Postgres) RecordWebhookDeliveryAttempt(ctx context.Context, arg database.RecordWebhookDeliveryAttemptParams) (database.WebhookDelivery, error) {
+	return p.queries.RecordWebhookDeliveryAttempt(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error) {
+	return p.queries.DeleteWebhookDeliveries(ctx, p.db, createdBefore)
+}
+
+func (p *Postgres) NotificationPreferences(ctx context.Context, userID int32) (database.NotificationPreference, error) {
+	return p.queries.NotificationPreferences(ctx, p.db, userID)
+}
+
+func (p *Postgres) NotificationPreferencesByUnsubscribeToken(ctx context.Context, token uuid.UUID) (database.NotificationPreference, error) {
+	return p.queries.NotificationPreferencesByUnsubscribeToken(ctx, p.db, token)
+}
+
+func (p *Postgres) UpdateNotificationPreferences(ctx context.Context, arg database.UpdateNotificationPreferencesParams) (database.NotificationPreference, error) {
+	return p.queries.UpdateNotificationPreferences(ctx, p.db, arg)
+}
+
+func (p *Postgres) Follows(ctx context.Context, followerID int32) ([]database.FollowsRow, error) {
+	return p.queries.Follows(ctx, p.db, followerID)
+}
+
+func (p *Postgres) CountFollows(ctx context.Context, followerID int32) (int64, error) {
+	return p.queries.CountFollows(ctx, p.db, followerID)
+}
+
+func (p *Postgres) InsertFollow(ctx context.Context, arg database.InsertFollowParams) (database.Follow, error) {
+	follow, err := p.queries.InsertFollow(ctx, p.db, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		// ON CONFLICT DO NOTHING returns no row when it is already followed.
+		return follow, ErrFollowExists
+	}
+
+	return follow, err
+}
+
+func (p *Postgres) DeleteFollow(ctx context.Context, arg database.DeleteFollowParams) error {
+	n, err := p.queries.DeleteFollow(ctx, p.db, arg)
+	if err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// feedBefore returns the position a feed query starts at, which is past every record for the
+// zero Cursor.
+func feedBefore(c Cursor) (time.Time, int32) {
+	if c.CreatedAt.IsZero() {
+		return time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC), math.MaxInt32
+	}
+
+	return c.CreatedAt, c.ID
+}
+
+func (p *Postgres) FeedProjects(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Project, error) {
+	createdAt, id := feedBefore(before)
+	return p.queries.FeedProjects(ctx, p.db, database.FeedProjectsParams{
+		FollowerID:      followerID,
+		BeforeCreatedAt: createdAt,
+		BeforeID:        id,
+		Limit:           limit,
+	})
+}
+
+func (p *Postgres) FeedEvents(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Event, error) {
+	createdAt, id := feedBefore(before)
+	return p.queries.FeedEvents(ctx, p.db, database.FeedEventsParams{
+		FollowerID:      followerID,
+		BeforeCreatedAt: createdAt,
+		BeforeID:        id,
+		Limit:           limit,
+	})
+}
+
+func (p *Postgres) UserBookmarks(ctx context.Context, userID int32, page Page) ([]database.Bookmark, error) {
+	return p.queries.UserBookmarksByOffsetLimit(ctx, p.db, database.UserBookmarksByOffsetLimitParams{
+		UserID: userID,
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+}
+
+func (p *Postgres) CountUserBookmarks(ctx context.Context, userID int32) (int64, error) {
+	return p.queries.CountUserBookmarks(ctx, p.db, userID)
+}
+
+func (p *Postgres) InsertBookmark(ctx context.Context, arg database.InsertBookmarkParams) (database.Bookmark, error) {
+	bookmark, err := p.queries.InsertBookmark(ctx, p.db, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		// ON CONFLICT DO NOTHING returns no row when it is already bookmarked.
+		return bookmark, ErrBookmarkExists
+	}
+
+	return bookmark, err
+}
+
+func (p *Postgres) DeleteBookmark(ctx context.Context, arg database.DeleteBookmarkParams) error {
+	n, err := p.queries.DeleteBookmark(ctx, p.db, arg)
+	if err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (p *Postgres) UserCollections(ctx context.Context, userID int32) ([]database.Collection, error) {
+	return p.queries.UserCollections(ctx, p.db, userID)
+}
+
+func (p *Postgres) CountUserCollections(ctx context.Context, userID int32) (int64, error) {
+	return p.queries.CountUserCollections(ctx, p.db, userID)
+}
+
+func (p *Postgres) CollectionByUUID(ctx context.Context, collectionUuid uuid.UUID) (database.Collection, error) {
+	return p.queries.CollectionByUUID(ctx, p.db, collectionUuid)
+}
+
+func (p *Postgres) InsertCollection(ctx context.Context, arg database.InsertCollectionParams) (database.Collection, error) {
+	return p.queries.InsertCollection(ctx, p.db, arg)
+}
+
+func (p *Postgres) UpdateCollection(ctx context.Context, arg database.UpdateCollectionParams) (database.Collection, error) {
+	return p.queries.UpdateCollection(ctx, p.db, arg)
+}
+
+func (p *Postgres) DeleteCollection(ctx context.Context, collectionID int32) error {
+	return p.queries.DeleteCollection(ctx, p.db, collectionID)
+}
+
+func (p *Postgres) CollectionItems(ctx context.Context, collectionID int32) ([]database.CollectionItem, error) {
+	return p.queries.CollectionItems(ctx, p.db, collectionID)
+}
+
+func (p *Postgres) CountCollectionItems(ctx context.Context, collectionID int32) (int64, error) {
+	return p.queries.CountCollectionItems(ctx, p.db, collectionID)
+}
+
+func (p *Postgres) InsertCollectionItem(ctx context.Context, arg database.InsertCollectionItemParams) (database.CollectionItem, error) {
+	item, err := p.queries.InsertCollectionItem(ctx, p.db, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		// ON CONFLICT DO NOTHING returns no row when the collection already holds it.
+		return item, ErrCollectionItemExists
+	}
+
+	return item, err
+}
+
+func (p *Postgres) UpdateCollectionItemPosition(ctx context.Context, collectionItemID, position int32) error {
+	return p.queries.UpdateCollectionItemPosition(ctx, p.db, database.UpdateCollectionItemPositionParams{
+		Position:         position,
+		CollectionItemID: collectionItemID,
+	})
+}
+
+func (p *Postgres) DeleteCollectionItem(ctx context.Context, collectionItemID int32) error {
+	return p.queries.DeleteCollectionItem(ctx, p.db, collectionItemID)
+}
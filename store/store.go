@@ -0,0 +1,329 @@
+// Package store hides the queries of the API behind interfaces, so that handlers can run
+// against Postgres in production and against an in-memory fake in tests.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/awesome-my/backend/database"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// ErrNotFound is returned when a record does not exist. It is sql.ErrNoRows, so callers
+// checking for either are served.
+var ErrNotFound = sql.ErrNoRows
+
+// ErrJobExists is returned when enqueuing a job whose unique key was already enqueued.
+var ErrJobExists = errors.New("a job with the same unique key exists")
+
+// ErrFollowExists is returned when following a user, organisation or tag already followed.
+var ErrFollowExists = errors.New("the follow exists")
+
+// ErrBookmarkExists is returned when bookmarking a project or event already bookmarked.
+var ErrBookmarkExists = errors.New("the bookmark exists")
+
+// ErrCollectionItemExists is returned when adding a project or event to a collection that
+// already holds it.
+var ErrCollectionItemExists = errors.New("the collection item exists")
+
+// errJobLockExpired is the last error of the jobs killed as their lock expired during their
+// last attempt, e.g. as their worker died.
+const errJobLockExpired = "the job lock expired during its last attempt"
+
+// Page selects a page of a list ordered by creation, newest first unless Ascending is set.
+type Page struct {
+	Ascending bool
+	Offset    int32
+	Limit     int32
+}
+
+// OrganisationFilter narrows a list of organisations, empty fields match every organisation.
+type OrganisationFilter struct {
+	Industry string
+	HqState  string
+}
+
+// Cursor is a position in a list ordered by creation then ID, newest first. A page starting
+// at a cursor holds the records created before CreatedAt, or at CreatedAt with an ID lower
+// than ID. The zero Cursor starts at the newest record.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int32
+}
+
+type UserStore interface {
+	UserByUUID(ctx context.Context, userUuid uuid.UUID) (database.User, error)
+	UserByID(ctx context.Context, userID int32) (database.User, error)
+	// UserByGithubEmail matches the email case-insensitively.
+	UserByGithubEmail(ctx context.Context, githubEmail string) (database.User, error)
+	InsertUser(ctx context.Context, githubEmail string) (database.User, error)
+	ScheduleUserDeletion(ctx context.Context, arg database.ScheduleUserDeletionParams) (database.User, error)
+	CancelUserDeletion(ctx context.Context, userID int32) (database.User, error)
+	// DeleteDueUsers deletes the users due for deletion at now along with everything they
+	// own, returning their IDs.
+	DeleteDueUsers(ctx context.Context, now time.Time) ([]int32, error)
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+type UserSessionStore interface {
+	InsertUserSession(ctx context.Context, arg database.InsertUserSessionParams) (database.UserSession, error)
+	UserSessionByUUID(ctx context.Context, sessionUuid uuid.UUID) (database.UserSession, error)
+	// UserSessions lists the sessions of a user that have not expired at now, most recently
+	// seen first.
+	UserSessions(ctx context.Context, userID int32, now time.Time) ([]database.UserSession, error)
+	TouchUserSession(ctx context.Context, arg database.TouchUserSessionParams) error
+	DeleteUserSession(ctx context.Context, sessionUuid uuid.UUID) error
+	DeleteUserSessions(ctx context.Context, userID int32) error
+	DeleteExpiredUserSessions(ctx context.Context, now time.Time) error
+}
+
+type ProjectStore interface {
+	// Projects lists the projects having any of tags, or every project when tags is empty.
+	Projects(ctx context.Context, tags []string, page Page) ([]database.Project, error)
+	CountProjects(ctx context.Context, tags []string) (int64, error)
+	UserProjects(ctx context.Context, userID int32, page Page) ([]database.Project, error)
+	// AllUserProjects lists every project of a user, oldest first.
+	AllUserProjects(ctx context.Context, userID int32) ([]database.Project, error)
+	CountUserProjects(ctx context.Context, userID int32) (int64, error)
+	ProjectByUUID(ctx context.Context, projectUuid uuid.UUID) (database.Project, error)
+	// ProjectsByIDs lists the projects of projectIDs that exist, oldest first.
+	ProjectsByIDs(ctx context.Context, projectIDs []int32) ([]database.Project, error)
+	UserProjectByName(ctx context.Context, userID int32, name string) (database.Project, error)
+	InsertProject(ctx context.Context, arg database.InsertProjectParams) (database.Project, error)
+	UpdateProject(ctx context.Context, arg database.UpdateProjectParams) (database.Project, error)
+	DeleteProject(ctx context.Context, projectID int32) error
+	OrganisationProjects(ctx context.Context, organisationID int32, page Page) ([]database.Project, error)
+	CountOrganisationProjects(ctx context.Context, organisationID int32) (int64, error)
+	// SetProjectOrganisation hands a project to an organisation, or takes it away from its
+	// organisation when organisationID is not valid.
+	SetProjectOrganisation(ctx context.Context, projectID int32, organisationID nulls.Int32) (database.Project, error)
+}
+
+// ProjectMemberStore holds the members of projects besides their owner, who is the user of
+// the project, and the invitations to become one.
+type ProjectMemberStore interface {
+	ProjectMembers(ctx context.Context, projectID int32) ([]database.ProjectMembersRow, error)
+	ProjectMember(ctx context.Context, projectID, userID int32) (database.ProjectMember, error)
+	InsertProjectMember(ctx context.Context, arg database.InsertProjectMemberParams) (database.ProjectMember, error)
+	UpdateProjectMemberRole(ctx context.Context, arg database.UpdateProjectMemberRoleParams) (database.ProjectMember, error)
+	DeleteProjectMember(ctx context.Context, projectID, userID int32) error
+	// MemberProjects lists the projects a user is a member of, newest first.
+	MemberProjects(ctx context.Context, userID int32) ([]database.Project, error)
+	// TransferProject makes a user the owner of a project, leaving memberships untouched.
+	TransferProject(ctx context.Context, projectID, userID int32) (database.Project, error)
+	ProjectInvitations(ctx context.Context, projectID int32) ([]database.ProjectInvitation, error)
+	ProjectInvitationByUUID(ctx context.Context, invitationUuid uuid.UUID) (database.ProjectInvitation, error)
+	ProjectInvitationByEmail(ctx context.Context, projectID int32, email string) (database.ProjectInvitation, error)
+	// UserProjectInvitations lists the invitations sent to an email along with their project.
+	UserProjectInvitations(ctx context.Context, email string) ([]database.UserProjectInvitationsRow, error)
+	InsertProjectInvitation(ctx context.Context, arg database.InsertProjectInvitationParams) (database.ProjectInvitation, error)
+	DeleteProjectInvitation(ctx context.Context, projectInvitationID int32) error
+}
+
+type EventStore interface {
+	// Events lists the events having any of tags, or every event when tags is empty.
+	Events(ctx context.Context, tags []string, page Page) ([]database.Event, error)
+	CountEvents(ctx context.Context, tags []string) (int64, error)
+	UserEvents(ctx context.Context, userID int32, page Page) ([]database.Event, error)
+	// AllUserEvents lists every event of a user, oldest first.
+	AllUserEvents(ctx context.Context, userID int32) ([]database.Event, error)
+	CountUserEvents(ctx context.Context, userID int32) (int64, error)
+	EventByUUID(ctx context.Context, eventUuid uuid.UUID) (database.Event, error)
+	// EventsByIDs lists the events of eventIDs that exist, oldest first.
+	EventsByIDs(ctx context.Context, eventIDs []int32) ([]database.Event, error)
+	UserEventByName(ctx context.Context, userID int32, name string) (database.Event, error)
+	InsertEvent(ctx context.Context, arg database.InsertEventParams) (database.Event, error)
+	UpdateEvent(ctx context.Context, arg database.UpdateEventParams) (database.Event, error)
+	DeleteEvent(ctx context.Context, eventID int32) error
+	// UpcomingOrganisationEvents lists the events of an organisation that have not ended at
+	// now, soonest first regardless of page.Ascending.
+	UpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time, page Page) ([]database.Event, error)
+	CountUpcomingOrganisationEvents(ctx context.Context, organisationID int32, now time.Time) (int64, error)
+	// SetEventOrganisation hands an event to an organisation, or takes it away from its
+	// organisation when organisationID is not valid.
+	SetEventOrganisation(ctx context.Context, eventID int32, organisationID nulls.Int32) (database.Event, error)
+}
+
+type OrganisationStore interface {
+	Organisations(ctx context.Context, filter OrganisationFilter, page Page) ([]database.Organisation, error)
+	CountOrganisations(ctx context.Context, filter OrganisationFilter) (int64, error)
+	OrganisationByUUID(ctx context.Context, organisationUuid uuid.UUID) (database.Organisation, error)
+	OrganisationBySlug(ctx context.Context, slug string) (database.Organisation, error)
+	InsertOrganisation(ctx context.Context, arg database.InsertOrganisationParams) (database.Organisation, error)
+	UpdateOrganisation(ctx context.Context, arg database.UpdateOrganisationParams) (database.Organisation, error)
+	// DeleteOrganisation deletes an organisation, its projects and events are kept without
+	// an organisation.
+	DeleteOrganisation(ctx context.Context, organisationID int32) error
+	// UserOrganisations lists the organisations a user is a member of, oldest first.
+	UserOrganisations(ctx context.Context, userID int32) ([]database.Organisation, error)
+	OrganisationMembers(ctx context.Context, organisationID int32) ([]database.OrganisationMembersRow, error)
+	OrganisationMember(ctx context.Context, organisationID, userID int32) (database.OrganisationMember, error)
+	InsertOrganisationMember(ctx context.Context, arg database.InsertOrganisationMemberParams) (database.OrganisationMember, error)
+	UpdateOrganisationMemberRole(ctx context.Context, arg database.UpdateOrganisationMemberRoleParams) (database.OrganisationMember, error)
+	DeleteOrganisationMember(ctx context.Context, organisationID, userID int32) error
+	CountOrganisationOwners(ctx context.Context, organisationID int32) (int64, error)
+}
+
+// RepositoryStatsStore holds the stats fetched from the GitHub repositories of projects, the
+// stats of a project only apply while it keeps the repository they were fetched for.
+type RepositoryStatsStore interface {
+	// ProjectsDueForRepositoryStats lists up to limit projects having a repository whose
+	// stats were never fetched or were fetched before staleBefore, least recently fetched
+	// first.
+	ProjectsDueForRepositoryStats(ctx context.Context, staleBefore time.Time, limit int32) ([]database.ProjectsDueForRepositoryStatsRow, error)
+	// ProjectRepositoryStats lists the stats of the projects whose repository was found.
+	ProjectRepositoryStats(ctx context.Context, projectIDs []int32) ([]database.ProjectRepositoryStat, error)
+	UpsertProjectRepositoryStats(ctx context.Context, arg database.UpsertProjectRepositoryStatsParams) (database.ProjectRepositoryStat, error)
+	// TouchProjectRepositoryStats records a fetch of the repository of the project that
+	// brought no new stats, keeping the stats of the same repository. A project without
+	// stats for that repository is recorded as not found.
+	TouchProjectRepositoryStats(ctx context.Context, projectID int32, repository string, fetchedAt time.Time) error
+}
+
+// JobStore holds the queue of background jobs. Jobs are kept once completed, so their unique
+// key keeps a scheduled job from being enqueued twice, until they are deleted.
+type JobStore interface {
+	// EnqueueJob adds a job to the queue, or returns ErrJobExists when its unique key is taken.
+	EnqueueJob(ctx context.Context, arg database.EnqueueJobParams) (database.Job, error)
+	// ClaimJobs locks up to limit jobs due at now, along with the running jobs whose lock
+	// was taken before lockExpiredBefore, counting an attempt for each. Jobs locked by another
+	// transaction are skipped, so concurrent workers never claim the same job. Running jobs
+	// whose lock expired after their last attempt are killed instead, maxAttempts applies to
+	// the jobs enqueued without their own.
+	ClaimJobs(ctx context.Context, now, lockExpiredBefore time.Time, maxAttempts, limit int32) ([]database.Job, error)
+	// CompleteJob, RetryJob and KillJob record the outcome of a job claimed at lockedAt, or
+	// return ErrNotFound when its lock expired and it was claimed again or killed since.
+	CompleteJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time) error
+	// RetryJob releases a failed job to be claimed again from runAt.
+	RetryJob(ctx context.Context, jobID int32, lockedAt, runAt time.Time, lastError string) error
+	// KillJob moves a job that exhausted its attempts to the dead letters, where it stays
+	// until it is requeued.
+	KillJob(ctx context.Context, jobID int32, lockedAt, finishedAt time.Time, lastError string) error
+	// DeadJobs lists up to limit dead jobs, most recently killed first.
+	DeadJobs(ctx context.Context, limit int32) ([]database.Job, error)
+	// RequeueDeadJob gives a dead job a fresh set of attempts from runAt, or returns
+	// ErrNotFound when the job is not dead.
+	RequeueDeadJob(ctx context.Context, jobID int32, runAt time.Time) (database.Job, error)
+	// DeleteCompletedJobs deletes the jobs completed before finishedBefore, returning how many.
+	DeleteCompletedJobs(ctx context.Context, finishedBefore time.Time) (int64, error)
+}
+
+// WebhookStore holds the webhooks notified of changes to projects and events, and the log of
+// their deliveries.
+type WebhookStore interface {
+	// UserWebhooks lists the webhooks of a user, oldest first.
+	UserWebhooks(ctx context.Context, userID int32) ([]database.Webhook, error)
+	CountUserWebhooks(ctx context.Context, userID int32) (int64, error)
+	WebhookByUUID(ctx context.Context, webhookUuid uuid.UUID) (database.Webhook, error)
+	WebhookByID(ctx context.Context, webhookID int32) (database.Webhook, error)
+	InsertWebhook(ctx context.Context, arg database.InsertWebhookParams) (database.Webhook, error)
+	UpdateWebhook(ctx context.Context, arg database.UpdateWebhookParams) (database.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int32) error
+	// SubscribedWebhooks lists the active webhooks subscribed to eventType for a record owned
+	// by userID, which are the webhooks of the user along with the site webhooks.
+	SubscribedWebhooks(ctx context.Context, eventType string, userID int32) ([]database.Webhook, error)
+	InsertWebhookDelivery(ctx context.Context, arg database.InsertWebhookDeliveryParams) (database.WebhookDelivery, error)
+	WebhookDeliveryByUUID(ctx context.Context, deliveryUuid uuid.UUID) (database.WebhookDelivery, error)
+	WebhookDeliveryByID(ctx context.Context, webhookDeliveryID int32) (database.WebhookDelivery, error)
+	// WebhookDeliveries lists the deliveries of a webhook, newest first regardless of
+	// page.Ascending.
+	WebhookDeliveries(ctx context.Context, webhookID int32, page Page) ([]database.WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID int32) (int64, error)
+	// RecordWebhookDeliveryAttempt records the outcome of an attempt to deliver, counting it.
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg database.RecordWebhookDeliveryAttemptParams) (database.WebhookDelivery, error)
+	// DeleteWebhookDeliveries deletes the deliveries created before createdBefore, returning
+	// how many.
+	DeleteWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error)
+}
+
+// NotificationStore holds the notification preferences of users, which are created with
+// every notification enabled the first time they are needed.
+type NotificationStore interface {
+	// NotificationPreferences returns the preferences of a user, creating them when missing.
+	NotificationPreferences(ctx context.Context, userID int32) (database.NotificationPreference, error)
+	NotificationPreferencesByUnsubscribeToken(ctx context.Context, token uuid.UUID) (database.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, arg database.UpdateNotificationPreferencesParams) (database.NotificationPreference, error)
+}
+
+// FollowStore holds the users, organisations and tags followed by users. Their new projects
+// and events make up the feed of their followers.
+type FollowStore interface {
+	// Follows lists what a user follows along with the followed users and organisations,
+	// oldest first.
+	Follows(ctx context.Context, followerID int32) ([]database.FollowsRow, error)
+	CountFollows(ctx context.Context, followerID int32) (int64, error)
+	// InsertFollow follows a single user, organisation or tag, or returns ErrFollowExists
+	// when it is already followed.
+	InsertFollow(ctx context.Context, arg database.InsertFollowParams) (database.Follow, error)
+	// DeleteFollow unfollows a user, organisation or tag, or returns ErrNotFound when it was
+	// not followed.
+	DeleteFollow(ctx context.Context, arg database.DeleteFollowParams) error
+	// FeedProjects lists up to limit projects from before, newest first, which were created
+	// by the users, for the organisations or with the tags followed by followerID. The
+	// projects of the follower are left out.
+	FeedProjects(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Project, error)
+	// FeedEvents lists the events of the feed like FeedProjects.
+	FeedEvents(ctx context.Context, followerID int32, before Cursor, limit int32) ([]database.Event, error)
+}
+
+// BookmarkStore holds the projects and events bookmarked by users.
+type BookmarkStore interface {
+	// UserBookmarks lists the bookmarks of a user, newest first regardless of page.Ascending.
+	UserBookmarks(ctx context.Context, userID int32, page Page) ([]database.Bookmark, error)
+	CountUserBookmarks(ctx context.Context, userID int32) (int64, error)
+	// InsertBookmark bookmarks a single project or event, or returns ErrBookmarkExists when
+	// it is already bookmarked.
+	InsertBookmark(ctx context.Context, arg database.InsertBookmarkParams) (database.Bookmark, error)
+	// DeleteBookmark removes the bookmark of a project or event, or returns ErrNotFound when
+	// it was not bookmarked.
+	DeleteBookmark(ctx context.Context, arg database.DeleteBookmarkParams) error
+}
+
+// CollectionStore holds the named collections users put projects and events in, in the
+// order of their position.
+type CollectionStore interface {
+	// UserCollections lists the collections of a user, oldest first.
+	UserCollections(ctx context.Context, userID int32) ([]database.Collection, error)
+	CountUserCollections(ctx context.Context, userID int32) (int64, error)
+	CollectionByUUID(ctx context.Context, collectionUuid uuid.UUID) (database.Collection, error)
+	InsertCollection(ctx context.Context, arg database.InsertCollectionParams) (database.Collection, error)
+	UpdateCollection(ctx context.Context, arg database.UpdateCollectionParams) (database.Collection, error)
+	DeleteCollection(ctx context.Context, collectionID int32) error
+	// CollectionItems lists the items of a collection by position.
+	CollectionItems(ctx context.Context, collectionID int32) ([]database.CollectionItem, error)
+	CountCollectionItems(ctx context.Context, collectionID int32) (int64, error)
+	// InsertCollectionItem adds a single project or event after the last item of a
+	// collection, or returns ErrCollectionItemExists when the collection already holds it.
+	InsertCollectionItem(ctx context.Context, arg database.InsertCollectionItemParams) (database.CollectionItem, error)
+	UpdateCollectionItemPosition(ctx context.Context, collectionItemID, position int32) error
+	DeleteCollectionItem(ctx context.Context, collectionItemID int32) error
+}
+
+// Store gives access to every record of the API.
+type Store interface {
+	UserStore
+	UserSessionStore
+	ProjectStore
+	ProjectMemberStore
+	EventStore
+	OrganisationStore
+	RepositoryStatsStore
+	JobStore
+	WebhookStore
+	NotificationStore
+	FollowStore
+	BookmarkStore
+	CollectionStore
+
+	// Ping checks that the store can be reached.
+	Ping(ctx context.Context) error
+
+	// WithTx calls fn with a store whose operations run in a single transaction, which is
+	// committed when fn returns nil and rolled back otherwise. Calling WithTx on the store
+	// handed to fn runs in the same transaction.
+	WithTx(ctx context.Context, fn func(tx Store) error) error
+}
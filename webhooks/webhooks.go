@@ -0,0 +1,329 @@
+// Package webhooks notifies the URLs subscribed by users and administrators of changes to
+// projects and events. Every notification is recorded as a delivery, which is posted by the
+// job workers and retried with backoff until the URL answers with a 2xx status.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/store"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// The types of the events webhooks are notified of.
+const (
+	EventProjectCreated = "project.created"
+	EventProjectUpdated = "project.updated"
+	EventProjectDeleted = "project.deleted"
+	EventEventCreated   = "event.created"
+	EventEventUpdated   = "event.updated"
+	EventEventDeleted   = "event.deleted"
+	// EventPing is sent on demand to check a webhook, it cannot be subscribed to.
+	EventPing = "ping"
+)
+
+// EventTypes are the event types webhooks may subscribe to.
+var EventTypes = []string{
+	EventProjectCreated, EventProjectUpdated, EventProjectDeleted,
+	EventEventCreated, EventEventUpdated, EventEventDeleted,
+}
+
+const (
+	// MaxAttempts is how many times a delivery is attempted before it is failed.
+	MaxAttempts = 6
+	// DefaultTimeout bounds a delivery when the configuration leaves it unset.
+	DefaultTimeout = 10 * time.Second
+	// DefaultRetention is how long deliveries are kept when the configuration leaves it unset.
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// SignatureHeader carries the HMAC-SHA256 of the body keyed with the secret of the webhook,
+	// as sha256=<hex>.
+	SignatureHeader = "X-AwesomeMY-Signature-256"
+	EventHeader     = "X-AwesomeMY-Event"
+	DeliveryHeader  = "X-AwesomeMY-Delivery"
+
+	// maxResponseBody is how much of a response body is kept in the delivery log.
+	maxResponseBody = 4 << 10
+)
+
+// DeliverJob posts a delivery to its webhook.
+const DeliverJob jobs.Kind[DeliverPayload] = "webhooks.deliver"
+
+// PruneDeliveriesJob deletes the deliveries older than the retention period.
+const PruneDeliveriesJob jobs.Kind[struct{}] = "webhooks.prune_deliveries"
+
+type DeliverPayload struct {
+	DeliveryID int32 `json:"delivery_id"`
+}
+
+// Payload is the JSON body posted to webhooks.
+type Payload struct {
+	ID        uuid.UUID `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// Sign returns the value of the SignatureHeader of body for secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatch records a delivery of event for every webhook subscribed to it for a record owned
+// by userID, and enqueues them. Calling it within the transaction of the change only notifies
+// the webhooks once the change is committed.
+func Dispatch(ctx context.Context, st store.Store, event string, userID int32, data any) error {
+	webhooks, err := st.SubscribedWebhooks(ctx, event, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := InsertDelivery(ctx, st, webhook, event, data)
+		if err != nil {
+			return err
+		}
+
+		if err := Enqueue(ctx, st, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InsertDelivery records a delivery of event to webhook without enqueuing it.
+func InsertDelivery(ctx context.Context, st store.WebhookStore, webhook database.Webhook, event string, data any) (database.WebhookDelivery, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return database.WebhookDelivery{}, err
+	}
+
+	payload, err := json.Marshal(Payload{
+		ID:        id,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return database.WebhookDelivery{}, err
+	}
+
+	return st.InsertWebhookDelivery(ctx, database.InsertWebhookDeliveryParams{
+		Uuid:      id,
+		EventType: event,
+		Payload:   payload,
+		WebhookID: webhook.WebhookID,
+	})
+}
+
+// Enqueue enqueues a job posting delivery.
+func Enqueue(ctx context.Context, st store.JobStore, delivery database.WebhookDelivery) error {
+	_, err := jobs.Enqueue(ctx, st, DeliverJob, DeliverPayload{DeliveryID: delivery.WebhookDeliveryID}, jobs.EnqueueOptions{
+		MaxAttempts: MaxAttempts,
+	})
+	return err
+}
+
+// Deliverer posts deliveries to their webhook and records the outcome.
+type Deliverer struct {
+	logger    *slog.Logger
+	store     store.WebhookStore
+	client    *http.Client
+	retention time.Duration
+}
+
+// NewDeliverer creates a Deliverer from the webhooks configuration, unset settings take their
+// default.
+func NewDeliverer(logger *slog.Logger, cfg awesomemy.WebhooksConfig, st store.WebhookStore) *Deliverer {
+	timeout := cfg.Timeout.Duration
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	retention := cfg.Retention.Duration
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = refusePrivateNetworks
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Deliverer{
+		logger: logger,
+		store:  st,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			// A redirect is answered like any other non-2xx status.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		retention: retention,
+	}
+}
+
+// refusePrivateNetworks refuses the connections to addresses that are not public, checked
+// once resolved so a hostname cannot point a webhook at an internal service.
+func refusePrivateNetworks(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("refusing to deliver to the non-public address %s", host)
+	}
+
+	return nil
+}
+
+// Deliver posts a delivery to its webhook and records the outcome of the attempt, returning
+// an error when the webhook did not answer with a 2xx status. The delivery is failed once
+// its last attempt is made, after a single attempt for pings, or when the webhook was
+// deactivated in the meantime.
+func (d *Deliverer) Deliver(ctx context.Context, deliveryID int32) (database.WebhookDelivery, error) {
+	delivery, err := d.store.WebhookDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return delivery, err
+	}
+
+	webhook, err := d.store.WebhookByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return delivery, err
+	}
+
+	last := delivery.Attempts+1 >= MaxAttempts || delivery.EventType == EventPing
+	record := database.RecordWebhookDeliveryAttemptParams{
+		Status:            "failed",
+		DeliveredAt:       nulls.NewTime(time.Now()),
+		WebhookDeliveryID: delivery.WebhookDeliveryID,
+	}
+
+	var deliverErr error
+	if !webhook.Active && delivery.EventType != EventPing {
+		deliverErr = errors.New("the webhook is inactive")
+		last = true
+	} else {
+		started := time.Now()
+		deliverErr = d.post(ctx, webhook, delivery, &record)
+		record.DurationMs = nulls.NewInt32(int32(time.Since(started).Milliseconds()))
+	}
+
+	switch {
+	case deliverErr == nil:
+		record.Status = "succeeded"
+	case !last:
+		record.Status = "pending"
+	}
+	if deliverErr != nil {
+		record.Error = nulls.NewString(deliverErr.Error())
+	}
+
+	delivery, err = d.store.RecordWebhookDeliveryAttempt(context.WithoutCancel(ctx), record)
+	if err != nil {
+		return delivery, err
+	}
+
+	return delivery, deliverErr
+}
+
+func (d *Deliverer) post(ctx context.Context, webhook database.Webhook, delivery database.WebhookDelivery, record *database.RecordWebhookDeliveryAttemptParams) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "AwesomeMY-Webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.Uuid.String())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	record.ResponseStatus = nulls.NewInt32(int32(resp.StatusCode))
+	record.ResponseBody = nulls.NewString(string(bytes.ToValidUTF8(body, nil)))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("the webhook answered with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Register has the worker run the deliveries, and prune them daily.
+func Register(w *jobs.Worker, d *Deliverer) error {
+	jobs.Handle(w, DeliverJob, func(ctx context.Context, p DeliverPayload) error {
+		delivery, err := d.Deliver(ctx, p.DeliveryID)
+		if errors.Is(err, store.ErrNotFound) {
+			// The webhook was deleted along with its deliveries.
+			return nil
+		}
+		if err != nil && delivery.Status == "failed" {
+			// The delivery log keeps the failure, which is redelivered by hand.
+			d.logger.Warn("webhook delivery failed", slog.Int("webhook_delivery_id", int(delivery.WebhookDeliveryID)), slog.Any("err", err))
+			return nil
+		}
+
+		return err
+	})
+
+	jobs.Handle(w, PruneDeliveriesJob, func(ctx context.Context, _ struct{}) error {
+		n, err := d.store.DeleteWebhookDeliveries(ctx, time.Now().Add(-d.retention))
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			d.logger.Info("deleted webhook deliveries", slog.Int64("count", n))
+		}
+
+		return nil
+	})
+
+	return jobs.Schedule(w, "@daily", PruneDeliveriesJob, struct{}{})
+}
+
+// ValidEventTypes reports whether every event type can be subscribed to.
+func ValidEventTypes(eventTypes []string) bool {
+	for _, t := range eventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return false
+		}
+	}
+
+	return true
+}
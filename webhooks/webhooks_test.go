@@ -0,0 +1,179 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awesome-my/backend"
+	"github.com/awesome-my/backend/database"
+	"github.com/awesome-my/backend/jobs"
+	"github.com/awesome-my/backend/store"
+	"github.com/awesome-my/backend/webhooks"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver serves the webhooks under test, answering with status and recording the
+// requests it receives.
+func newReceiver(t *testing.T, status int) (*httptest.Server, func() []received) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, received{header: r.Header.Clone(), body: body})
+		mu.Unlock()
+
+		w.WriteHeader(status)
+		w.Write([]byte("thanks"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]received(nil), requests...)
+	}
+}
+
+func insertWebhook(t *testing.T, st store.WebhookStore, url string, userID int32, site bool, eventTypes ...string) database.Webhook {
+	t.Helper()
+
+	webhook, err := st.InsertWebhook(context.Background(), database.InsertWebhookParams{
+		Url:        url,
+		Secret:     "s3cr3t",
+		EventTypes: eventTypes,
+		Site:       site,
+		Active:     true,
+		UserID:     userID,
+	})
+	if err != nil {
+		t.Fatalf("could not insert webhook: %v", err)
+	}
+
+	return webhook
+}
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestDispatchDeliversSignedPayloads(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	server, requests := newReceiver(t, http.StatusNoContent)
+
+	insertWebhook(t, st, server.URL+"/alice", 1, false, webhooks.EventProjectCreated)
+	insertWebhook(t, st, server.URL+"/alice-events", 1, false, webhooks.EventEventCreated)
+	insertWebhook(t, st, server.URL+"/bob", 2, false, webhooks.EventProjectCreated)
+	insertWebhook(t, st, server.URL+"/admin", 3, true, webhooks.EventProjectCreated)
+
+	if err := webhooks.Dispatch(ctx, st, webhooks.EventProjectCreated, 1, map[string]string{"name": "Kedai"}); err != nil {
+		t.Fatalf("could not dispatch: %v", err)
+	}
+
+	w := jobs.NewWorker(logger, awesomemy.JobsConfig{}, st)
+	if err := webhooks.Register(w, webhooks.NewDeliverer(logger, awesomemy.WebhooksConfig{AllowPrivateNetworks: true}, st)); err != nil {
+		t.Fatalf("could not register webhooks: %v", err)
+	}
+	if n, err := w.Work(ctx, time.Now(), 10); err != nil || n != 2 {
+		t.Fatalf("got %d, %v, want 2 deliveries claimed", n, err)
+	}
+
+	// Only the webhook of alice and the site webhook are subscribed.
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	for _, req := range got {
+		if sig := req.header.Get(webhooks.SignatureHeader); sig != webhooks.Sign("s3cr3t", req.body) {
+			t.Errorf("got signature %q, want the body signed with the secret", sig)
+		}
+		if event := req.header.Get(webhooks.EventHeader); event != webhooks.EventProjectCreated {
+			t.Errorf("got event %q, want %q", event, webhooks.EventProjectCreated)
+		}
+
+		var payload struct {
+			ID    string            `json:"id"`
+			Event string            `json:"event"`
+			Data  map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal(req.body, &payload); err != nil {
+			t.Fatalf("could not decode payload: %v", err)
+		}
+		if payload.ID != req.header.Get(webhooks.DeliveryHeader) || payload.Data["name"] != "Kedai" {
+			t.Errorf("got %+v, want the delivery of the project", payload)
+		}
+	}
+
+	for id := int32(1); id <= 2; id++ {
+		delivery, err := st.WebhookDeliveryByID(ctx, id)
+		if err != nil {
+			t.Fatalf("could not fetch delivery: %v", err)
+		}
+		if delivery.Status != "succeeded" || delivery.ResponseStatus.Int32 != http.StatusNoContent || delivery.Attempts != 1 {
+			t.Errorf("got %+v, want a delivery succeeded at its first attempt", delivery)
+		}
+	}
+}
+
+func TestDeliverFailsAfterLastAttempt(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	server, _ := newReceiver(t, http.StatusInternalServerError)
+
+	webhook := insertWebhook(t, st, server.URL, 1, false, webhooks.EventProjectCreated)
+	delivery, err := webhooks.InsertDelivery(ctx, st, webhook, webhooks.EventProjectCreated, nil)
+	if err != nil {
+		t.Fatalf("could not insert delivery: %v", err)
+	}
+
+	d := webhooks.NewDeliverer(logger, awesomemy.WebhooksConfig{AllowPrivateNetworks: true}, st)
+	for attempt := 1; attempt <= webhooks.MaxAttempts; attempt++ {
+		delivery, err = d.Deliver(ctx, delivery.WebhookDeliveryID)
+		if err == nil {
+			t.Fatalf("attempt %d: got no error, want the status reported", attempt)
+		}
+
+		want := "pending"
+		if attempt == webhooks.MaxAttempts {
+			want = "failed"
+		}
+		if delivery.Status != want || delivery.Attempts != int32(attempt) || delivery.ResponseStatus.Int32 != http.StatusInternalServerError || delivery.ResponseBody.String != "thanks" {
+			t.Fatalf("attempt %d: got %+v, want a %s delivery", attempt, delivery, want)
+		}
+	}
+}
+
+func TestDeliverRefusesPrivateNetworks(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	server, requests := newReceiver(t, http.StatusOK)
+
+	webhook := insertWebhook(t, st, server.URL, 1, false, webhooks.EventProjectCreated)
+	delivery, err := webhooks.InsertDelivery(ctx, st, webhook, webhooks.EventPing, nil)
+	if err != nil {
+		t.Fatalf("could not insert delivery: %v", err)
+	}
+
+	delivery, err = webhooks.NewDeliverer(logger, awesomemy.WebhooksConfig{}, st).Deliver(ctx, delivery.WebhookDeliveryID)
+	if err == nil || !strings.Contains(err.Error(), "non-public address") {
+		t.Fatalf("got %v, want the loopback address refused", err)
+	}
+	if delivery.Status != "failed" || len(requests()) != 0 {
+		t.Errorf("got %+v, want the ping failed without a request", delivery)
+	}
+}